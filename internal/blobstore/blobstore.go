@@ -0,0 +1,45 @@
+// Package blobstore 对象存储抽象，用于保存数据导出归档等二进制文件
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("blob not found")
+
+// Store 对象存储接口
+// 对象键使用 "/" 分隔的相对路径（如 "exports/<user_id>/<export_id>.zip"），不能包含 ".." 等路径穿越片段
+type Store interface {
+	// Put 写入对象，已存在时覆盖；写入失败时不会留下不完整的对象
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Open 读取对象，对象不存在时返回 [ErrNotFound]，调用方负责关闭
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete 删除对象，对象不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// Config 对象存储配置
+type Config struct {
+	// 存储类型：local（本地文件系统，多实例部署时需要挂载共享存储）
+	// 接入 S3、OSS 等对象存储时实现 [Store] 并在 [New] 中注册
+	Type string `json:"Type,default=local,options=local"`
+
+	// local 存储的根目录
+	LocalDir string `json:"LocalDir,default=data/blobs"`
+}
+
+// New 根据配置创建对象存储
+func New(conf Config) (Store, error) {
+	switch conf.Type {
+	case "", "local":
+		return NewLocalStore(conf.LocalDir)
+	default:
+		return nil, fmt.Errorf("unsupported blob store type %q", conf.Type)
+	}
+}
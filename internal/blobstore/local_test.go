@@ -0,0 +1,95 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(ctx, "exports/u1/e1.zip", strings.NewReader("hello")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	// 覆盖已有对象
+	if err := store.Put(ctx, "exports/u1/e1.zip", strings.NewReader("world")); err != nil {
+		t.Fatalf("overwrite: %v", err)
+	}
+
+	r, err := store.Open(ctx, "exports/u1/e1.zip")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "world" {
+		t.Errorf("content = %q, want %q", data, "world")
+	}
+
+	if err := store.Delete(ctx, "exports/u1/e1.zip"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := store.Open(ctx, "exports/u1/e1.zip"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("open after delete: err = %v, want ErrNotFound", err)
+	}
+	// 删除不存在的对象不报错
+	if err := store.Delete(ctx, "exports/u1/e1.zip"); err != nil {
+		t.Errorf("delete missing: %v", err)
+	}
+
+	// 临时文件不应残留
+	entries, err := os.ReadDir(store.root + "/exports/u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("unexpected leftover files: %v", entries)
+	}
+}
+
+func TestLocalStore_FailedPutKeepsPrevious(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, "a.zip", strings.NewReader("old")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(ctx, "a.zip", io.MultiReader(strings.NewReader("new"), errReader{})); err == nil {
+		t.Fatal("expected put to fail")
+	}
+	r, err := store.Open(ctx, "a.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if data, _ := io.ReadAll(r); string(data) != "old" {
+		t.Errorf("content = %q, want previous object to be kept", data)
+	}
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b", "a/./b", "a\\b", "a/.hidden"} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x")); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
@@ -42,7 +42,13 @@ func (h *HTTPServerComponent) Start(ctx context.Context) error {
 	h.server = rest.MustNewServer(h.config.RestConf)
 
 	// 注册全局中间件
+	clientIP, err := middleware.NewClientIPMiddleware(h.config.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("failed to init client ip middleware: %w", err)
+	}
+	h.server.Use(clientIP.Handle)
 	h.server.Use(middleware.NewUserAgentMiddleware().Handle)
+	h.server.Use(middleware.NewLocaleMiddleware().Handle)
 
 	// 注册路由
 	routes.RegisterHandlers(h.server, h.svcCtx)
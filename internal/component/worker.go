@@ -3,11 +3,17 @@ package component
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"hello-gozero/internal/service/dataexport"
+	"hello-gozero/internal/service/erasure"
 	"hello-gozero/internal/svc"
 	"hello-gozero/internal/worker"
+	erasurejob "hello-gozero/internal/worker/erasure_job"
+	exportjob "hello-gozero/internal/worker/export_job"
 	kafkaconsumer "hello-gozero/internal/worker/kafka_consumer"
 	userevent "hello-gozero/internal/worker/user_event"
+	userpurge "hello-gozero/internal/worker/user_purge"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -88,6 +94,39 @@ func (w *WorkerComponent) setupWorkers() *worker.Manager {
 	)
 	manager.Register(userEventWorker)
 
+	// 注册通知分发任务 - 异步投递邮件、短信等站外通知
+	manager.Register(w.svcCtx.Notifier)
+
+	// 注册定时任务 - 彻底删除超过保留期的已删除用户
+	if purgeConf := w.svcCtx.Config.UserPurge; purgeConf.RetentionDays > 0 && purgeConf.Interval > 0 {
+		manager.Register(worker.NewScheduledWorker(
+			"user-purge",
+			time.Duration(purgeConf.Interval)*time.Second,
+			userpurge.NewPurgeDeletedUsersTask(w.svcCtx.Repository.User, purgeConf, logger),
+			logger,
+		))
+	}
+
+	// 注册定时任务 - 生成个人数据导出归档、清理过期归档
+	if exportConf := w.svcCtx.Config.Export; exportConf.PollInterval > 0 {
+		manager.Register(worker.NewScheduledWorker(
+			"data-export",
+			time.Duration(exportConf.PollInterval)*time.Second,
+			exportjob.NewDataExportTask(dataexport.NewExporter(w.svcCtx), logger),
+			logger,
+		))
+	}
+
+	// 注册定时任务 - 继续处理中途失败或中断的个人数据擦除
+	if erasureConf := w.svcCtx.Config.Erasure; erasureConf.PollInterval > 0 {
+		manager.Register(worker.NewScheduledWorker(
+			"user-erasure",
+			time.Duration(erasureConf.PollInterval)*time.Second,
+			erasurejob.NewErasureTask(erasure.NewEraser(w.svcCtx), logger),
+			logger,
+		))
+	}
+
 	// 可以注册更多的后台任务
 	// 例如：定时任务、另一个 Kafka 消费者等
 
@@ -5,17 +5,194 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+
 	"hello-gozero/infra/cache"
 	"hello-gozero/infra/database"
 	"hello-gozero/infra/queue"
+	"hello-gozero/internal/blobstore"
+	"hello-gozero/internal/notify"
+	"hello-gozero/internal/utils/password"
 
 	"github.com/zeromicro/go-zero/rest"
 )
 
 type Config struct {
 	rest.RestConf
-	Infra Infra       `json:"Infra"`
-	Pprof PprofConfig `json:"Pprof,optional"`
+	Infra  Infra         `json:"Infra"`
+	Pprof  PprofConfig   `json:"Pprof,optional"`
+	Auth   AuthConfig    `json:"Auth"`
+	Tenant TenantConfig  `json:"Tenant,optional"`
+	Notify notify.Config `json:"Notify,optional"`
+
+	TrustedProxies []string `json:"TrustedProxies,optional"` // 可信反向代理的 CIDR，仅来自这些网段的请求才采信 X-Forwarded-For
+
+	UserPurge UserPurgeConfig `json:"UserPurge,optional"` // 已删除用户的彻底清理配置
+	Export    ExportConfig    `json:"Export,optional"`    // 个人数据导出配置
+	Erasure   ErasureConfig   `json:"Erasure,optional"`   // 个人数据擦除配置
+
+	Invitation InvitationConfig `json:"Invitation,optional"` // 用户邀请配置
+	Username   UsernameConfig   `json:"Username,optional"`   // 用户名变更配置
+
+	PasswordPolicy password.PoliciesConfig `json:"PasswordPolicy"` // 密码策略，注册、修改密码与重置密码时校验新密码
+}
+
+// AuthConfig 认证配置
+type AuthConfig struct {
+	AccessSecret  string `json:"AccessSecret"`                 // 访问令牌（JWT）签名密钥，至少 32 个字符
+	AccessExpire  int64  `json:"AccessExpire,default=900"`     // 访问令牌有效期，单位秒
+	RefreshExpire int64  `json:"RefreshExpire,default=604800"` // 刷新令牌有效期，单位秒
+	Issuer        string `json:"Issuer,default=hello-gozero"`  // 令牌签发者
+
+	Password PasswordConfig `json:"Password"` // 密码哈希配置
+
+	PasswordReset VerifyCodeConfig `json:"PasswordReset,optional"` // 忘记密码（重置验证码）配置
+	EmailVerify   VerifyCodeConfig `json:"EmailVerify,optional"`   // 邮箱验证码配置
+
+	RequireEmailVerification bool `json:"RequireEmailVerification,optional"` // 填写邮箱注册的账户是否需要验证邮箱后才进入正常状态（此前为待验证状态）
+
+	Registration string `json:"Registration,default=open,options=open|invite|closed"` // 注册方式：open-开放注册，invite-仅限邀请，closed-关闭注册（也不能接受邀请）
+
+	MFA MFAConfig `json:"MFA"` // 两步验证配置
+
+	Lockout LockoutConfig `json:"Lockout,optional"` // 认证失败锁定配置
+	Admins  []string      `json:"Admins,optional"`  // 初始管理员列表（用户名只在默认租户下生效，或 "<租户标识>:<用户名>"），始终拥有管理员角色与全部权限（不依赖数据库中的角色分配），用于初始化与紧急恢复
+}
+
+// TenantConfig 多租户配置
+// 每个请求按请求头或子域名解析所属租户，用户名、邮箱、手机号只在租户内唯一，不同租户的用户相互隔离
+type TenantConfig struct {
+	Header      string `json:"Header,default=X-Tenant-ID"` // 携带租户标识的请求头
+	BaseDomain  string `json:"BaseDomain,optional"`        // 按子域名解析租户时的主域名（如 example.com，acme.example.com 解析为 acme），为空表示不按子域名解析
+	Default     string `json:"Default,default=default"`    // 请求未携带租户时使用的租户标识，为空表示必须携带租户
+	CacheExpire int64  `json:"CacheExpire,default=60"`     // 租户信息的进程内缓存有效期，单位秒，租户停用后最长经过该时长生效
+}
+
+// PasswordConfig 密码哈希配置（HMAC pepper + Argon2id / bcrypt）
+// 切换算法、调整算法参数或轮换 pepper 后，已有哈希仍可验证，用户下次登录成功时哈希自动按新配置重新生成
+// 轮换 pepper：在 Peppers 中新增一个版本并修改 PepperVersion，旧版本需要保留到所有旧哈希都已重新生成后再移除
+type PasswordConfig struct {
+	Algorithm     string         `json:"Algorithm,default=argon2id,options=argon2id|bcrypt"` // 生成新哈希使用的算法
+	Argon2        Argon2Config   `json:"Argon2,optional"`                                    // Argon2id 参数
+	BcryptCost    int            `json:"BcryptCost,default=10"`                              // bcrypt 哈希成本（4-31）
+	PepperVersion int            `json:"PepperVersion,default=1"`                            // 生成新哈希使用的 pepper 版本
+	Peppers       []PepperConfig `json:"Peppers"`                                            // 所有仍需支持验证的 pepper
+}
+
+// Argon2Config Argon2id 参数，可以通过 password 包的基准测试（BenchmarkArgon2id）为部署环境调优
+// 单次哈希占用 Memory KiB 内存，并发登录时按请求数叠加，需要结合 Pod 内存限制设置
+type Argon2Config struct {
+	Memory      uint32 `json:"Memory,default=19456"`  // 内存开销，单位 KiB
+	Time        uint32 `json:"Time,default=2"`        // 迭代次数
+	Parallelism uint8  `json:"Parallelism,default=1"` // 并行度（线程数）
+	SaltLength  uint32 `json:"SaltLength,default=16"` // 随机盐值长度，单位字节
+	KeyLength   uint32 `json:"KeyLength,default=32"`  // 输出哈希长度，单位字节
+}
+
+// PepperConfig 带版本号的 pepper
+type PepperConfig struct {
+	Version int    `json:"Version"` // 版本号，正整数
+	Key     string `json:"Key"`     // pepper 密钥，至少 32 个字符，仅保存在后端
+}
+
+// LockoutConfig 认证失败锁定配置（暴力破解防护）
+// 按用户名与客户端 IP 两个维度分别计数，失败次数达到上限后暂时锁定，锁定时长随连续锁定次数指数增长
+type LockoutConfig struct {
+	UserMaxFailures  int64 `json:"UserMaxFailures,default=5"`     // 单个用户名在时间窗口内允许的最大失败次数
+	IPMaxFailures    int64 `json:"IPMaxFailures,default=20"`      // 单个 IP 在时间窗口内允许的最大失败次数
+	FailureWindow    int64 `json:"FailureWindow,default=900"`     // 失败计数时间窗口，单位秒
+	BaseLockout      int64 `json:"BaseLockout,default=60"`        // 首次锁定时长，之后每次锁定翻倍，单位秒
+	MaxLockout       int64 `json:"MaxLockout,default=86400"`      // 单次锁定的最长时长，单位秒
+	LevelResetAfter  int64 `json:"LevelResetAfter,default=86400"` // 最近一次锁定后经过多久锁定时长重新从 BaseLockout 开始，单位秒
+	LockAccountAfter int64 `json:"LockAccountAfter,default=5"`    // 用户名连续被锁定多少次后将账户置为锁定状态（需要管理员解锁），0 表示不启用
+}
+
+// ErrInvalidLockout 认证失败锁定配置无效
+var ErrInvalidLockout = errors.New("invalid lockout config")
+
+// Validate 校验认证失败锁定配置，锁定时长为 0 时 Redis 会拒绝加锁，导致每次认证失败都返回错误
+func (c LockoutConfig) Validate() error {
+	if c.UserMaxFailures < 1 || c.IPMaxFailures < 1 {
+		return fmt.Errorf("%w: UserMaxFailures and IPMaxFailures must be at least 1", ErrInvalidLockout)
+	}
+	if c.FailureWindow <= 0 {
+		return fmt.Errorf("%w: FailureWindow must be positive", ErrInvalidLockout)
+	}
+	if c.BaseLockout <= 0 {
+		return fmt.Errorf("%w: BaseLockout must be positive", ErrInvalidLockout)
+	}
+	if c.MaxLockout < c.BaseLockout {
+		return fmt.Errorf("%w: MaxLockout(%d) is less than BaseLockout(%d)", ErrInvalidLockout, c.MaxLockout, c.BaseLockout)
+	}
+	if c.LevelResetAfter <= 0 {
+		return fmt.Errorf("%w: LevelResetAfter must be positive", ErrInvalidLockout)
+	}
+	if c.LockAccountAfter < 0 {
+		return fmt.Errorf("%w: LockAccountAfter must not be negative", ErrInvalidLockout)
+	}
+	return nil
+}
+
+// MFAConfig 两步验证（TOTP）配置
+type MFAConfig struct {
+	SecretKey            string `json:"SecretKey"`                      // TOTP 密钥加密与恢复码摘要使用的 pepper，至少 32 个字符，仅保存在后端
+	Issuer               string `json:"Issuer,default=hello-gozero"`    // 身份验证器中显示的签发者名称
+	ChallengeExpire      int64  `json:"ChallengeExpire,default=300"`    // 登录挑战有效期，单位秒
+	ChallengeMaxAttempts int64  `json:"ChallengeMaxAttempts,default=5"` // 单个登录挑战允许的最大校验失败次数，超过后需要重新登录
+	RecoveryCodes        int    `json:"RecoveryCodes,default=10"`       // 启用时生成的恢复码数量
+}
+
+// VerifyCodeConfig 一次性验证码配置
+type VerifyCodeConfig struct {
+	CodeExpire       int64 `json:"CodeExpire,default=900"`       // 验证码有效期，单位秒
+	CodeMaxAttempts  int64 `json:"CodeMaxAttempts,default=5"`    // 单个验证码允许的最大校验失败次数，超过后验证码作废
+	EmailMaxAttempts int64 `json:"EmailMaxAttempts,default=10"`  // 单个接收方在时间窗口内允许的最大校验失败次数
+	EmailMaxRequests int64 `json:"EmailMaxRequests,default=5"`   // 单个接收方在时间窗口内允许申请验证码的最大次数
+	RateLimitWindow  int64 `json:"RateLimitWindow,default=3600"` // 限流时间窗口，单位秒
+}
+
+// UserPurgeConfig 已删除用户的彻底清理配置
+// 用户删除后先软删除，保留期内可以由管理员恢复，超过保留期后由定时任务分批彻底删除（包括密码历史、两步验证等关联数据）
+type UserPurgeConfig struct {
+	RetentionDays int `json:"RetentionDays,default=30"` // 软删除用户的保留天数，0 表示不清理
+	Interval      int `json:"Interval,default=3600"`    // 清理任务的执行间隔，单位秒
+	BatchSize     int `json:"BatchSize,default=500"`    // 每批彻底删除的用户数量，每批在一个事务中完成
+}
+
+// ExportConfig 个人数据导出配置
+// 导出任务保存在数据库中，由后台任务异步生成归档并写入对象存储，通过限时签名地址下载
+type ExportConfig struct {
+	BlobStore    blobstore.Config `json:"BlobStore,optional"`       // 归档使用的对象存储
+	SigningKey   string           `json:"SigningKey,optional"`      // 下载地址的签名密钥，为空时使用 Auth.AccessSecret
+	BaseURL      string           `json:"BaseURL,optional"`         // 下载地址的前缀（如 https://api.example.com），为空时返回相对路径
+	URLExpire    int64            `json:"URLExpire,default=3600"`   // 下载地址有效期，单位秒，过期后可以重新查询任务获取新地址
+	Retention    int64            `json:"Retention,default=604800"` // 归档保留时长，单位秒，过期后删除归档；应短于 UserPurge.RetentionDays，避免用户被彻底删除后残留归档
+	PollInterval int              `json:"PollInterval,default=10"`  // 后台任务检查待处理任务的间隔，单位秒
+	JobTimeout   int64            `json:"JobTimeout,default=600"`   // 处理中的任务超过该时长未完成时视为实例已退出，由其他实例重新处理，单位秒
+	MaxAttempts  int              `json:"MaxAttempts,default=3"`    // 单个任务的最大尝试次数，用尽后标记为失败
+}
+
+// ErasureConfig 个人数据擦除配置
+// 擦除请求在接口中同步处理，中途失败或实例退出时由后台任务从中断的步骤继续
+type ErasureConfig struct {
+	PollInterval int   `json:"PollInterval,default=60"` // 后台任务检查未完成擦除的间隔，单位秒，0 表示不启动后台任务
+	JobTimeout   int64 `json:"JobTimeout,default=300"`  // 处理中的擦除超过该时长未完成时视为实例已退出，由其他实例继续处理，单位秒
+}
+
+// InvitationConfig 用户邀请配置
+// 邀请链接携带签名令牌，邀请记录保存在数据库中，撤销或接受后令牌立即失效
+type InvitationConfig struct {
+	SigningKey string `json:"SigningKey,optional"`   // 邀请令牌的签名密钥，为空时使用 Auth.AccessSecret
+	AcceptURL  string `json:"AcceptURL,optional"`    // 前端接受邀请页面的地址，邀请邮件中的链接为该地址加上 token 查询参数；为空时邮件中只包含令牌
+	Expire     int64  `json:"Expire,default=604800"` // 邀请有效期，单位秒
+}
+
+// UsernameConfig 用户名变更配置
+// 变更后原用户名在保留期内只能由原用户改回，按原用户名获取用户时重定向到当前用户名
+type UsernameConfig struct {
+	Reservation    int64 `json:"Reservation,default=2592000"`   // 原用户名保留时长，单位秒，应长于 Auth.AccessExpire，避免旧访问令牌中的用户名被其他用户占用
+	ChangeInterval int64 `json:"ChangeInterval,default=604800"` // 两次变更用户名的最短间隔，单位秒，0 表示不限制
 }
 
 // PprofConfig pprof性能分析配置
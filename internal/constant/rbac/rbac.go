@@ -0,0 +1,44 @@
+// Package rbac 角色与权限常量
+//
+// 权限编码格式为 "资源:操作:范围"，范围 any 表示可以操作任意用户；
+// 普通用户只能管理自己的账户（见路由的归属校验），不需要任何权限
+package rbac
+
+// 内置角色
+const (
+	// RoleAdmin 管理员，拥有全部权限；配置 Auth.Admins 中的用户始终拥有该角色
+	RoleAdmin = "admin"
+
+	// RoleAuditor 审计员，只能查看用户、角色与擦除进度
+	RoleAuditor = "auditor"
+)
+
+// 权限，需要与 sql/user.sql 中 t_permission 的初始数据保持一致
+const (
+	// PermUserListAny 查询用户列表
+	PermUserListAny = "user:list:any"
+
+	// PermUserUpdateAny 更新任意用户的资料
+	PermUserUpdateAny = "user:update:any"
+
+	// PermUserDeleteAny 删除任意用户
+	PermUserDeleteAny = "user:delete:any"
+
+	// PermUserStatusAny 管理任意用户的账户状态（更新状态、激活、停用、解锁）
+	PermUserStatusAny = "user:status:any"
+
+	// PermUserRestoreAny 恢复已删除的用户
+	PermUserRestoreAny = "user:restore:any"
+
+	// PermUserEraseAny 擦除任意用户的个人数据、查询擦除进度
+	PermUserEraseAny = "user:erase:any"
+
+	// PermUserInviteAny 邀请用户注册、查看与撤销邀请
+	PermUserInviteAny = "user:invite:any"
+
+	// PermRoleReadAny 查看角色定义与任意用户的角色、权限
+	PermRoleReadAny = "role:read:any"
+
+	// PermRoleAssignAny 为任意用户分配角色
+	PermRoleAssignAny = "role:assign:any"
+)
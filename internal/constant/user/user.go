@@ -1,9 +1,67 @@
 package user
 
-// 用户状态常量
+// 用户状态常量，允许的状态迁移见 [accountstatus.CanTransition]
 const (
 	// 激活
 	StatusActive = 1
 	// 禁用
 	StatusDisabled = 0
+	// 锁定（连续认证失败被多次暂时锁定后进入该状态，需要管理员解锁）
+	StatusLocked = 2
+	// 待验证（开启 Auth.RequireEmailVerification 时，注册时填写了邮箱的账户在邮箱验证通过前处于该状态）
+	StatusPendingVerification = 3
+	// 暂停（到期后自动恢复为正常状态，到期时间见 [userEntity.User.SuspendedUntil]）
+	StatusSuspended = 4
+)
+
+// 个人数据导出任务状态常量
+const (
+	// 等待处理
+	ExportStatusPending = 0
+	// 处理中（处理中的任务超时未完成时视为实例已退出，会被重新处理）
+	ExportStatusRunning = 1
+	// 已完成，可以下载
+	ExportStatusCompleted = 2
+	// 失败（重试次数用尽）
+	ExportStatusFailed = 3
+	// 已过期，归档已删除
+	ExportStatusExpired = 4
+)
+
+// 个人数据擦除状态常量
+const (
+	// 等待处理（上次处理失败时同样回到该状态，由后台任务重试）
+	ErasureStatusPending = 0
+	// 处理中（处理中的任务超时未完成时视为实例已退出，会被重新处理）
+	ErasureStatusRunning = 1
+	// 已完成
+	ErasureStatusCompleted = 2
+)
+
+// 租户状态常量
+const (
+	// 停用（请求返回 403，租户内的用户无法登录与调用接口）
+	TenantStatusDisabled = 0
+	// 正常
+	TenantStatusActive = 1
+)
+
+// 注册方式常量（配置 Auth.Registration）
+const (
+	// 开放注册，同时可以通过邀请注册
+	RegistrationOpen = "open"
+	// 仅限邀请，只能通过管理员发出的邀请注册
+	RegistrationInvite = "invite"
+	// 关闭注册，不能注册新用户，也不能接受邀请
+	RegistrationClosed = "closed"
+)
+
+// 邀请状态常量
+const (
+	// 等待接受（过期后不能再接受）
+	InvitationStatusPending = 0
+	// 已接受
+	InvitationStatusAccepted = 1
+	// 已撤销（管理员撤销，或同一邮箱被重新邀请）
+	InvitationStatusRevoked = 2
 )
@@ -0,0 +1,65 @@
+// Package auth 认证相关的请求与响应
+package auth
+
+// LoginReq 用户登录请求
+type LoginReq struct {
+	// 用户名
+	Username string `json:"username"`
+
+	// 密码
+	Password string `json:"password"`
+
+	// 设备名称（可选），用于在会话列表中区分不同设备，如 "iPhone 15"
+	Device string `json:"device,optional"`
+
+	// 客户端 IP，由 handler 从请求中提取，不从请求体解析
+	ClientIP string `json:"-"`
+}
+
+// LoginResp 用户登录响应
+// 账户启用了两步验证时不下发令牌，而是返回登录挑战，客户端需要携带挑战令牌与动态口令调用 `POST /auth/login/mfa` 完成登录
+type LoginResp struct {
+	*TokenPair
+
+	// 是否需要两步验证
+	MFARequired bool `json:"mfa_required,omitempty"`
+
+	// 登录挑战令牌，仅在需要两步验证时返回
+	MFAToken string `json:"mfa_token,omitempty"`
+
+	// 登录挑战有效期，单位秒
+	MFAExpiresIn int64 `json:"mfa_expires_in,omitempty"`
+
+	// 密码已超过密码策略的最长使用期限，客户端应引导用户修改密码
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+}
+
+// RefreshTokenReq 刷新令牌请求
+type RefreshTokenReq struct {
+	// 登录或上一次刷新时下发的刷新令牌
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshTokenResp 刷新令牌响应
+// 刷新令牌采用轮换机制，每次刷新都会下发新的刷新令牌，旧令牌立即失效
+type RefreshTokenResp struct {
+	TokenPair
+}
+
+// TokenPair 访问令牌与刷新令牌
+type TokenPair struct {
+	// 访问令牌（JWT），请求时通过 `Authorization: Bearer <token>` 携带
+	AccessToken string `json:"access_token"`
+
+	// 令牌类型，固定为 Bearer
+	TokenType string `json:"token_type"`
+
+	// 访问令牌有效期，单位秒
+	ExpiresIn int64 `json:"expires_in"`
+
+	// 刷新令牌，用于换取新的访问令牌
+	RefreshToken string `json:"refresh_token"`
+
+	// 刷新令牌有效期，单位秒
+	RefreshExpiresIn int64 `json:"refresh_expires_in"`
+}
@@ -0,0 +1,77 @@
+package auth
+
+// EnrollTOTPReq 开始绑定身份验证器请求
+type EnrollTOTPReq struct {
+	// 用户名
+	Username string `path:"username"`
+}
+
+// EnrollTOTPResp 开始绑定身份验证器响应
+// 密钥只在此处返回一次，客户端应将 otpauth URI 渲染为二维码供用户扫描
+type EnrollTOTPResp struct {
+	// base32 编码的 TOTP 密钥，用于无法扫码时手动输入
+	Secret string `json:"secret"`
+
+	// otpauth URI
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// ConfirmTOTPReq 确认绑定身份验证器请求
+type ConfirmTOTPReq struct {
+	// 用户名
+	Username string `path:"username"`
+
+	// 身份验证器显示的动态口令
+	Code string `json:"code"`
+}
+
+// ConfirmTOTPResp 确认绑定身份验证器响应
+type ConfirmTOTPResp struct {
+	// 恢复码，只在此处返回一次，用户需要妥善保存，身份验证器丢失时每个恢复码可代替动态口令使用一次
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DisableTOTPReq 关闭两步验证请求
+// 需要同时提供密码与动态口令（或恢复码），避免会话被盗用后两步验证被直接关闭
+type DisableTOTPReq struct {
+	// 用户名
+	Username string `path:"username"`
+
+	// 当前密码
+	Password string `json:"password"`
+
+	// 身份验证器显示的动态口令，与 RecoveryCode 二选一
+	Code string `json:"code,optional"`
+
+	// 恢复码，与 Code 二选一
+	RecoveryCode string `json:"recovery_code,optional"`
+
+	// 客户端 IP，由 handler 从请求中提取，不从请求体解析
+	ClientIP string `json:"-"`
+}
+
+// DisableTOTPResp 关闭两步验证响应
+type DisableTOTPResp struct{}
+
+// LoginMFAReq 两步验证登录请求
+type LoginMFAReq struct {
+	// 登录时返回的挑战令牌
+	MFAToken string `json:"mfa_token"`
+
+	// 身份验证器显示的动态口令，与 RecoveryCode 二选一
+	Code string `json:"code,optional"`
+
+	// 恢复码，与 Code 二选一
+	RecoveryCode string `json:"recovery_code,optional"`
+}
+
+// LoginMFAResp 两步验证登录响应
+type LoginMFAResp struct {
+	TokenPair
+
+	// 剩余未使用的恢复码数量，仅在使用恢复码登录时返回，用于提醒用户及时重新生成
+	RecoveryCodesRemaining *int64 `json:"recovery_codes_remaining,omitempty"`
+
+	// 密码已超过密码策略的最长使用期限，客户端应引导用户修改密码
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
+}
@@ -0,0 +1,52 @@
+package auth
+
+// LogoutReq 登出当前会话请求
+// 当前会话由访问令牌确定，无需额外参数
+type LogoutReq struct{}
+
+// LogoutResp 登出当前会话响应
+type LogoutResp struct{}
+
+// LogoutAllReq 登出所有会话请求
+type LogoutAllReq struct{}
+
+// LogoutAllResp 登出所有会话响应
+type LogoutAllResp struct {
+	// 被吊销的会话数量（包含当前会话）
+	Revoked int `json:"revoked"`
+}
+
+// ListSessionsReq 获取用户会话列表请求
+type ListSessionsReq struct {
+	// 用户名
+	Username string `path:"username"`
+}
+
+// ListSessionsResp 获取用户会话列表响应
+type ListSessionsResp struct {
+	Sessions []SessionInfo `json:"sessions"`
+}
+
+// SessionInfo 会话信息
+type SessionInfo struct {
+	// 会话 ID
+	ID string `json:"id"`
+
+	// 设备名称
+	Device string `json:"device"`
+
+	// 登录时的客户端 IP（不含端口，经可信代理转发时取 X-Forwarded-For 中最右侧的非代理地址）
+	IP string `json:"ip"`
+
+	// 登录时的 User-Agent
+	UserAgent string `json:"user_agent"`
+
+	// 创建时间（Unix 秒）
+	CreatedAt int64 `json:"created_at"`
+
+	// 最近活跃时间（Unix 秒）
+	LastSeenAt int64 `json:"last_seen_at"`
+
+	// 是否为发起本次请求的会话
+	Current bool `json:"current"`
+}
@@ -0,0 +1,32 @@
+package user
+
+// EraseUserReq 擦除用户个人数据请求（需要 user:erase:any 权限）
+type EraseUserReq struct {
+	// 用户名，路径参数，活跃用户与已删除用户均可
+	// 例如: /api/v1/users/{username}/erase
+	Username string `path:"username"`
+}
+
+// GetErasureReq 查询个人数据擦除进度请求（需要 user:erase:any 权限）
+type GetErasureReq struct {
+	// 用户 ID，路径参数（擦除后用户名已被匿名化，只能按用户 ID 查询）
+	// 例如: /api/v1/erasures/{id}
+	UserID string `path:"id"`
+}
+
+// ErasureResp 个人数据擦除进度
+type ErasureResp struct {
+	UserID string `json:"user_id"`
+	// 擦除状态：pending（等待重试）、running、completed
+	Status string `json:"status"`
+	// 已完成的步骤数与总步骤数
+	Step       int `json:"step"`
+	TotalSteps int `json:"total_steps"`
+	// 已尝试处理的次数
+	Attempts int `json:"attempts"`
+	// 最近一次失败的原因，仅在等待重试时返回
+	Error     string `json:"error,omitempty"`
+	CreatedAt string `json:"created_at"`
+	// 完成时间，仅在擦除完成后返回
+	CompletedAt string `json:"completed_at,omitempty"`
+}
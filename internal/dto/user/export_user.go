@@ -0,0 +1,47 @@
+package user
+
+// RequestDataExportReq 申请导出个人数据请求
+type RequestDataExportReq struct {
+	// 用户名，路径参数
+	// 例如: /api/v1/users/{username}/export
+	Username string `path:"username"`
+}
+
+// GetDataExportReq 查询个人数据导出任务请求
+type GetDataExportReq struct {
+	// 用户名，路径参数
+	Username string `path:"username"`
+	// 导出任务 ID，路径参数
+	// 例如: /api/v1/users/{username}/export/{id}
+	ID string `path:"id"`
+}
+
+// DownloadDataExportReq 下载个人数据导出归档请求，参数来自导出任务返回的下载地址
+type DownloadDataExportReq struct {
+	// 导出任务 ID，路径参数
+	// 例如: /api/v1/exports/{id}/download
+	ID string `path:"id"`
+	// 下载地址过期时间（Unix 秒）
+	Expires int64 `form:"expires"`
+	// 下载地址签名
+	Signature string `form:"signature"`
+}
+
+// DataExportResp 个人数据导出任务
+type DataExportResp struct {
+	ID string `json:"id"`
+	// 任务状态：pending、running、completed、failed、expired
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+
+	// 以下字段仅在任务完成后返回
+	CompletedAt string `json:"completed_at,omitempty"`
+	// 归档大小，单位字节
+	Size int64 `json:"size,omitempty"`
+	// 归档过期时间，过期后归档被删除，需要重新申请导出
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// 限时下载地址，每次查询生成新的地址
+	DownloadURL string `json:"download_url,omitempty"`
+	// 下载地址过期时间
+	DownloadURLExpiresAt string `json:"download_url_expires_at,omitempty"`
+}
@@ -10,4 +10,10 @@ type GetUserReq struct {
 
 type GetUserResp struct {
 	User User `json:"user"`
+
+	// 资源版本，由 handler 写入 ETag 响应头，更新用户信息时通过 If-Match 携带
+	ETag string `json:"-"`
+
+	// 请求的是保留期内的原用户名时为用户的当前用户名，由 handler 重定向到当前用户名，此时 User 为空
+	MovedTo string `json:"-"`
 }
@@ -0,0 +1,80 @@
+package user
+
+// CreateInvitationReq 邀请用户注册请求
+type CreateInvitationReq struct {
+	// 被邀请人邮箱，接受邀请后作为用户邮箱且视为已验证
+	Email string `json:"email"`
+	// 接受邀请后分配的角色，为空表示不分配
+	Role string `json:"role,optional"`
+	// 租户标识，为空表示请求所属的租户；不为空时必须与请求所属的租户一致
+	Tenant string `json:"tenant,optional"`
+}
+
+// Validate 校验邮箱
+func (r *CreateInvitationReq) Validate() error {
+	if r.Email == "" {
+		return RegisterUserValidationError{Field: "email", Code: "required"}
+	}
+	if !emailRegex.MatchString(r.Email) {
+		return RegisterUserValidationError{Field: "email", Code: "invalid_email", Value: r.Email}
+	}
+	return nil
+}
+
+// Invitation 邀请
+type Invitation struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Role      string `json:"role,omitempty"`
+	InvitedBy string `json:"invited_by"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ListInvitationsResp 获取等待接受的邀请响应
+type ListInvitationsResp struct {
+	Invitations []Invitation `json:"invitations"`
+}
+
+// RevokeInvitationReq 撤销邀请请求
+type RevokeInvitationReq struct {
+	// 邀请 ID，路径参数
+	// 例如: /api/v1/invitations/{id}
+	ID string `path:"id"`
+}
+
+// RevokeInvitationResp 撤销邀请响应
+type RevokeInvitationResp struct{}
+
+// AcceptInvitationReq 接受邀请请求，邮箱取自邀请
+type AcceptInvitationReq struct {
+	// 邀请令牌，路径参数，来自邀请邮件中的链接
+	// 例如: /api/v1/invitations/{token}/accept
+	Token string `path:"token"`
+
+	Username         string `json:"username"`
+	Password         string `json:"password"`
+	PhoneCountryCode string `json:"phone_country_code"`
+	PhoneNumber      string `json:"phone_number"`
+	Nickname         string `json:"nickname,optional"`
+}
+
+// RegisterReq 转换为注册请求，email 为邀请中的邮箱
+func (r *AcceptInvitationReq) RegisterReq(email string) *RegisterUserReq {
+	return &RegisterUserReq{
+		Username:         r.Username,
+		Password:         r.Password,
+		Email:            email,
+		PhoneCountryCode: r.PhoneCountryCode,
+		PhoneNumber:      r.PhoneNumber,
+		Nickname:         r.Nickname,
+	}
+}
+
+// AcceptInvitationResp 接受邀请响应
+type AcceptInvitationResp struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	// 接受邀请后分配的角色，为空表示未分配
+	Role string `json:"role,omitempty"`
+}
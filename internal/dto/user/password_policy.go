@@ -0,0 +1,82 @@
+package user
+
+import "fmt"
+
+// GetPasswordPolicyReq 获取密码策略请求
+type GetPasswordPolicyReq struct {
+	// 租户标识，为空表示请求所属的租户
+	Tenant string `form:"tenant,optional"`
+
+	// 角色，为空表示使用默认策略
+	Role string `form:"role,optional"`
+}
+
+// GetPasswordPolicyResp 获取密码策略响应，供前端渲染密码规则与实时校验
+type GetPasswordPolicyResp struct {
+	// 最小长度
+	MinLength int `json:"min_length"`
+
+	// 最大长度
+	MaxLength int `json:"max_length"`
+
+	// 是否要求数字
+	RequireDigit bool `json:"require_digit"`
+
+	// 是否要求大写字母
+	RequireUpper bool `json:"require_upper"`
+
+	// 是否要求小写字母
+	RequireLower bool `json:"require_lower"`
+
+	// 是否要求特殊符号
+	RequireSymbol bool `json:"require_symbol"`
+
+	// 特殊符号字符集
+	Symbols string `json:"symbols"`
+
+	// 是否禁止简单密码（纯数字/纯字母、连续字符、重复字符）
+	BanSimple bool `json:"ban_simple"`
+
+	// 是否禁止泄露密码与常见密码
+	BanBreached bool `json:"ban_breached"`
+
+	// 最低强度评分（0-4），0 表示不校验强度，评分可通过 POST /password/strength 实时获取
+	MinStrength int `json:"min_strength"`
+
+	// 不能重复使用的最近密码个数，0 表示不限制
+	HistorySize int `json:"history_size"`
+
+	// 密码最长使用天数，超过后登录响应提示需要修改密码，0 表示不过期
+	MaxAgeDays int `json:"max_age_days"`
+}
+
+// PasswordViolation 密码违反的一条约束
+type PasswordViolation struct {
+	// 稳定的违规代码，如 "too_short"
+	Code string `json:"code"`
+
+	// 违规参数，如 too_short 的 {"min": 8}
+	Params map[string]any `json:"params,omitempty"`
+
+	// 按调用方语言渲染的提示文案
+	Message string `json:"message"`
+}
+
+// PasswordValidationError 表示密码不符合密码策略，包含违反的所有约束
+type PasswordValidationError struct {
+	Field      string              // 字段名，如 "password", "new_password"
+	Violations []PasswordViolation // 违反的所有约束
+}
+
+func (e PasswordValidationError) Error() string {
+	return fmt.Sprintf("invalid field %s: weak_password", e.Field)
+}
+
+// ToMap 返回结构化数据，与 [RegisterUserValidationError.ToMap] 的格式保持一致
+func (e PasswordValidationError) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"field":      e.Field,
+		"code":       "weak_password",
+		"violations": e.Violations,
+	}
+}
@@ -0,0 +1,43 @@
+package user
+
+// EstimatePasswordStrengthReq 评估密码强度请求
+type EstimatePasswordStrengthReq struct {
+	// 待评估的密码
+	Password string `json:"password"`
+
+	// 用户名、邮箱等个人信息，密码中包含这些信息时评分更低
+	UserInputs []string `json:"user_inputs,optional"`
+}
+
+// EstimatePasswordStrengthResp 评估密码强度响应，供前端渲染实时强度条
+type EstimatePasswordStrengthResp struct {
+	// 强度评分 0-4，越高越难猜到
+	Score int `json:"score"`
+
+	// 估算的猜测次数
+	Guesses float64 `json:"guesses"`
+
+	// 猜测次数的常用对数
+	GuessesLog10 float64 `json:"guesses_log10"`
+
+	// 改进建议
+	Feedback PasswordStrengthFeedback `json:"feedback"`
+}
+
+// PasswordStrengthFeedback 密码强度反馈
+type PasswordStrengthFeedback struct {
+	// 警告，评分较高时为空
+	Warning *PasswordFeedbackItem `json:"warning,omitempty"`
+
+	// 建议，评分较高时为空
+	Suggestions []PasswordFeedbackItem `json:"suggestions"`
+}
+
+// PasswordFeedbackItem 一条反馈
+type PasswordFeedbackItem struct {
+	// 稳定的反馈代码，如 "keyboard_pattern"
+	Code string `json:"code"`
+
+	// 按调用方语言渲染的提示文案
+	Message string `json:"message"`
+}
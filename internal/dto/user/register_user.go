@@ -74,6 +74,11 @@ func (u *RegisterUserReq) Validate() error {
 }
 
 // validateUsername 校验用户名是否合法
+func (u *RegisterUserReq) validateUsername() error {
+	return validateUsername("username", u.Username)
+}
+
+// validateUsername 校验用户名是否合法，field 为错误中的字段名
 //
 // 支持：
 //   - 非空
@@ -84,25 +89,25 @@ func (u *RegisterUserReq) Validate() error {
 // 不支持
 //   - 中文字符（根据需求可添加）
 //   - emoji（根据需求可添加）
-func (u *RegisterUserReq) validateUsername() error {
-	if u.Username == "" {
-		return RegisterUserValidationError{Field: "username", Code: "required"}
+func validateUsername(field, username string) error {
+	if username == "" {
+		return RegisterUserValidationError{Field: field, Code: "required"}
 	}
 	// 黑名单校验（统一转小写避免大小写绕过）
-	if _, exists := invalidUsername[strings.ToLower(u.Username)]; exists {
+	if _, exists := invalidUsername[strings.ToLower(username)]; exists {
 		return RegisterUserValidationError{
-			Field: "username",
+			Field: field,
 			Code:  "reserved_username",
-			Value: u.Username,
+			Value: username,
 		}
 	}
-	if len(u.Username) < 3 {
-		return RegisterUserValidationError{Field: "username", Code: "too_short", Value: u.Username}
+	if len(username) < 3 {
+		return RegisterUserValidationError{Field: field, Code: "too_short", Value: username}
 	}
 	// 格式校验：只允许字母、数字、下划线、点（根据业务需求调整）
-	if !regexp.MustCompile(`^[a-zA-Z0-9_.]+$`).MatchString(u.Username) {
+	if !regexp.MustCompile(`^[a-zA-Z0-9_.]+$`).MatchString(username) {
 		// return fmt.Errorf("username can only contain letters, numbers, underscores, and dots")
-		return RegisterUserValidationError{Field: "username", Code: "invalid_format", Value: u.Username}
+		return RegisterUserValidationError{Field: field, Code: "invalid_format", Value: username}
 	}
 
 	return nil
@@ -0,0 +1,27 @@
+package user
+
+// RenameUserReq 变更用户名请求
+type RenameUserReq struct {
+	// 当前用户名，路径参数
+	// 例如: /api/v1/users/{username}/username
+	Username string `path:"username"`
+
+	// 新用户名，规则与注册相同
+	NewUsername string `json:"new_username"`
+}
+
+// Validate 校验新用户名
+func (r *RenameUserReq) Validate() error {
+	return validateUsername("new_username", r.NewUsername)
+}
+
+// RenameUserResp 变更用户名响应
+type RenameUserResp struct {
+	User User `json:"user"`
+
+	// 原用户名保留到期时间（RFC 3339），此前其他用户不能使用原用户名，按原用户名获取用户时重定向到新用户名
+	ReservedUntil string `json:"reserved_until"`
+
+	// 资源版本，由 handler 写入 ETag 响应头
+	ETag string `json:"-"`
+}
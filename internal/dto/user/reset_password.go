@@ -1,5 +1,17 @@
 package user
 
+// RequestPasswordResetReq 申请重置密码（忘记密码）请求
+type RequestPasswordResetReq struct {
+	// 账户绑定的邮箱，重置验证码将发送到该邮箱
+	Email string `json:"email"`
+}
+
+type RequestPasswordResetResp struct {
+	// 结果消息
+	// 无论邮箱是否已注册都返回相同的消息，避免通过该接口枚举已注册的邮箱
+	Message string `json:"message"`
+}
+
 type ResetPasswordReq struct {
 	// 邮箱
 	Email string `json:"email"`
@@ -17,6 +29,9 @@ type ResetPasswordResp struct {
 }
 
 type VerifyResetPasswordTokenReq struct {
+	// 邮箱
+	Email string `json:"email"`
+
 	// 重置密码的验证码
 	ResetCode string `json:"reset_code"`
 }
@@ -0,0 +1,13 @@
+package user
+
+// RestoreUserReq 恢复已删除用户请求（管理员）
+type RestoreUserReq struct {
+	// 用户名，路径参数
+	// 例如: /api/v1/users/{username}/restore
+	Username string `path:"username"`
+}
+
+// RestoreUserResp 恢复已删除用户响应
+type RestoreUserResp struct {
+	User User `json:"user"`
+}
@@ -0,0 +1,41 @@
+package user
+
+// Role 角色定义
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// ListRolesResp 获取全部角色响应
+type ListRolesResp struct {
+	Roles []Role `json:"roles"`
+}
+
+// GetUserRolesReq 获取用户角色或权限请求
+type GetUserRolesReq struct {
+	// 用户名，路径参数
+	// 例如: /api/v1/users/{username}/roles
+	Username string `path:"username"`
+}
+
+// UpdateUserRolesReq 更新用户角色请求，用请求中的角色替换用户的全部角色
+type UpdateUserRolesReq struct {
+	// 用户名，路径参数
+	// 例如: /api/v1/users/{username}/roles
+	Username string `path:"username"`
+	// 角色名称列表，为空表示移除全部角色
+	Roles []string `json:"roles"`
+}
+
+// UserRolesResp 用户角色响应
+type UserRolesResp struct {
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+}
+
+// UserPermissionsResp 用户权限响应
+type UserPermissionsResp struct {
+	Username    string   `json:"username"`
+	Permissions []string `json:"permissions"`
+}
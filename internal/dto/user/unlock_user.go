@@ -0,0 +1,14 @@
+package user
+
+// UnlockUserReq 解锁用户请求（管理员）
+type UnlockUserReq struct {
+	// 用户名，路径参数
+	// 例如: /api/v1/users/{username}/unlock
+	Username string `path:"username"`
+}
+
+// UnlockUserResp 解锁用户响应
+type UnlockUserResp struct {
+	// 解锁结果消息
+	Message string `json:"message"`
+}
@@ -10,6 +10,9 @@ type UpdatePasswordReq struct {
 
 	// 新密码
 	NewPassword string `json:"new_password"`
+
+	// 客户端 IP，由 handler 从请求中提取，不从请求体解析
+	ClientIP string `json:"-"`
 }
 
 type UpdatePasswordResp struct {
@@ -0,0 +1,38 @@
+package user
+
+// UpdateUserStatusReq 更新用户状态请求（管理员）
+type UpdateUserStatusReq struct {
+	// 用户名，路径参数
+	// 例如: /api/v1/users/{username}/status
+	Username string `path:"username"`
+
+	// 目标状态：0-禁用，1-正常，2-锁定，4-暂停（待验证状态只能由注册产生）
+	Status int `json:"status"`
+
+	// 暂停到期时间，RFC 3339 格式，目标状态为暂停时必填且必须晚于当前时间
+	SuspendedUntil string `json:"suspended_until,optional"`
+
+	// 变更原因，记录在状态变更历史中
+	Reason string `json:"reason,optional"`
+}
+
+// ChangeUserStatusReq 激活/停用用户请求（管理员）
+type ChangeUserStatusReq struct {
+	// 用户名，路径参数
+	// 例如: /api/v1/users/{username}/activate
+	Username string `path:"username"`
+
+	// 变更原因，记录在状态变更历史中
+	Reason string `json:"reason,optional"`
+}
+
+// UpdateUserStatusResp 更新用户状态响应
+type UpdateUserStatusResp struct {
+	Username string `json:"username"`
+	// 变更后的状态
+	Status int `json:"status"`
+	// 变更后的状态名称：active、disabled、locked、pending_verification、suspended
+	StatusName string `json:"status_name"`
+	// 暂停到期时间，RFC 3339 格式，仅暂停状态返回
+	SuspendedUntil string `json:"suspended_until,omitempty"`
+}
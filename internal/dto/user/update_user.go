@@ -0,0 +1,104 @@
+package user
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// phoneCountryCodeRegex 手机号区号，如 +86
+var phoneCountryCodeRegex = regexp.MustCompile(`^\+[1-9]\d{0,3}$`)
+
+// UserProfile 用户可以自行修改的资料
+type UserProfile struct {
+	Nickname         string `json:"nickname"`
+	Email            string `json:"email"`
+	PhoneCountryCode string `json:"phone_country_code"`
+	PhoneNumber      string `json:"phone_number"`
+}
+
+// Validate 校验用户资料格式，校验前去除首尾空白
+func (p *UserProfile) Validate() error {
+	p.Nickname = strings.TrimSpace(p.Nickname)
+	p.Email = strings.TrimSpace(p.Email)
+	p.PhoneCountryCode = strings.TrimSpace(p.PhoneCountryCode)
+	p.PhoneNumber = strings.TrimSpace(p.PhoneNumber)
+
+	if len([]rune(p.Nickname)) > 50 {
+		return RegisterUserValidationError{Field: "nickname", Code: "too_long", Value: p.Nickname}
+	}
+	if p.Email != "" && (len(p.Email) > 100 || !emailRegex.MatchString(p.Email)) {
+		return RegisterUserValidationError{Field: "email", Code: "invalid_email", Value: p.Email}
+	}
+	// 区号与号码必须同时提供或同时为空
+	if (p.PhoneCountryCode == "") != (p.PhoneNumber == "") {
+		return RegisterUserValidationError{Field: "phone", Code: "incomplete_phone"}
+	}
+	if p.PhoneNumber != "" {
+		if !phoneCountryCodeRegex.MatchString(p.PhoneCountryCode) {
+			return RegisterUserValidationError{Field: "phone_country_code", Code: "invalid_phone", Value: p.PhoneCountryCode}
+		}
+		if len(p.PhoneNumber) > 20 || !regexp.MustCompile(`^[0-9]+$`).MatchString(p.PhoneNumber) {
+			return RegisterUserValidationError{Field: "phone", Code: "invalid_phone", Value: p.PhoneNumber}
+		}
+	}
+	return nil
+}
+
+// UpdateUserReq 更新用户信息（完整更新）请求参数
+// 请求体为完整的用户资料，未提供的字段视为清空（已有邮箱不能清空）
+type UpdateUserReq struct {
+	// 用户名，路径参数
+	Username string `path:"username"`
+
+	// 期望的资源版本（获取用户信息时返回的 ETag），不匹配时返回 412；为空表示不校验
+	IfMatch string `header:"If-Match,optional"`
+
+	Nickname         string `json:"nickname,optional"`
+	Email            string `json:"email,optional"`
+	PhoneCountryCode string `json:"phone_country_code,optional"`
+	PhoneNumber      string `json:"phone_number,optional"`
+
+	// 当前密码，仅在本人更换邮箱时需要，更换邮箱属于敏感操作，需要再次确认身份；管理员为其他用户更换邮箱时不需要
+	Password string `json:"password,optional"`
+
+	// 客户端 IP，由 handler 从请求中提取，不从请求体解析
+	ClientIP string `json:"-"`
+}
+
+// Profile 请求中的用户资料
+func (r *UpdateUserReq) Profile() UserProfile {
+	return UserProfile{
+		Nickname:         r.Nickname,
+		Email:            r.Email,
+		PhoneCountryCode: r.PhoneCountryCode,
+		PhoneNumber:      r.PhoneNumber,
+	}
+}
+
+// PatchUserReq 部分更新用户信息请求参数，请求体为 JSON Merge Patch（RFC 7396）
+type PatchUserReq struct {
+	// 用户名，路径参数
+	Username string `path:"username"`
+
+	// 期望的资源版本，含义同 [UpdateUserReq.IfMatch]
+	IfMatch string `header:"If-Match,optional"`
+
+	// 合并补丁，由 handler 从请求体解析：字段不存在表示不修改，null 表示清空
+	// 支持的字段与 [UpdateUserReq] 的请求体相同
+	Patch map[string]json.RawMessage `json:"-"`
+
+	// 客户端 IP，由 handler 从请求中提取，不从请求体解析
+	ClientIP string `json:"-"`
+}
+
+// UpdateUserResp 更新用户信息响应参数
+type UpdateUserResp struct {
+	User User `json:"user"`
+
+	// 邮箱已更换、等待验证，新邮箱验证通过后才会生效（见 POST /users/email/verify）
+	EmailPendingVerification bool `json:"email_pending_verification,omitempty"`
+
+	// 更新后的资源版本，由 handler 写入 ETag 响应头
+	ETag string `json:"-"`
+}
@@ -4,11 +4,13 @@ package user
 type User struct {
 	Username         string `json:"username"`
 	Email            string `json:"email,omitempty"`
+	EmailVerified    bool   `json:"email_verified"`
 	PhoneCountryCode string `json:"phone_country_code,omitempty"`
 	PhoneNumber      string `json:"phone_number,omitempty"`
 	Nickname         string `json:"nickname,omitempty"`
 	Status           int    `json:"status"`
 	LastLoginTime    string `json:"lastLoginTime,omitempty"`
+	CreatedAt        string `json:"created_at"`
 }
 
 // DeleteUserReq 删除用户请求参数
@@ -20,14 +22,46 @@ type DeleteUserReq struct {
 type DeleteUserResp struct {
 }
 
+// GetUserListReq 获取用户列表请求参数
+//
+// 支持两种分页方式：
+//   - 偏移分页（默认）：按 page 与 pageSize 翻页，同时返回总数，适合管理后台
+//   - 游标分页：pagination=cursor 或携带 cursor 时启用，按上一页返回的 next_cursor 翻页，不返回总数，适合大表深度翻页
 type GetUserListReq struct {
-	Page     int    `form:"page,default=1" validate:"min=1"`
-	PageSize int    `form:"pageSize,default=10" validate:"min=1,max=100"`
+	Page     int `form:"page,default=1,range=[1:]"`
+	PageSize int `form:"pageSize,default=10,range=[1:100]"`
+
+	// 分页方式：offset（偏移分页）或 cursor（游标分页）
+	Pagination string `form:"pagination,default=offset,options=offset|cursor"`
+	// 上一页返回的 next_cursor，为空表示第一页；游标与排序方式绑定，更换排序方式后需要从第一页开始
+	Cursor string `form:"cursor,optional"`
+
+	// 用户名前缀
 	Username string `form:"username,optional"`
-	Status   int    `form:"status,optional"`
+	// 用户状态：0-禁用，1-正常，2-锁定，3-待验证，4-暂停
+	Status *int `form:"status,optional"`
+	// 创建时间范围，RFC 3339 格式，包含 created_after，不包含 created_before
+	CreatedAfter  string `form:"created_after,optional"`
+	CreatedBefore string `form:"created_before,optional"`
+	// 邮箱域名，如 example.com
+	EmailDomain string `form:"email_domain,optional"`
+
+	// 排序字段与方向，排序字段相同时按用户 ID 排序
+	Sort  string `form:"sort,default=created_at,options=created_at|updated_at|username"`
+	Order string `form:"order,default=desc,options=asc|desc"`
 }
 
+// GetUserListResp 获取用户列表响应参数
 type GetUserListResp struct {
-	Total int64  `json:"total"`
-	List  []User `json:"list"`
+	List []User `json:"list"`
+
+	// 偏移分页时返回
+	Total    *int64 `json:"total,omitempty"`
+	Page     int    `json:"page,omitempty"`
+	PageSize int    `json:"page_size"`
+
+	// 是否还有下一页
+	HasMore bool `json:"has_more"`
+	// 游标分页时返回，用于获取下一页，没有下一页时为空
+	NextCursor string `json:"next_cursor,omitempty"`
 }
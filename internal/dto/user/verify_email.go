@@ -0,0 +1,56 @@
+package user
+
+import "strings"
+
+// VerifyEmailReq 验证邮箱请求
+// 待验证的邮箱由服务端记录（注册时填写的邮箱或申请更换的新邮箱），客户端只需提交验证码
+type VerifyEmailReq struct {
+	// 邮件中收到的验证码
+	Code string `json:"code"`
+}
+
+type VerifyEmailResp struct {
+	// 验证通过的邮箱
+	Email string `json:"email"`
+}
+
+// ResendEmailVerificationReq 重新发送邮箱验证码请求
+type ResendEmailVerificationReq struct{}
+
+type ResendEmailVerificationResp struct {
+	// 结果消息
+	Message string `json:"message"`
+}
+
+// ChangeEmailReq 更换邮箱请求
+// 新邮箱验证通过之前，账户仍然使用原邮箱
+type ChangeEmailReq struct {
+	// 用户名，路径参数
+	Username string `path:"username"`
+
+	// 新邮箱
+	Email string `json:"email"`
+
+	// 当前密码，更换邮箱属于敏感操作，需要再次确认身份
+	Password string `json:"password"`
+
+	// 客户端 IP，由 handler 从请求中提取，不从请求体解析
+	ClientIP string `json:"-"`
+}
+
+type ChangeEmailResp struct {
+	// 结果消息
+	Message string `json:"message"`
+}
+
+// Validate 校验新邮箱格式
+func (r *ChangeEmailReq) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	if r.Email == "" {
+		return RegisterUserValidationError{Field: "email", Code: "required"}
+	}
+	if !emailRegex.MatchString(r.Email) {
+		return RegisterUserValidationError{Field: "email", Code: "invalid_email", Value: r.Email}
+	}
+	return nil
+}
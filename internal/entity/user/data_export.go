@@ -0,0 +1,53 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExport 个人数据导出任务，任务保存在数据库中，实例重启后由其他实例继续处理
+type DataExport struct {
+	ID     []byte `gorm:"primaryKey;type:BINARY(16);not null"`
+	UserID []byte `gorm:"type:BINARY(16);not null;column:user_id"`
+
+	Status   int8   `gorm:"type:tinyint;not null;default:0;column:status"` // 0-等待处理，1-处理中，2-已完成，3-失败，4-已过期
+	Attempts int    `gorm:"not null;default:0;column:attempts"`            // 已尝试处理的次数
+	BlobKey  string `gorm:"type:varchar(255);default:'';column:blob_key"`  // 归档在对象存储中的键，完成后有值
+	Size     int64  `gorm:"not null;default:0;column:size"`                // 归档大小，单位字节
+	Error    string `gorm:"type:varchar(255);default:'';column:error"`     // 最近一次失败的原因
+
+	StartedAt   *time.Time `gorm:"column:started_at"`   // 最近一次开始处理的时间
+	CompletedAt *time.Time `gorm:"column:completed_at"` // 完成时间
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`   // 归档过期时间，过期后删除归档
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:updated_at"`
+}
+
+// TableName specifies the table name for the DataExport model
+func (DataExport) TableName() string {
+	return "t_user_data_export"
+}
+
+// BeforeCreate GORM hook - generates UUID before creating a new export
+func (e *DataExport) BeforeCreate(tx *gorm.DB) error {
+	if len(e.ID) == 0 {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		e.ID = id[:]
+	}
+	return nil
+}
+
+// GetIDAsString 任务 ID（UUID 字符串）
+func (e *DataExport) GetIDAsString() string {
+	id, err := uuid.FromBytes(e.ID)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,33 @@
+package user
+
+import "time"
+
+// UserErasure 个人数据擦除记录，每个用户最多一条
+// 擦除分多个步骤完成，每完成一步记录进度，失败或实例退出后从下一步继续
+type UserErasure struct {
+	UserID []byte `gorm:"primaryKey;type:BINARY(16);not null;column:user_id"`
+	// 所属租户 ID，用户被彻底删除后擦除记录仍然保留，查询时按它限定租户
+	TenantID uint64 `gorm:"not null;default:1;column:tenant_id"`
+
+	Status   int8   `gorm:"type:tinyint;not null;default:0;column:status"` // 0-等待处理，1-处理中，2-已完成
+	Step     int    `gorm:"not null;default:0;column:step"`                // 已完成的步骤
+	Attempts int    `gorm:"not null;default:0;column:attempts"`            // 已尝试处理的次数
+	Error    string `gorm:"type:varchar(255);default:'';column:error"`     // 最近一次失败的原因
+
+	// 擦除前的用户名与邮箱（JSON），匿名化之后清理缓存与 Redis 中以它们为键的数据时使用，擦除完成后清空
+	Subjects string `gorm:"type:text;column:subjects" json:"-"`
+	// 操作人用户名
+	Actor string `gorm:"type:varchar(50);not null;column:actor"`
+
+	StartedAt     *time.Time `gorm:"column:started_at"`      // 最近一次开始处理的时间
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at"` // 失败后最早的重试时间
+	CompletedAt   *time.Time `gorm:"column:completed_at"`    // 完成时间
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:updated_at"`
+}
+
+// TableName specifies the table name for the UserErasure model
+func (UserErasure) TableName() string {
+	return "t_user_erasure"
+}
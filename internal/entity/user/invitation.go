@@ -0,0 +1,52 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Invitation 用户邀请，被邀请人通过邀请链接中的签名令牌接受邀请并注册，邮箱视为已验证
+type Invitation struct {
+	ID       []byte `gorm:"primaryKey;type:BINARY(16);not null"`
+	TenantID uint64 `gorm:"not null;default:1;column:tenant_id"` // 所属租户 ID，只能在该租户下接受
+
+	Email     string `gorm:"type:varchar(100);not null;column:email"`       // 被邀请人邮箱，注册后作为用户邮箱
+	Role      string `gorm:"type:varchar(50);default:'';column:role"`       // 接受后分配的角色，为空表示不分配
+	InvitedBy string `gorm:"type:varchar(50);not null;column:invited_by"`   // 邀请人用户名
+	Status    int8   `gorm:"type:tinyint;not null;default:0;column:status"` // 0-等待接受，1-已接受，2-已撤销
+	UserID    []byte `gorm:"type:BINARY(16);column:user_id"`                // 接受邀请后注册的用户 ID
+
+	ExpiresAt  time.Time  `gorm:"not null;column:expires_at"` // 过期时间
+	AcceptedAt *time.Time `gorm:"column:accepted_at"`         // 接受时间
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:updated_at"`
+}
+
+// TableName specifies the table name for the Invitation model
+func (Invitation) TableName() string {
+	return "t_user_invitation"
+}
+
+// BeforeCreate GORM hook - generates UUID before creating a new invitation
+func (i *Invitation) BeforeCreate(tx *gorm.DB) error {
+	if len(i.ID) == 0 {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		i.ID = id[:]
+	}
+	return nil
+}
+
+// GetIDAsString 邀请 ID（UUID 字符串）
+func (i *Invitation) GetIDAsString() string {
+	id, err := uuid.FromBytes(i.ID)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
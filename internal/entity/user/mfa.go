@@ -0,0 +1,36 @@
+package user
+
+import "time"
+
+// UserMFA 用户两步验证（TOTP）配置，每个用户最多一条
+type UserMFA struct {
+	UserID []byte `gorm:"primaryKey;type:BINARY(16);not null;column:user_id"`
+
+	TOTPSecret   string     `gorm:"type:varchar(255);not null;column:totp_secret" json:"-"` // 加密后的 TOTP 密钥（见 totp.SecretCipher），不在 JSON 中返回
+	Enabled      bool       `gorm:"not null;default:false;column:enabled" json:"enabled"`   // 是否已启用（完成确认后启用）
+	LastUsedStep int64      `gorm:"not null;default:0;column:last_used_step" json:"-"`      // 最近一次使用的动态口令时间步，用于防止重放
+	ConfirmedAt  *time.Time `gorm:"column:confirmed_at" json:"confirmed_at,omitempty"`      // 确认启用时间
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:updated_at" json:"updated_at"`
+}
+
+// TableName specifies the table name for the UserMFA model
+func (UserMFA) TableName() string {
+	return "t_user_mfa"
+}
+
+// MFARecoveryCode 两步验证恢复码，只保存摘要，每个恢复码只能使用一次
+type MFARecoveryCode struct {
+	ID     uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	UserID []byte     `gorm:"type:BINARY(16);not null;column:user_id"`
+	Hash   string     `gorm:"type:char(64);not null;column:code_hash"` // 恢复码摘要（十六进制 HMAC-SHA256）
+	UsedAt *time.Time `gorm:"column:used_at"`                          // 使用时间，为空表示未使用
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+}
+
+// TableName specifies the table name for the MFARecoveryCode model
+func (MFARecoveryCode) TableName() string {
+	return "t_user_mfa_recovery_code"
+}
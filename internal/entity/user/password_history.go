@@ -0,0 +1,17 @@
+package user
+
+import "time"
+
+// PasswordHistory 用户密码历史，每次设置密码时记录新密码的哈希，用于禁止重复使用最近的密码
+type PasswordHistory struct {
+	ID           uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	UserID       []byte `gorm:"type:BINARY(16);not null;column:user_id"`
+	PasswordHash string `gorm:"type:varchar(255);not null;column:password_hash"` // 密码哈希，与 t_user.password 格式相同
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+}
+
+// TableName specifies the table name for the PasswordHistory model
+func (PasswordHistory) TableName() string {
+	return "t_user_password_history"
+}
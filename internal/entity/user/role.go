@@ -0,0 +1,57 @@
+package user
+
+import "time"
+
+// Role 角色，一个角色包含一组权限
+type Role struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	Name        string `gorm:"type:varchar(50);not null;column:name"`           // 角色名称，唯一
+	Description string `gorm:"type:varchar(255);default:'';column:description"` // 角色说明
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:updated_at"`
+}
+
+// TableName specifies the table name for the Role model
+func (Role) TableName() string {
+	return "t_role"
+}
+
+// Permission 权限，编码格式为 "资源:操作:范围"（如 user:delete:any）
+type Permission struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	Code        string `gorm:"type:varchar(100);not null;column:code"`          // 权限编码，唯一
+	Description string `gorm:"type:varchar(255);default:'';column:description"` // 权限说明
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+}
+
+// TableName specifies the table name for the Permission model
+func (Permission) TableName() string {
+	return "t_permission"
+}
+
+// RolePermission 角色与权限的关联
+type RolePermission struct {
+	RoleID       uint64 `gorm:"primaryKey;not null;column:role_id"`
+	PermissionID uint64 `gorm:"primaryKey;not null;column:permission_id"`
+}
+
+// TableName specifies the table name for the RolePermission model
+func (RolePermission) TableName() string {
+	return "t_role_permission"
+}
+
+// UserRole 用户与角色的关联
+type UserRole struct {
+	UserID    []byte `gorm:"primaryKey;type:BINARY(16);not null;column:user_id"`
+	RoleID    uint64 `gorm:"primaryKey;not null;column:role_id"`
+	GrantedBy string `gorm:"type:varchar(50);not null;default:'';column:granted_by"` // 分配角色的操作人用户名
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+}
+
+// TableName specifies the table name for the UserRole model
+func (UserRole) TableName() string {
+	return "t_user_role"
+}
@@ -0,0 +1,21 @@
+package user
+
+import "time"
+
+// StatusHistory 用户状态变更记录，记录每次状态迁移的原因与操作人
+type StatusHistory struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement;column:id"`
+	UserID         []byte     `gorm:"type:BINARY(16);not null;column:user_id"`
+	FromStatus     int8       `gorm:"type:tinyint;not null;column:from_status"`
+	ToStatus       int8       `gorm:"type:tinyint;not null;column:to_status"`
+	SuspendedUntil *time.Time `gorm:"column:suspended_until"`                     // 暂停到期时间，仅在迁移到暂停状态时有值
+	Reason         string     `gorm:"type:varchar(255);default:'';column:reason"` // 变更原因
+	Actor          string     `gorm:"type:varchar(50);not null;column:actor"`     // 操作人用户名，系统自动变更为 "system"
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+}
+
+// TableName specifies the table name for the StatusHistory model
+func (StatusHistory) TableName() string {
+	return "t_user_status_history"
+}
@@ -0,0 +1,19 @@
+package user
+
+import "time"
+
+// Tenant 租户（组织），同一部署中不同租户的用户相互隔离，用户名、邮箱、手机号只在租户内唯一
+type Tenant struct {
+	ID     uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	Code   string `gorm:"type:varchar(50);not null;column:code"` // 租户标识，唯一，用于请求头与子域名
+	Name   string `gorm:"type:varchar(100);default:'';column:name"`
+	Status int8   `gorm:"type:tinyint;default:1;column:status"` // 0-停用，1-正常
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:updated_at"`
+}
+
+// TableName specifies the table name for the Tenant model
+func (Tenant) TableName() string {
+	return "t_tenant"
+}
@@ -10,17 +10,22 @@ import (
 
 // User represents a user entity. Use GORM model definitions and tags as needed.
 type User struct {
-	ID []byte `gorm:"primaryKey;type:BINARY(16);not null"`
+	ID       []byte `gorm:"primaryKey;type:BINARY(16);not null"`
+	TenantID uint64 `gorm:"not null;default:1;column:tenant_id" json:"tenant_id"` // 所属租户，用户名、邮箱、手机号只在租户内唯一
 
-	Username         string `gorm:"type:varchar(50);not null;column:username" json:"username"`
-	Password         string `gorm:"type:varchar(255);not null;column:password" json:"-"` // 不在 JSON 中返回密码
-	Email            string `gorm:"type:varchar(100);default:'';column:email" json:"email"`
-	PhoneCountryCode string `gorm:"type:varchar(10);default:'';column:phone_country_code" json:"phone_country_code"`
-	PhoneNumber      string `gorm:"type:varchar(20);default:'';column:phone_number" json:"phone_number"`
-	Nickname         string `gorm:"type:varchar(50);default:'';column:nickname" json:"nickname"`
+	Username         string     `gorm:"type:varchar(50);not null;column:username" json:"username"`
+	Password         string     `gorm:"type:varchar(255);not null;column:password" json:"-"` // 不在 JSON 中返回密码
+	Email            string     `gorm:"type:varchar(100);default:'';column:email" json:"email"`
+	EmailVerifiedAt  *time.Time `gorm:"column:email_verified_at" json:"email_verified_at,omitempty"` // 邮箱验证时间，为空表示邮箱未验证
+	PhoneCountryCode string     `gorm:"type:varchar(10);default:'';column:phone_country_code" json:"phone_country_code"`
+	PhoneNumber      string     `gorm:"type:varchar(20);default:'';column:phone_number" json:"phone_number"`
+	Nickname         string     `gorm:"type:varchar(50);default:'';column:nickname" json:"nickname"`
 
-	Status        int8       `gorm:"type:tinyint;default:1;column:status" json:"status"` // 0-禁用，1-正常
-	LastLoginTime *time.Time `gorm:"column:last_login_time" json:"last_login_time,omitempty"`
+	PasswordChangedAt *time.Time `gorm:"column:password_changed_at" json:"password_changed_at,omitempty"` // 最后一次设置密码的时间，为空表示升级前创建且从未修改（以 CreatedAt 为准）
+
+	Status         int8       `gorm:"type:tinyint;default:1;column:status" json:"status"`      // 0-禁用，1-正常，2-锁定，3-待验证，4-暂停
+	SuspendedUntil *time.Time `gorm:"column:suspended_until" json:"suspended_until,omitempty"` // 暂停到期时间，仅在暂停状态时有值
+	LastLoginTime  *time.Time `gorm:"column:last_login_time" json:"last_login_time,omitempty"`
 
 	CreatedAt time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP;column:updated_at" json:"updated_at"`
@@ -44,6 +49,14 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// GetPasswordChangedAt 最后一次设置密码的时间，升级前创建且从未修改密码的用户以创建时间为准
+func (u *User) GetPasswordChangedAt() time.Time {
+	if u.PasswordChangedAt != nil {
+		return *u.PasswordChangedAt
+	}
+	return u.CreatedAt
+}
+
 func (u *User) GetIDAsString() string {
 	id, err := uuid.FromBytes(u.ID)
 	if err != nil {
@@ -0,0 +1,23 @@
+package user
+
+import "time"
+
+// UsernameHistory 用户名变更记录
+// 原用户名在 ReservedUntil 之前为该用户保留：其他用户不能注册或改用，按原用户名获取用户时重定向到该用户的当前用户名
+type UsernameHistory struct {
+	ID       uint64 `gorm:"primaryKey;autoIncrement;column:id"`
+	TenantID uint64 `gorm:"not null;default:1;column:tenant_id"` // 所属租户 ID，用户名只在租户内保留
+	UserID   []byte `gorm:"type:BINARY(16);not null;column:user_id"`
+
+	OldUsername   string    `gorm:"type:varchar(50);not null;column:old_username"`
+	NewUsername   string    `gorm:"type:varchar(50);not null;column:new_username"`
+	ReservedUntil time.Time `gorm:"not null;column:reserved_until"`         // 原用户名保留到期时间
+	Actor         string    `gorm:"type:varchar(50);not null;column:actor"` // 操作人用户名（变更前）
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;column:created_at"`
+}
+
+// TableName specifies the table name for the UsernameHistory model
+func (UsernameHistory) TableName() string {
+	return "t_username_history"
+}
@@ -0,0 +1,116 @@
+// Package event 用户领域事件的定义与发布
+//
+// 事件以 JSON 格式写入 Kafka，消息键为用户 ID（与用户无关的事件如 IP 锁定为空），
+// 同一用户的事件落在同一分区，消费方可以按顺序处理；除本服务的用户事件消费者外，安全审计等外部系统也可以订阅
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"hello-gozero/internal/tenant"
+)
+
+// 事件类型
+const (
+	// TypeConnectionTest Kafka 连接测试消息
+	TypeConnectionTest = "connection_test"
+
+	// TypeUserRegistered 用户注册
+	TypeUserRegistered = "user_registered"
+
+	// TypeUserUpdated 用户信息更新，数据：username、fields（变更的字段名，如 nickname、phone）
+	TypeUserUpdated = "user_updated"
+
+	// TypeUserDeleted 用户删除
+	TypeUserDeleted = "user_deleted"
+
+	// TypeUserErased 用户的个人数据已被擦除，数据：erased_at（Unix 时间戳）
+	// 事件不包含任何个人信息，消费方收到后应按用户 ID 删除各自保存的该用户数据（包括历史事件中的用户名等）
+	TypeUserErased = "user_erased"
+
+	// TypeUserRestored 管理员恢复已删除的用户，数据：username、operator
+	TypeUserRestored = "user_restored"
+
+	// TypeLoginLockout 连续认证失败触发暂时锁定，数据：subject（user/ip）、key、level、failures、locked_seconds、ip
+	TypeLoginLockout = "login_lockout"
+
+	// TypeUserLocked 账户被锁定（状态变为锁定，需要管理员解锁），数据：username、level、ip
+	TypeUserLocked = "user_locked"
+
+	// TypeUserUnlocked 管理员解锁账户，数据：username、operator
+	TypeUserUnlocked = "user_unlocked"
+
+	// TypeUserDataExported 个人数据导出完成，数据：username、export_id
+	TypeUserDataExported = "user_data_exported"
+
+	// TypeUserRolesChanged 用户角色变更，数据：username、roles（变更后的角色）、operator
+	TypeUserRolesChanged = "user_roles_changed"
+
+	// TypeUserInvited 管理员邀请用户注册，数据：email、role（接受后分配的角色）、operator
+	TypeUserInvited = "user_invited"
+
+	// TypeUsernameChanged 用户名变更，数据：old_username、new_username、operator
+	TypeUsernameChanged = "username_changed"
+
+	// TypeUserStatusChanged 账户状态变更，数据：username、from、to、reason、actor、suspended_until（Unix 时间戳，仅暂停时）
+	TypeUserStatusChanged = "user_status_changed"
+)
+
+// publishTimeout 单次发布的超时时间
+const publishTimeout = 3 * time.Second
+
+// UserEvent 用户事件结构
+type UserEvent struct {
+	EventType string                 `json:"event_type"` // 事件类型，见 Type* 常量
+	UserID    string                 `json:"user_id"`    // UUID 格式的用户 ID，与具体用户无关的事件为空
+	TenantID  uint64                 `json:"tenant_id"`  // 事件所属租户 ID，为空时发布方使用上下文中的租户
+	Data      map[string]interface{} `json:"data"`
+	Timestamp int64                  `json:"timestamp"`
+}
+
+// Publisher 事件发布接口
+type Publisher interface {
+	// Publish 发布事件，Timestamp 为空时使用当前时间
+	Publish(ctx context.Context, event *UserEvent) error
+}
+
+// kafkaPublisher 基于 Kafka 的 [Publisher] 实现
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher 创建基于 Kafka 的事件发布器，事件写入 writer 配置的 Topic
+func NewKafkaPublisher(writer *kafka.Writer) Publisher {
+	return &kafkaPublisher{writer: writer}
+}
+
+// Publish Implements [Publisher.Publish]
+// 请求结束不应中断已经开始的发布，因此不继承 ctx 的取消信号，只使用独立的超时时间
+func (p *kafkaPublisher) Publish(ctx context.Context, event *UserEvent) error {
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().Unix()
+	}
+	if t, ok := tenant.FromContext(ctx); ok && event.TenantID == 0 {
+		event.TenantID = t.ID
+	}
+	val, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event.EventType, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+	defer cancel()
+	err = p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.UserID),
+		Value: val,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.EventType, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	authService "hello-gozero/internal/service/auth"
+	"hello-gozero/internal/svc"
+)
+
+// ListSessionsHandler 获取用户会话列表
+// 例如，GET /users/johndoe/sessions 返回 johndoe 当前所有已登录的设备
+func ListSessionsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authDto.ListSessionsReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse list sessions request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := authService.NewListSessionsService(r.Context(), svcCtx)
+		resp, err := srv.ListSessions(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to list sessions for user(%s): %v", req.Username, err)
+			writeServiceError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package auth provides HTTP handlers for authentication operations.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	"hello-gozero/internal/handler/response"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/accountstatus"
+	authService "hello-gozero/internal/service/auth"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+)
+
+// LoginHandler 用户登录
+func LoginHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authDto.LoginReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse login request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		req.ClientIP = middleware.GetClientIP(r.Context())
+
+		srv := authService.NewLoginService(r.Context(), svcCtx)
+		resp, err := srv.Login(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			// 注意：不要在日志中打印 req，避免泄露密码
+			srv.Logger.WithContext(ctx).Errorf("failed to login user(%s): %v", req.Username, err)
+			if errors.Is(err, authService.ErrInvalidCredentials) {
+				// 用户名或密码错误，返回 401 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusUnauthorized, map[string]interface{}{
+					"code": http.StatusUnauthorized,
+					"msg":  "invalid username or password",
+				})
+			} else if errors.Is(err, authService.ErrAccountDisabled) {
+				// 账户被禁用，返回 403 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+					"code": http.StatusForbidden,
+					"msg":  "account is disabled",
+				})
+			} else if errors.Is(err, authService.ErrAccountLocked) || errors.Is(err, lockout.ErrLocked) {
+				response.WriteLocked(ctx, w, err)
+			} else if errors.Is(err, authService.ErrAccountSuspended) {
+				writeSuspendedError(ctx, w, err)
+			} else {
+				// 其他未知错误，返回标准错误响应
+				httpx.ErrorCtx(ctx, w, err)
+			}
+		} else {
+			setPasswordChangeRequired(w, resp.PasswordChangeRequired)
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeSuspendedError 账户被暂停，返回 403 状态码，有到期时间时一并返回
+func writeSuspendedError(ctx context.Context, w http.ResponseWriter, err error) {
+	body := map[string]interface{}{
+		"code": http.StatusForbidden,
+		"msg":  authService.ErrAccountSuspended.Error(),
+	}
+	var suspendedErr *accountstatus.SuspendedError
+	if errors.As(err, &suspendedErr) && suspendedErr.Until != nil {
+		body["suspended_until"] = suspendedErr.Until.Format(time.RFC3339)
+	}
+	httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, body)
+}
+
+// HeaderPasswordChangeRequired 密码已过期、需要修改密码时登录响应携带的响应头，与响应体的 password_change_required 字段一致
+const HeaderPasswordChangeRequired = "X-Password-Change-Required"
+
+// setPasswordChangeRequired 密码已过期时设置 [HeaderPasswordChangeRequired] 响应头
+func setPasswordChangeRequired(w http.ResponseWriter, required bool) {
+	if required {
+		w.Header().Set(HeaderPasswordChangeRequired, "true")
+	}
+}
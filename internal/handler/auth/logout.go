@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	authService "hello-gozero/internal/service/auth"
+	"hello-gozero/internal/svc"
+)
+
+// LogoutHandler 登出当前会话
+func LogoutHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv := authService.NewLogoutService(r.Context(), svcCtx)
+		resp, err := srv.Logout(&authDto.LogoutReq{})
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to logout: %v", err)
+			writeServiceError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// LogoutAllHandler 登出所有会话
+func LogoutAllHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv := authService.NewLogoutService(r.Context(), svcCtx)
+		resp, err := srv.LogoutAll(&authDto.LogoutAllReq{})
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to logout all sessions: %v", err)
+			writeServiceError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeServiceError 将认证服务的通用错误映射为 HTTP 响应
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, authService.ErrUnauthenticated) {
+		// 未认证，返回 401 状态码
+		httpx.WriteJsonCtx(r.Context(), w, http.StatusUnauthorized, map[string]interface{}{
+			"code": http.StatusUnauthorized,
+			"msg":  "authentication required",
+		})
+		return
+	}
+	// 其他未知错误，返回标准错误响应
+	httpx.ErrorCtx(r.Context(), w, err)
+}
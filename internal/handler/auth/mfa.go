@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	"hello-gozero/internal/handler/response"
+	"hello-gozero/internal/middleware"
+	authService "hello-gozero/internal/service/auth"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+)
+
+// EnrollTOTPHandler 开始绑定身份验证器
+// 例如，POST /users/johndoe/mfa/totp 会返回新的 TOTP 密钥与 otpauth URI
+func EnrollTOTPHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authDto.EnrollTOTPReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse enroll totp request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := authService.NewMFAService(r.Context(), svcCtx)
+		resp, err := srv.EnrollTOTP(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to enroll totp for user(%s): %v", req.Username, err)
+			writeMFAError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// ConfirmTOTPHandler 确认绑定身份验证器，启用两步验证
+func ConfirmTOTPHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authDto.ConfirmTOTPReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse confirm totp request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := authService.NewMFAService(r.Context(), svcCtx)
+		resp, err := srv.ConfirmTOTP(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to confirm totp for user(%s): %v", req.Username, err)
+			writeMFAError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// DisableTOTPHandler 关闭两步验证
+func DisableTOTPHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authDto.DisableTOTPReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse disable totp request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		req.ClientIP = middleware.GetClientIP(r.Context())
+
+		srv := authService.NewMFAService(r.Context(), svcCtx)
+		resp, err := srv.DisableTOTP(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			// 注意：不要在日志中打印 req，避免泄露密码与恢复码
+			srv.Logger.WithContext(ctx).Errorf("failed to disable totp for user(%s): %v", req.Username, err)
+			writeMFAError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// LoginMFAHandler 两步验证登录
+func LoginMFAHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authDto.LoginMFAReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse mfa login request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := authService.NewLoginMFAService(r.Context(), svcCtx)
+		resp, err := srv.LoginMFA(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to login with mfa: %v", err)
+			switch {
+			case errors.Is(err, authService.ErrInvalidMFAChallenge), errors.Is(err, authService.ErrInvalidMFACode):
+				// 挑战或口令无效，返回 401 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusUnauthorized, map[string]interface{}{
+					"code": http.StatusUnauthorized,
+					"msg":  err.Error(),
+				})
+			case errors.Is(err, authService.ErrAccountDisabled):
+				// 账户被禁用，返回 403 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+					"code": http.StatusForbidden,
+					"msg":  "account is disabled",
+				})
+			case errors.Is(err, authService.ErrAccountLocked), errors.Is(err, lockout.ErrLocked):
+				response.WriteLocked(ctx, w, err)
+			case errors.Is(err, authService.ErrAccountSuspended):
+				writeSuspendedError(ctx, w, err)
+			default:
+				// 其他未知错误，返回标准错误响应
+				httpx.ErrorCtx(ctx, w, err)
+			}
+		} else {
+			setPasswordChangeRequired(w, resp.PasswordChangeRequired)
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeMFAError 将两步验证管理流程的错误映射为 HTTP 响应
+func writeMFAError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	switch {
+	case errors.Is(err, authService.ErrUserNotFound):
+		// 用户不存在错误，返回 404 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  "user not found",
+		})
+	case errors.Is(err, authService.ErrInvalidMFACode),
+		errors.Is(err, authService.ErrMFANotEnrolled),
+		errors.Is(err, authService.ErrMFANotEnabled),
+		errors.Is(err, authService.ErrPasswordMismatch):
+		// 请求参数错误，返回 400 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, authService.ErrMFAAlreadyEnabled):
+		// 两步验证已启用，返回 409 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+			"code": http.StatusConflict,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, lockout.ErrLocked):
+		response.WriteLocked(ctx, w, err)
+	default:
+		writeServiceError(w, r, err)
+	}
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	"hello-gozero/internal/handler/response"
+	authService "hello-gozero/internal/service/auth"
+	"hello-gozero/internal/svc"
+)
+
+// RefreshTokenHandler 使用刷新令牌换取新的令牌对
+func RefreshTokenHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authDto.RefreshTokenReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse refresh token request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := authService.NewRefreshTokenService(r.Context(), svcCtx)
+		resp, err := srv.RefreshToken(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to refresh token: %v", err)
+			if errors.Is(err, authService.ErrInvalidRefreshToken) {
+				// 刷新令牌无效，返回 401 状态码，客户端需要重新登录
+				httpx.WriteJsonCtx(ctx, w, http.StatusUnauthorized, map[string]interface{}{
+					"code": http.StatusUnauthorized,
+					"msg":  "invalid refresh token",
+				})
+			} else if errors.Is(err, authService.ErrSessionRevoked) {
+				// 会话在刷新期间被吊销，返回 401 状态码，客户端需要重新登录
+				httpx.WriteJsonCtx(ctx, w, http.StatusUnauthorized, map[string]interface{}{
+					"code": http.StatusUnauthorized,
+					"msg":  "session has been revoked",
+				})
+			} else if errors.Is(err, authService.ErrAccountDisabled) {
+				// 账户被禁用，返回 403 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+					"code": http.StatusForbidden,
+					"msg":  "account is disabled",
+				})
+			} else if errors.Is(err, authService.ErrAccountLocked) {
+				response.WriteLocked(ctx, w, err)
+			} else if errors.Is(err, authService.ErrAccountSuspended) {
+				writeSuspendedError(ctx, w, err)
+			} else {
+				// 其他未知错误，返回标准错误响应
+				httpx.ErrorCtx(ctx, w, err)
+			}
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
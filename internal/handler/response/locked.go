@@ -0,0 +1,34 @@
+// Package response 多个 handler 包共用的错误响应
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"hello-gozero/internal/service/lockout"
+)
+
+// WriteLocked 将锁定错误映射为 HTTP 响应，所有需要认证的接口返回相同的锁定响应
+//   - 认证失败次数过多暂时锁定（[lockout.ErrLocked]）：返回 429 状态码，并通过 Retry-After 响应头告知剩余锁定时长
+//   - 账户被锁定（状态为锁定）：返回 423 状态码，需要管理员解锁
+func WriteLocked(ctx context.Context, w http.ResponseWriter, err error) {
+	if errors.Is(err, lockout.ErrLocked) {
+		var lockedErr *lockout.LockedError
+		if errors.As(err, &lockedErr) {
+			w.Header().Set("Retry-After", strconv.FormatInt(lockedErr.RetryAfterSeconds(), 10))
+		}
+		httpx.WriteJsonCtx(ctx, w, http.StatusTooManyRequests, map[string]interface{}{
+			"code": http.StatusTooManyRequests,
+			"msg":  lockout.ErrLocked.Error(),
+		})
+		return
+	}
+	httpx.WriteJsonCtx(ctx, w, http.StatusLocked, map[string]interface{}{
+		"code": http.StatusLocked,
+		"msg":  "account is locked, please contact the administrator",
+	})
+}
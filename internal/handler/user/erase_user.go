@@ -0,0 +1,82 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// EraseUserHandler 擦除用户的个人数据（需要 user:erase:any 权限）
+// 例如，POST /users/johndoe/erase 匿名化 `johndoe` 的账户并清理各存储中的个人信息，完成时返回 200；
+// 中途失败时返回 202 与当前进度，由后台任务继续，可以通过 GET /erasures/{user_id} 查询
+func EraseUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.EraseUserReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse erase user request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewEraseUserService(r.Context(), svcCtx)
+		resp, completed, err := srv.EraseUser(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to erase user(%s): %v", req.Username, err)
+			writeErasureError(ctx, w, err)
+		} else if completed {
+			httpx.OkJsonCtx(ctx, w, resp)
+		} else {
+			httpx.WriteJsonCtx(ctx, w, http.StatusAccepted, resp)
+		}
+	}
+}
+
+// GetErasureHandler 查询个人数据擦除进度（需要 user:erase:any 权限）
+// 例如，GET /erasures/{user_id} 返回擦除状态与已完成的步骤
+func GetErasureHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.GetErasureReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse get erasure request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewEraseUserService(r.Context(), svcCtx)
+		resp, err := srv.GetErasure(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to get erasure(%s): %v", req.UserID, err)
+			writeErasureError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeErasureError 将个人数据擦除的错误映射为 HTTP 响应
+func writeErasureError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound),
+		errors.Is(err, userService.ErrErasureNotFound):
+		// 用户或擦除记录不存在（擦除后用户名已被匿名化），返回 404 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrMissingUsername):
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	default:
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
@@ -0,0 +1,121 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/service/dataexport"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// RequestDataExportHandler 申请导出个人数据
+// 例如，POST /users/johndoe/export 返回 202 与导出任务，归档由后台任务异步生成；已有未完成的任务时返回该任务
+func RequestDataExportHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.RequestDataExportReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse request data export request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewDataExportService(r.Context(), svcCtx)
+		resp, _, err := srv.RequestExport(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to request data export for user(%s): %v", req.Username, err)
+			writeDataExportError(ctx, w, err)
+		} else {
+			httpx.WriteJsonCtx(ctx, w, http.StatusAccepted, resp)
+		}
+	}
+}
+
+// GetDataExportHandler 查询个人数据导出任务
+// 例如，GET /users/johndoe/export/{id} 返回任务状态，完成后附带限时下载地址
+func GetDataExportHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.GetDataExportReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse get data export request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewDataExportService(r.Context(), svcCtx)
+		resp, err := srv.GetExport(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to get data export(%s): %v", req.ID, err)
+			writeDataExportError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// DownloadDataExportHandler 下载个人数据导出归档
+// 通过限时签名地址访问，不需要登录，例如 GET /exports/{id}/download?expires=...&signature=...
+func DownloadDataExportHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.DownloadDataExportReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse download data export request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewDataExportService(r.Context(), svcCtx)
+		archive, export, err := srv.Download(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to download data export(%s): %v", req.ID, err)
+			writeDataExportError(ctx, w, err)
+			return
+		}
+		defer archive.Close()
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="user-data-export-%s.zip"`, req.ID))
+		w.Header().Set("Content-Length", strconv.FormatInt(export.Size, 10))
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(w, archive); err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to write data export(%s): %v", req.ID, err)
+		}
+	}
+}
+
+// writeDataExportError 将个人数据导出的错误映射为响应
+func writeDataExportError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound), errors.Is(err, userService.ErrExportNotFound):
+		// 用户或导出任务不存在，返回 404 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, dataexport.ErrInvalidDownloadLink):
+		// 下载地址签名错误或已过期，返回 403 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+			"code": http.StatusForbidden,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrExportNotReady):
+		// 导出尚未完成或归档已过期，返回 409 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+			"code": http.StatusConflict,
+			"msg":  err.Error(),
+		})
+	default:
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
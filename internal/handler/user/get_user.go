@@ -3,6 +3,8 @@ package user
 import (
 	"errors"
 	"net/http"
+	"net/url"
+	"path"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
 
@@ -12,6 +14,7 @@ import (
 )
 
 // GetUserHandler 获取单个用户
+// 用户名已变更时，按保留期内的原用户名请求返回 307 并在 Location 中给出当前用户名的地址
 func GetUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req userDto.GetUserReq
@@ -40,7 +43,16 @@ func GetUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 				// 其他未知错误，返回标准错误响应
 				httpx.ErrorCtx(ctx, w, err)
 			}
+		} else if resp.MovedTo != "" {
+			// 请求的是保留期内的原用户名，重定向到当前用户名；原用户名保留期满后可能被其他用户使用，因此使用临时重定向
+			w.Header().Set("Location", path.Join(path.Dir(r.URL.Path), url.PathEscape(resp.MovedTo)))
+			httpx.WriteJsonCtx(ctx, w, http.StatusTemporaryRedirect, map[string]interface{}{
+				"code":     http.StatusTemporaryRedirect,
+				"msg":      "user has been renamed",
+				"username": resp.MovedTo,
+			})
 		} else {
+			w.Header().Set("ETag", resp.ETag)
 			httpx.OkJsonCtx(ctx, w, resp)
 		}
 	}
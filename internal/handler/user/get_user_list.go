@@ -4,6 +4,7 @@
 package user
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
@@ -18,6 +19,7 @@ func GetUserListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req userDto.GetUserListReq
 		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse get user list request: %v", err)
 			httpx.ErrorCtx(r.Context(), w, err)
 			return
 		}
@@ -26,10 +28,19 @@ func GetUserListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		resp, err := l.GetUserList(&req)
 		ctx := l.GetCtx() // 使用服务层的上下文以包含日志字段
 		if err != nil {
-			l.Logger.WithContext(ctx).Errorf("failed to get userlist: %v", err)
-			httpx.ErrorCtx(r.Context(), w, err)
+			l.Logger.WithContext(ctx).Errorf("failed to get user list: %v", err)
+			if errors.Is(err, userService.ErrInvalidListQuery) || errors.Is(err, userService.ErrInvalidCursor) {
+				// 查询参数或游标无效，返回 400 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+					"code": http.StatusBadRequest,
+					"msg":  err.Error(),
+				})
+			} else {
+				// 其他未知错误，返回标准错误响应
+				httpx.ErrorCtx(ctx, w, err)
+			}
 		} else {
-			httpx.OkJsonCtx(r.Context(), w, resp)
+			httpx.OkJsonCtx(ctx, w, resp)
 		}
 	}
 }
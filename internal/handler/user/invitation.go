@@ -0,0 +1,157 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// CreateInvitationHandler 邀请用户注册
+// 例如，POST /invitations 请求体 {"email": "jane@example.com", "role": "auditor"} 向该邮箱发送邀请链接
+func CreateInvitationHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.CreateInvitationReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse create invitation request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		if err := req.Validate(); err != nil {
+			var v userDto.RegisterUserValidationError
+			if errors.As(err, &v) {
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusBadRequest, map[string]interface{}{"error": v.ToMap()})
+			} else {
+				httpx.ErrorCtx(r.Context(), w, err)
+			}
+			return
+		}
+
+		srv := userService.NewUserInvitationService(r.Context(), svcCtx)
+		resp, err := srv.CreateInvitation(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to create invitation for %s: %v", req.Email, err)
+			writeInvitationError(ctx, w, err)
+		} else {
+			httpx.WriteJsonCtx(ctx, w, http.StatusCreated, resp)
+		}
+	}
+}
+
+// ListInvitationsHandler 获取等待接受的邀请
+// 例如，GET /invitations 返回当前租户内未过期且尚未接受的邀请
+func ListInvitationsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv := userService.NewUserInvitationService(r.Context(), svcCtx)
+		resp, err := srv.ListInvitations()
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to list invitations: %v", err)
+			httpx.ErrorCtx(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// RevokeInvitationHandler 撤销邀请
+// 例如，DELETE /invitations/0190f3c2-... 撤销该邀请，邀请链接立即失效
+func RevokeInvitationHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.RevokeInvitationReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse revoke invitation request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewUserInvitationService(r.Context(), svcCtx)
+		resp, err := srv.RevokeInvitation(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to revoke invitation(%s): %v", req.ID, err)
+			writeInvitationError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// AcceptInvitationHandler 接受邀请并注册
+// 例如，POST /invitations/{token}/accept 请求体包含用户名、密码与手机号，邮箱取自邀请
+func AcceptInvitationHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.AcceptInvitationReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse accept invitation request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 与注册相同的参数校验（邮箱取自邀请，不需要校验）
+		if err := req.RegisterReq("").Validate(); err != nil {
+			var v userDto.RegisterUserValidationError
+			if errors.As(err, &v) {
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusBadRequest, map[string]interface{}{"error": v.ToMap()})
+			} else {
+				httpx.ErrorCtx(r.Context(), w, err)
+			}
+			return
+		}
+
+		srv := userService.NewUserInvitationService(r.Context(), svcCtx)
+		resp, err := srv.AcceptInvitation(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to accept invitation: %v", err)
+			if errors.Is(err, userService.ErrWeakPassword) {
+				writeWeakPasswordError(ctx, w, "password", err)
+			} else {
+				writeInvitationError(ctx, w, err)
+			}
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeInvitationError 将邀请相关的错误映射为 HTTP 响应
+func writeInvitationError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, userService.ErrInvitationNotFound):
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrInvalidInvitation),
+		errors.Is(err, userService.ErrRoleNotFound),
+		errors.Is(err, userService.ErrTenantMismatch):
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrRoleNotGrantable),
+		errors.Is(err, userService.ErrRegistrationClosed):
+		httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+			"code": http.StatusForbidden,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrUsernameExists),
+		errors.Is(err, userService.ErrUsernameReserved),
+		errors.Is(err, userService.ErrEmailExists),
+		errors.Is(err, userService.ErrPhoneExists):
+		// 用户名、邮箱或手机号已被注册，返回 409 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+			"code": http.StatusConflict,
+			"msg":  err.Error(),
+		})
+	default:
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
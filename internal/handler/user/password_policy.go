@@ -0,0 +1,34 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// GetPasswordPolicyHandler 获取密码策略
+// 例如，GET /password/policy?role=admin 会返回管理员适用的密码规则
+func GetPasswordPolicyHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.GetPasswordPolicyReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse get password policy request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewGetPasswordPolicyService(r.Context(), svcCtx)
+		resp, err := srv.GetPasswordPolicy(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to get password policy (req: %+v): %v", req, err)
+			httpx.ErrorCtx(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
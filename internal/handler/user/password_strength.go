@@ -0,0 +1,35 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// EstimatePasswordStrengthHandler 评估密码强度
+// 例如，POST /password/strength 会返回 0-4 的强度评分与改进建议，供前端渲染实时强度条
+func EstimatePasswordStrengthHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.EstimatePasswordStrengthReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse estimate password strength request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewEstimatePasswordStrengthService(r.Context(), svcCtx)
+		resp, err := srv.EstimatePasswordStrength(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			// 注意：不要在日志中打印 req，避免泄露密码
+			srv.Logger.WithContext(ctx).Errorf("failed to estimate password strength: %v", err)
+			httpx.ErrorCtx(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
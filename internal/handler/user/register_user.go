@@ -41,9 +41,17 @@ func RegisterUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		ctx := l.GetCtx() // 使用服务层的上下文以包含日志字段
 		if err != nil {
 			l.Logger.WithContext(ctx).Errorf("failed to get user: %v", err)
-			if errors.Is(err, userService.ErrUsernameExists) {
+			if errors.Is(err, userService.ErrUsernameExists) || errors.Is(err, userService.ErrUsernameReserved) {
 				// TODO: 塞入 i18n 信息
 				httpx.ErrorCtx(r.Context(), w, err)
+			} else if errors.Is(err, userService.ErrWeakPassword) {
+				writeWeakPasswordError(ctx, w, "password", err)
+			} else if errors.Is(err, userService.ErrInvitationRequired) || errors.Is(err, userService.ErrRegistrationClosed) {
+				// 未开放注册，返回 403 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+					"code": http.StatusForbidden,
+					"msg":  err.Error(),
+				})
 			} else {
 				// 默认情况，内部服务错误
 				httpx.ErrorCtx(r.Context(), w, err)
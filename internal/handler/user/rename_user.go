@@ -0,0 +1,85 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// RenameUserHandler 变更用户名
+// 例如，PUT /users/alice/username 请求体 {"new_username": "alice.w"}，之后按 alice 获取用户时重定向到 alice.w
+func RenameUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.RenameUserReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse rename user request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 参数校验
+		if err := req.Validate(); err != nil {
+			var v userDto.RegisterUserValidationError
+			if errors.As(err, &v) {
+				// 返回结构化的校验错误信息
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusBadRequest, map[string]interface{}{"error": v.ToMap()})
+			} else {
+				httpx.ErrorCtx(r.Context(), w, err)
+			}
+			return
+		}
+
+		srv := userService.NewRenameUserService(r.Context(), svcCtx)
+		resp, err := srv.RenameUser(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to rename user(%s): %v", req.Username, err)
+			writeRenameError(ctx, w, err)
+		} else {
+			w.Header().Set("ETag", resp.ETag)
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeRenameError 将变更用户名的错误映射为 HTTP 响应
+func writeRenameError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound):
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  "user not found",
+		})
+	case errors.Is(err, userService.ErrUsernameUnchanged):
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrUsernameExists),
+		errors.Is(err, userService.ErrUsernameReserved):
+		// 新用户名已被使用或仍为其他用户保留，返回 409 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+			"code": http.StatusConflict,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrPreconditionFailed):
+		// 用户名已被并发变更
+		httpx.WriteJsonCtx(ctx, w, http.StatusPreconditionFailed, map[string]interface{}{
+			"code": http.StatusPreconditionFailed,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrUsernameChangeTooSoon):
+		httpx.WriteJsonCtx(ctx, w, http.StatusTooManyRequests, map[string]interface{}{
+			"code": http.StatusTooManyRequests,
+			"msg":  err.Error(),
+		})
+	default:
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
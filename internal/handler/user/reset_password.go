@@ -1,15 +1,39 @@
 package user
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
 
 	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
 	"hello-gozero/internal/svc"
 )
 
-// ResetPasswordHandler 重制用户密码
+// RequestPasswordResetHandler 申请重置密码（忘记密码），向邮箱发送重置验证码
+func RequestPasswordResetHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.RequestPasswordResetReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse request password reset request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewResetPasswordService(r.Context(), svcCtx)
+		resp, err := srv.RequestReset(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to request password reset: %v", err)
+			writeResetPasswordError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// ResetPasswordHandler 重置用户密码
 func ResetPasswordHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req userDto.ResetPasswordReq
@@ -19,28 +43,16 @@ func ResetPasswordHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 			return
 		}
 
-		// l := userService.NewGetUserService(r.Context(), svcCtx)
-		// resp, err := l.GetUser(&req)
-		// ctx := l.GetCtx() // 使用服务层的上下文以包含日志字段
-		// if err != nil {
-		// 	l.Logger.WithContext(ctx).Errorf("failed to get user: %v", err)
-		// 	if errors.Is(err, userService.ErrMissingUsername) {
-		// 		// 用户名缺失错误，返回标准错误响应
-		// 		httpx.ErrorCtx(ctx, w, err)
-		// 	} else if errors.Is(err, userService.ErrUserNotFound) {
-		// 		// 用户不存在错误，返回 404 状态码和自定义错误信息
-		// 		w.WriteHeader(http.StatusNotFound)
-		// 		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
-		// 			"code": http.StatusNotFound,
-		// 			"msg":  "user not found",
-		// 		})
-		// 	} else {
-		// 		// 其他未知错误，返回标准错误响应
-		// 		httpx.ErrorCtx(ctx, w, err)
-		// 	}
-		// } else {
-		// 	httpx.OkJsonCtx(ctx, w, resp)
-		// }
+		srv := userService.NewResetPasswordService(r.Context(), svcCtx)
+		resp, err := srv.ResetPassword(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			// 注意：不要在日志中打印 req，避免泄露新密码与验证码
+			srv.Logger.WithContext(ctx).Errorf("failed to reset password: %v", err)
+			writeResetPasswordError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
 	}
 }
 
@@ -54,27 +66,43 @@ func VerifyResetPasswordTokenHandler(svcCtx *svc.ServiceContext) http.HandlerFun
 			return
 		}
 
-		// l := userService.NewGetUserService(r.Context(), svcCtx)
-		// resp, err := l.GetUser(&req)
-		// ctx := l.GetCtx() // 使用服务层的上下文以包含日志字段
-		// if err != nil {
-		// 	l.Logger.WithContext(ctx).Errorf("failed to get user: %v", err)
-		// 	if errors.Is(err, userService.ErrMissingUsername) {
-		// 		// 用户名缺失错误，返回标准错误响应
-		// 		httpx.ErrorCtx(ctx, w, err)
-		// 	} else if errors.Is(err, userService.ErrUserNotFound) {
-		// 		// 用户不存在错误，返回 404 状态码和自定义错误信息
-		// 		w.WriteHeader(http.StatusNotFound)
-		// 		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
-		// 			"code": http.StatusNotFound,
-		// 			"msg":  "user not found",
-		// 		})
-		// 	} else {
-		// 		// 其他未知错误，返回标准错误响应
-		// 		httpx.ErrorCtx(ctx, w, err)
-		// 	}
-		// } else {
-		// 	httpx.OkJsonCtx(ctx, w, resp)
-		// }
+		srv := userService.NewResetPasswordService(r.Context(), svcCtx)
+		resp, err := srv.VerifyResetCode(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to verify reset code: %v", err)
+			writeResetPasswordError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeResetPasswordError 将重置密码流程的错误映射为 HTTP 响应
+func writeResetPasswordError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	if errors.Is(err, userService.ErrMissingEmail) {
+		// 邮箱缺失错误，返回 400 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  "missing email",
+		})
+	} else if errors.Is(err, userService.ErrInvalidResetCode) {
+		// 验证码无效，返回 400 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  "invalid or expired reset code",
+		})
+	} else if errors.Is(err, userService.ErrWeakPassword) {
+		writeWeakPasswordError(ctx, w, "new_password", err)
+	} else if errors.Is(err, userService.ErrTooManyResetRequests) || errors.Is(err, userService.ErrTooManyResetAttempts) {
+		// 请求过于频繁，返回 429 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusTooManyRequests, map[string]interface{}{
+			"code": http.StatusTooManyRequests,
+			"msg":  err.Error(),
+		})
+	} else {
+		// 其他未知错误，返回标准错误响应
+		httpx.ErrorCtx(ctx, w, err)
 	}
 }
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// RestoreUserHandler 恢复已删除的用户（管理员）
+// 例如，POST /users/johndoe/restore 会恢复 `johndoe` 最近一次被删除的账户；删除期间用户名、邮箱或手机号已被重新注册时返回 409
+func RestoreUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.RestoreUserReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse restore user request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewRestoreUserService(r.Context(), svcCtx)
+		resp, err := srv.RestoreUser(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to restore user(%s): %v", req.Username, err)
+			switch {
+			case errors.Is(err, userService.ErrUserNotFound):
+				// 没有可恢复的已删除用户，返回 404 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+					"code": http.StatusNotFound,
+					"msg":  "deleted user not found",
+				})
+			case errors.Is(err, userService.ErrUsernameExists),
+				errors.Is(err, userService.ErrEmailExists),
+				errors.Is(err, userService.ErrPhoneExists):
+				// 用户名、邮箱或手机号已被重新注册，返回 409 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+					"code": http.StatusConflict,
+					"msg":  err.Error(),
+				})
+			default:
+				httpx.ErrorCtx(ctx, w, err)
+			}
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// ListRolesHandler 获取全部角色及其权限
+// 例如，GET /roles 返回 admin、auditor 等角色及各自包含的权限
+func ListRolesHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv := userService.NewUserRoleService(r.Context(), svcCtx)
+		resp, err := srv.ListRoles()
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to list roles: %v", err)
+			httpx.ErrorCtx(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// GetUserRolesHandler 获取用户角色
+// 例如，GET /users/johndoe/roles 返回 `johndoe` 的角色列表
+func GetUserRolesHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.GetUserRolesReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse get user roles request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewUserRoleService(r.Context(), svcCtx)
+		resp, err := srv.GetUserRoles(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to get roles of user(%s): %v", req.Username, err)
+			writeUserRoleError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// UpdateUserRolesHandler 更新用户角色
+// 例如，PUT /users/johndoe/roles 请求体 {"roles": ["auditor"]} 将 `johndoe` 的角色替换为 auditor
+func UpdateUserRolesHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.UpdateUserRolesReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse update user roles request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewUserRoleService(r.Context(), svcCtx)
+		resp, err := srv.UpdateUserRoles(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to update roles of user(%s): %v", req.Username, err)
+			writeUserRoleError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// GetUserPermissionsHandler 获取用户权限
+// 例如，GET /users/johndoe/permissions 返回 `johndoe` 通过角色拥有的全部权限
+func GetUserPermissionsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.GetUserRolesReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse get user permissions request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewUserRoleService(r.Context(), svcCtx)
+		resp, err := srv.GetUserPermissions(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to get permissions of user(%s): %v", req.Username, err)
+			writeUserRoleError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeUserRoleError 将角色管理的错误映射为 HTTP 响应
+func writeUserRoleError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound):
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  "user not found",
+		})
+	case errors.Is(err, userService.ErrMissingUsername),
+		errors.Is(err, userService.ErrRoleNotFound):
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrCannotChangeOwnRoles),
+		errors.Is(err, userService.ErrRoleNotGrantable):
+		// 防止越权，返回 403 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+			"code": http.StatusForbidden,
+			"msg":  err.Error(),
+		})
+	default:
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// UnlockUserHandler 解锁用户（管理员）
+// 例如，POST /users/johndoe/unlock 会清除 `johndoe` 的认证失败锁定，并将锁定状态的账户恢复为正常状态
+func UnlockUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.UnlockUserReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse unlock user request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewUnlockUserService(r.Context(), svcCtx)
+		resp, err := srv.UnlockUser(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to unlock user(%s): %v", req.Username, err)
+			if errors.Is(err, userService.ErrUserNotFound) {
+				// 用户不存在错误，返回 404 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+					"code": http.StatusNotFound,
+					"msg":  "user not found",
+				})
+			} else {
+				httpx.ErrorCtx(ctx, w, err)
+			}
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
@@ -1,14 +1,21 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
 
 	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/handler/response"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/service/lockout"
 	userService "hello-gozero/internal/service/user"
 	"hello-gozero/internal/svc"
+	"hello-gozero/internal/utils/password"
+	"hello-gozero/pkg/i18n"
 )
 
 // UpdatePasswordHandler 更新用户密码
@@ -16,16 +23,18 @@ func UpdatePasswordHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req userDto.UpdatePasswordReq
 		if err := httpx.Parse(r, &req); err != nil {
-			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse get user request: %v", err)
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse update password request: %v", err)
 			httpx.ErrorCtx(r.Context(), w, err)
 			return
 		}
+		req.ClientIP = middleware.GetClientIP(r.Context())
 
 		srv := userService.NewUpdatePasswordService(r.Context(), svcCtx)
 		resp, err := srv.UpdatePassword(&req)
 		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
 		if err != nil {
-			srv.Logger.WithContext(ctx).Errorf("failed to update password for user(req: %+v): %v", req, err)
+			// 注意：不要在日志中打印 req，避免泄露密码
+			srv.Logger.WithContext(ctx).Errorf("failed to update password for user(%s): %v", req.Username, err)
 			if errors.Is(err, userService.ErrMissingUsername) {
 				// 用户名缺失错误，返回标准错误响应
 				httpx.ErrorCtx(ctx, w, err)
@@ -50,6 +59,16 @@ func UpdatePasswordHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 					"code": http.StatusBadRequest,
 					"msg":  "new password cannot be the same as the old password",
 				})
+			} else if errors.Is(err, userService.ErrWeakPassword) {
+				writeWeakPasswordError(ctx, w, "new_password", err)
+			} else if errors.Is(err, lockout.ErrLocked) || errors.Is(err, accountstatus.ErrLocked) {
+				response.WriteLocked(ctx, w, err)
+			} else if errors.Is(err, accountstatus.ErrDisabled) || errors.Is(err, accountstatus.ErrSuspended) {
+				// 账户被禁用或暂停，返回 403 状态码
+				httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+					"code": http.StatusForbidden,
+					"msg":  err.Error(),
+				})
 			} else {
 				// 其他未知错误，返回标准错误响应
 				httpx.ErrorCtx(ctx, w, err)
@@ -59,3 +78,26 @@ func UpdatePasswordHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		}
 	}
 }
+
+// writeWeakPasswordError 密码不符合密码策略，返回 400 状态码与结构化的违规列表，提示文案按调用方语言渲染
+func writeWeakPasswordError(ctx context.Context, w http.ResponseWriter, field string, err error) {
+	var policyErr *password.PolicyError
+	if !errors.As(err, &policyErr) {
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+		return
+	}
+
+	locale := i18n.GetLocale(ctx)
+	v := userDto.PasswordValidationError{Field: field}
+	for _, violation := range policyErr.Violations {
+		v.Violations = append(v.Violations, userDto.PasswordViolation{
+			Code:    violation.Code,
+			Params:  violation.Params,
+			Message: violation.Message(locale),
+		})
+	}
+	httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{"error": v.ToMap()})
+}
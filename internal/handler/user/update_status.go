@@ -0,0 +1,97 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/service/accountstatus"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// UpdateUserStatusHandler 更新用户状态（管理员）
+// 例如，PUT /users/johndoe/status 请求体 {"status": 4, "suspended_until": "2026-01-01T00:00:00Z", "reason": "spam"} 会将 `johndoe` 暂停到指定时间
+func UpdateUserStatusHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.UpdateUserStatusReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse update user status request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewUpdateUserStatusService(r.Context(), svcCtx)
+		resp, err := srv.UpdateStatus(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to update status of user(%s): %v", req.Username, err)
+			writeUpdateUserStatusError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// ActivateUserHandler 激活用户（管理员）
+// 例如，PUT /users/johndoe/activate 会将 `johndoe` 恢复为正常状态
+func ActivateUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return changeUserStatusHandler(svcCtx, "activate", (*userService.UpdateUserStatusService).Activate)
+}
+
+// DeactivateUserHandler 停用用户（管理员）
+// 例如，PUT /users/johndoe/deactivate 会禁用 `johndoe` 并吊销其所有会话
+func DeactivateUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return changeUserStatusHandler(svcCtx, "deactivate", (*userService.UpdateUserStatusService).Deactivate)
+}
+
+// changeUserStatusHandler 激活/停用用户的公共处理逻辑
+func changeUserStatusHandler(svcCtx *svc.ServiceContext, action string,
+	change func(*userService.UpdateUserStatusService, *userDto.ChangeUserStatusReq) (*userDto.UpdateUserStatusResp, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.ChangeUserStatusReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse %s user request: %v", action, err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewUpdateUserStatusService(r.Context(), svcCtx)
+		resp, err := change(srv, &req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to %s user(%s): %v", action, req.Username, err)
+			writeUpdateUserStatusError(ctx, w, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeUpdateUserStatusError 将更新用户状态的错误映射为响应
+func writeUpdateUserStatusError(ctx context.Context, w http.ResponseWriter, err error) {
+	if errors.Is(err, userService.ErrUserNotFound) {
+		// 用户不存在错误，返回 404 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  "user not found",
+		})
+	} else if errors.Is(err, userService.ErrInvalidStatus) || errors.Is(err, userService.ErrMissingUsername) {
+		// 参数错误，返回 400 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	} else if errors.Is(err, accountstatus.ErrInvalidTransition) || errors.Is(err, accountstatus.ErrConflict) {
+		// 当前状态不允许迁移到目标状态或状态已被并发修改，返回 409 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+			"code": http.StatusConflict,
+			"msg":  err.Error(),
+		})
+	} else {
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
@@ -0,0 +1,119 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/middleware"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// mergePatchContentType JSON Merge Patch（RFC 7396）的媒体类型
+const mergePatchContentType = "application/merge-patch+json"
+
+// UpdateUserHandler 更新用户信息（完整更新）
+// 例如，PUT /users/johndoe 使用请求体中的完整资料替换用户当前的昵称、邮箱与手机号
+func UpdateUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.UpdateUserReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse update user request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		req.ClientIP = middleware.GetClientIP(r.Context())
+
+		srv := userService.NewUpdateUserService(r.Context(), svcCtx)
+		resp, err := srv.UpdateUser(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			// 注意：不要在日志中打印 req，避免泄露密码
+			srv.Logger.WithContext(ctx).Errorf("failed to update user(%s): %v", req.Username, err)
+			writeUpdateUserError(w, r, err)
+		} else {
+			w.Header().Set("ETag", resp.ETag)
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// PatchUserHandler 部分更新用户信息
+// 例如，PATCH /users/johndoe 请求体 {"nickname": "John", "phone_number": null} 修改昵称并清空手机号
+func PatchUserHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.PatchUserReq
+		if err := httpx.ParsePath(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		if err := httpx.ParseHeaders(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 合并补丁需要区分「字段不存在」与「字段为 null」，因此直接解析请求体，不经过 httpx.Parse
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != mergePatchContentType && mediaType != "application/json" {
+			httpx.WriteJsonCtx(r.Context(), w, http.StatusUnsupportedMediaType, map[string]interface{}{
+				"code": http.StatusUnsupportedMediaType,
+				"msg":  "content type must be " + mergePatchContentType,
+			})
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req.Patch); err != nil || req.Patch == nil {
+			httpx.WriteJsonCtx(r.Context(), w, http.StatusBadRequest, map[string]interface{}{
+				"code": http.StatusBadRequest,
+				"msg":  "request body must be a JSON object",
+			})
+			return
+		}
+		req.ClientIP = middleware.GetClientIP(r.Context())
+
+		srv := userService.NewUpdateUserService(r.Context(), svcCtx)
+		resp, err := srv.PatchUser(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			// 注意：不要在日志中打印 req，避免泄露密码
+			srv.Logger.WithContext(ctx).Errorf("failed to patch user(%s): %v", req.Username, err)
+			writeUpdateUserError(w, r, err)
+		} else {
+			w.Header().Set("ETag", resp.ETag)
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeUpdateUserError 将更新用户信息的错误映射为 HTTP 响应，更换邮箱相关的错误见 [writeEmailError]
+func writeUpdateUserError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	var v userDto.RegisterUserValidationError
+	switch {
+	case errors.As(err, &v):
+		// 返回结构化的校验错误信息
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{"error": v.ToMap()})
+	case errors.Is(err, userService.ErrPreconditionFailed):
+		// 资源版本不匹配，返回 412 状态码，客户端应重新获取后再修改
+		httpx.WriteJsonCtx(ctx, w, http.StatusPreconditionFailed, map[string]interface{}{
+			"code": http.StatusPreconditionFailed,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrEmailRequired), errors.Is(err, userService.ErrInvalidPatch):
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrPhoneExists):
+		// 手机号已被占用，返回 409 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+			"code": http.StatusConflict,
+			"msg":  "phone already exists",
+		})
+	default:
+		writeEmailError(w, r, err)
+	}
+}
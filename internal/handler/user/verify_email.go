@@ -0,0 +1,129 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/handler/response"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/lockout"
+	userService "hello-gozero/internal/service/user"
+	"hello-gozero/internal/svc"
+)
+
+// VerifyEmailHandler 使用验证码验证邮箱
+func VerifyEmailHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.VerifyEmailReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse verify email request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		srv := userService.NewVerifyEmailService(r.Context(), svcCtx)
+		resp, err := srv.VerifyEmail(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to verify email: %v", err)
+			writeEmailError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// ResendEmailVerificationHandler 重新发送邮箱验证码
+func ResendEmailVerificationHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		srv := userService.NewVerifyEmailService(r.Context(), svcCtx)
+		resp, err := srv.ResendVerification(&userDto.ResendEmailVerificationReq{})
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			srv.Logger.WithContext(ctx).Errorf("failed to resend email verification: %v", err)
+			writeEmailError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// ChangeEmailHandler 申请更换邮箱
+// 例如，PUT /users/johndoe/email 会向请求体中的新邮箱发送验证码，验证通过后生效
+func ChangeEmailHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userDto.ChangeEmailReq
+		if err := httpx.Parse(r, &req); err != nil {
+			svcCtx.Logger.WithContext(r.Context()).Errorf("failed to parse change email request: %v", err)
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 参数校验
+		if err := req.Validate(); err != nil {
+			var v userDto.RegisterUserValidationError
+			if errors.As(err, &v) {
+				// 返回结构化的校验错误信息
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusBadRequest, map[string]interface{}{"error": v.ToMap()})
+			} else {
+				httpx.ErrorCtx(r.Context(), w, err)
+			}
+			return
+		}
+
+		req.ClientIP = middleware.GetClientIP(r.Context())
+
+		srv := userService.NewChangeEmailService(r.Context(), svcCtx)
+		resp, err := srv.ChangeEmail(&req)
+		ctx := srv.GetCtx() // 使用服务层的上下文以包含日志字段
+		if err != nil {
+			// 注意：不要在日志中打印 req，避免泄露密码
+			srv.Logger.WithContext(ctx).Errorf("failed to change email for user(%s): %v", req.Username, err)
+			writeEmailError(w, r, err)
+		} else {
+			httpx.OkJsonCtx(ctx, w, resp)
+		}
+	}
+}
+
+// writeEmailError 将邮箱验证与更换邮箱流程的错误映射为 HTTP 响应
+func writeEmailError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound):
+		// 用户不存在错误，返回 404 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  "user not found",
+		})
+	case errors.Is(err, userService.ErrInvalidVerifyCode),
+		errors.Is(err, userService.ErrNothingToVerify),
+		errors.Is(err, userService.ErrEmailUnchanged),
+		errors.Is(err, userService.ErrPasswordMismatch):
+		// 请求参数错误，返回 400 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, map[string]interface{}{
+			"code": http.StatusBadRequest,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, userService.ErrEmailExists):
+		// 邮箱已被占用，返回 409 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusConflict, map[string]interface{}{
+			"code": http.StatusConflict,
+			"msg":  "email already exists",
+		})
+	case errors.Is(err, userService.ErrTooManyVerifyRequests), errors.Is(err, userService.ErrTooManyVerifyAttempts):
+		// 请求过于频繁，返回 429 状态码
+		httpx.WriteJsonCtx(ctx, w, http.StatusTooManyRequests, map[string]interface{}{
+			"code": http.StatusTooManyRequests,
+			"msg":  err.Error(),
+		})
+	case errors.Is(err, lockout.ErrLocked):
+		response.WriteLocked(ctx, w, err)
+	default:
+		// 其他未知错误，返回标准错误响应
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"github.com/zeromicro/go-zero/rest/pathvar"
+
+	"hello-gozero/internal/tenant"
+	"hello-gozero/internal/utils/token"
+)
+
+// 定义一个自定义的上下文 key 类型（非导出，避免外部冲突）
+type principalContextKey struct{}
+
+// Principal 已认证的调用方身份，由 [AuthMiddleware] 从访问令牌中解析得到
+type Principal struct {
+	// 用户 ID（UUID 字符串）
+	UserID string
+
+	// 用户名
+	Username string
+
+	// 会话 ID
+	SessionID string
+
+	// 所属租户 ID
+	TenantID uint64
+}
+
+// GetPrincipal 从给定的上下文中检索已认证的调用方身份。如果请求未经过认证，则返回 nil。
+func GetPrincipal(ctx context.Context) *Principal {
+	if val, ok := ctx.Value(principalContextKey{}).(*Principal); ok {
+		return val
+	}
+	return nil
+}
+
+// SessionChecker 会话状态检查接口
+// 访问令牌本身在有效期内始终可以通过签名校验，需要结合服务端会话状态识别已被吊销（登出）的令牌
+type SessionChecker interface {
+	// Exists 检查会话是否仍然有效
+	Exists(ctx context.Context, sessionID string) (bool, error)
+}
+
+// AuthMiddleware 是一个中间件，它校验请求头中的 Bearer 访问令牌及其所属会话，并将调用方身份存储在请求上下文中以供后续检索。
+// 令牌缺失、无效、会话已被吊销或令牌不是为请求所属租户签发时直接返回 401，不会调用后续处理器。
+// 挂载在 [TenantMiddleware] 之后时校验令牌的租户，否则不校验。
+type AuthMiddleware struct {
+	tokens   token.Manager
+	sessions SessionChecker
+}
+
+func NewAuthMiddleware(tokens token.Manager, sessions SessionChecker) *AuthMiddleware {
+	return &AuthMiddleware{
+		tokens:   tokens,
+		sessions: sessions,
+	}
+}
+
+func (m *AuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqCtx := r.Context()
+
+		rawToken, ok := bearerToken(r)
+		if !ok {
+			writeUnauthorized(reqCtx, w, "missing bearer token")
+			return
+		}
+
+		claims, err := m.tokens.ParseAccessToken(rawToken)
+		if err != nil {
+			logx.WithContext(reqCtx).Infof("rejected access token: %v", err)
+			if errors.Is(err, token.ErrTokenExpired) {
+				writeUnauthorized(reqCtx, w, "token expired")
+			} else {
+				writeUnauthorized(reqCtx, w, "invalid token")
+			}
+			return
+		}
+
+		subject := claims.Subject()
+		if subject.SessionID == "" {
+			writeUnauthorized(reqCtx, w, "invalid token")
+			return
+		}
+		if subject.TenantID == 0 {
+			// 引入多租户之前签发的令牌属于默认租户
+			subject.TenantID = tenant.DefaultID
+		}
+		// 访问令牌只能在签发时的租户内使用，避免不同租户中的同名用户互相冒用
+		if t, ok := tenant.FromContext(reqCtx); ok && t.ID != subject.TenantID {
+			writeUnauthorized(reqCtx, w, "token issued for another tenant")
+			return
+		}
+		alive, err := m.sessions.Exists(reqCtx, subject.SessionID)
+		if err != nil {
+			// 无法确认会话状态时拒绝请求（fail closed）
+			logx.WithContext(reqCtx).Errorf("failed to check session(%s): %v", subject.SessionID, err)
+			httpx.ErrorCtx(reqCtx, w, err)
+			return
+		}
+		if !alive {
+			writeUnauthorized(reqCtx, w, "session revoked")
+			return
+		}
+
+		principal := &Principal{
+			UserID:    subject.UserID,
+			Username:  subject.Username,
+			SessionID: subject.SessionID,
+			TenantID:  subject.TenantID,
+		}
+		ctx := context.WithValue(reqCtx, principalContextKey{}, principal)
+		ctx = logx.ContextWithFields(ctx, logx.Field("user_id", principal.UserID))
+		newReq := r.WithContext(ctx)
+
+		// Passthrough to next handler
+		next(w, newReq)
+	}
+}
+
+// OwnerResolver 路径参数中的用户名查询接口
+// 访问令牌中的用户名在变更用户名之后、刷新令牌之前已经过时，归属校验按用户 ID 比较，不依赖令牌中的用户名
+type OwnerResolver interface {
+	// ResolveUserID 获取用户名在请求所属租户内对应的用户 ID（UUID 字符串），用户不存在时返回空字符串
+	ResolveUserID(ctx context.Context, username string) (string, error)
+}
+
+// OwnerMiddleware 是一个中间件，它要求已认证的调用方就是路径参数所指向的用户本人，否则返回 403。
+// 必须挂载在 [AuthMiddleware] 之后。
+type OwnerMiddleware struct {
+	resolver OwnerResolver
+
+	// 路径参数名，如 "username" 对应路由中的 `:username`
+	pathVar string
+}
+
+func NewOwnerMiddleware(resolver OwnerResolver, pathVar string) *OwnerMiddleware {
+	return &OwnerMiddleware{resolver: resolver, pathVar: pathVar}
+}
+
+func (m *OwnerMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqCtx := r.Context()
+		principal := GetPrincipal(reqCtx)
+		if principal == nil {
+			writeUnauthorized(reqCtx, w, "authentication required")
+			return
+		}
+
+		owner, err := isOwner(reqCtx, m.resolver, principal, pathvar.Vars(r)[m.pathVar])
+		if err != nil {
+			// 无法确认归属时拒绝请求（fail closed）
+			logx.WithContext(reqCtx).Errorf("failed to resolve owner(%s): %v", pathvar.Vars(r)[m.pathVar], err)
+			httpx.ErrorCtx(reqCtx, w, err)
+			return
+		}
+		if !owner {
+			writeForbidden(reqCtx, w, "you can only manage your own account")
+			return
+		}
+
+		// Passthrough to next handler
+		next(w, r)
+	}
+}
+
+// isOwner 判断路径参数中的用户名是否指向调用方本人
+func isOwner(ctx context.Context, resolver OwnerResolver, principal *Principal, username string) (bool, error) {
+	if username == "" || principal.UserID == "" {
+		return false, nil
+	}
+	userID, err := resolver.ResolveUserID(ctx, username)
+	if err != nil {
+		return false, err
+	}
+	return userID == principal.UserID, nil
+}
+
+// PermissionChecker 权限查询接口
+type PermissionChecker interface {
+	// HasPermission 判断已认证的调用方是否拥有指定权限
+	HasPermission(ctx context.Context, principal *Principal, permission string) (bool, error)
+}
+
+// PermissionMiddleware 是一个中间件，它要求已认证的调用方拥有指定权限，否则返回 403。
+// 设置了 ownerPathVar 时，路径参数所指向的用户本人（按用户 ID 判断，见 [OwnerResolver]）不需要权限即可访问
+// （本人管理自己，拥有权限的调用方管理任意用户）。
+// 必须挂载在 [AuthMiddleware] 之后。
+type PermissionMiddleware struct {
+	checker    PermissionChecker
+	permission string
+
+	// 路径参数名，为空表示不允许本人免权限访问
+	ownerPathVar string
+
+	// 设置了 ownerPathVar 时用于判断本人
+	resolver OwnerResolver
+}
+
+// NewPermissionMiddleware 创建权限校验中间件，ownerPathVar 为空时 resolver 可以为 nil
+func NewPermissionMiddleware(checker PermissionChecker, resolver OwnerResolver, permission, ownerPathVar string) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		checker:      checker,
+		permission:   permission,
+		ownerPathVar: ownerPathVar,
+		resolver:     resolver,
+	}
+}
+
+func (m *PermissionMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqCtx := r.Context()
+		principal := GetPrincipal(reqCtx)
+		if principal == nil {
+			writeUnauthorized(reqCtx, w, "authentication required")
+			return
+		}
+
+		if m.ownerPathVar != "" {
+			owner, err := isOwner(reqCtx, m.resolver, principal, pathvar.Vars(r)[m.ownerPathVar])
+			if err != nil {
+				// 无法确认归属时拒绝请求（fail closed）
+				logx.WithContext(reqCtx).Errorf("failed to resolve owner(%s): %v", pathvar.Vars(r)[m.ownerPathVar], err)
+				httpx.ErrorCtx(reqCtx, w, err)
+				return
+			}
+			if owner {
+				next(w, r)
+				return
+			}
+		}
+
+		allowed, err := m.checker.HasPermission(reqCtx, principal, m.permission)
+		if err != nil {
+			// 无法确认权限时拒绝请求（fail closed）
+			logx.WithContext(reqCtx).Errorf("failed to check permission(%s): %v", m.permission, err)
+			httpx.ErrorCtx(reqCtx, w, err)
+			return
+		}
+		if !allowed {
+			if m.ownerPathVar != "" {
+				writeForbidden(reqCtx, w, "you can only manage your own account")
+			} else {
+				writeForbidden(reqCtx, w, "permission "+m.permission+" required")
+			}
+			return
+		}
+
+		// Passthrough to next handler
+		next(w, r)
+	}
+}
+
+// bearerToken 从 Authorization 请求头中提取 Bearer 令牌
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	rawToken := strings.TrimSpace(header[len(prefix):])
+	return rawToken, rawToken != ""
+}
+
+// writeUnauthorized 返回 401 状态码和自定义错误信息
+func writeUnauthorized(ctx context.Context, w http.ResponseWriter, msg string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	httpx.WriteJsonCtx(ctx, w, http.StatusUnauthorized, map[string]interface{}{
+		"code": http.StatusUnauthorized,
+		"msg":  msg,
+	})
+}
+
+// writeForbidden 返回 403 状态码和自定义错误信息
+func writeForbidden(ctx context.Context, w http.ResponseWriter, msg string) {
+	httpx.WriteJsonCtx(ctx, w, http.StatusForbidden, map[string]interface{}{
+		"code": http.StatusForbidden,
+		"msg":  msg,
+	})
+}
@@ -0,0 +1,351 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zeromicro/go-zero/rest/pathvar"
+
+	"hello-gozero/internal/tenant"
+	"hello-gozero/internal/utils/token"
+)
+
+func newTestTokenManager(t *testing.T) token.Manager {
+	t.Helper()
+	m, err := token.NewJWTManager("0123456789abcdef0123456789abcdef", "test", time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create token manager: %v", err)
+	}
+	return m
+}
+
+// fakeSessions 内存会话状态，用于替代 Redis 会话仓库
+type fakeSessions struct {
+	alive map[string]bool
+	err   error
+}
+
+func (f *fakeSessions) Exists(_ context.Context, sessionID string) (bool, error) {
+	return f.alive[sessionID], f.err
+}
+
+// fakeOwners 内存用户表，用于替代用户仓库
+type fakeOwners struct {
+	ids map[string]string // 用户名 -> 用户 ID
+	err error
+}
+
+func (f *fakeOwners) ResolveUserID(_ context.Context, username string) (string, error) {
+	return f.ids[username], f.err
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	tokens := newTestTokenManager(t)
+	validToken, _, err := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice", SessionID: "sid-1"})
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	revokedToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice", SessionID: "sid-2"})
+	noSessionToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice"})
+
+	cases := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{"missing header", "", http.StatusUnauthorized},
+		{"wrong scheme", "Basic " + validToken, http.StatusUnauthorized},
+		{"invalid token", "Bearer not-a-token", http.StatusUnauthorized},
+		{"valid token", "Bearer " + validToken, http.StatusOK},
+		{"case insensitive scheme", "bearer " + validToken, http.StatusOK},
+		{"revoked session", "Bearer " + revokedToken, http.StatusUnauthorized},
+		{"missing session id", "Bearer " + noSessionToken, http.StatusUnauthorized},
+	}
+	sessions := &fakeSessions{alive: map[string]bool{"sid-1": true}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *Principal
+			handler := NewAuthMiddleware(tokens, sessions).Handle(func(w http.ResponseWriter, r *http.Request) {
+				got = GetPrincipal(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusOK {
+				if got == nil || got.Username != "alice" || got.UserID != "user-1" || got.SessionID != "sid-1" {
+					t.Fatalf("unexpected principal: %+v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_SessionCheckError(t *testing.T) {
+	tokens := newTestTokenManager(t)
+	validToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice", SessionID: "sid-1"})
+	sessions := &fakeSessions{err: errors.New("redis unavailable")}
+
+	called := false
+	handler := NewAuthMiddleware(tokens, sessions).Handle(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+validToken)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if called || rec.Code < http.StatusBadRequest {
+		t.Fatalf("expected request to be rejected, got status %d", rec.Code)
+	}
+}
+
+func TestAuthMiddleware_Tenant(t *testing.T) {
+	tokens := newTestTokenManager(t)
+	acmeToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice", SessionID: "sid-1", TenantID: 2})
+	legacyToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice", SessionID: "sid-1"})
+	sessions := &fakeSessions{alive: map[string]bool{"sid-1": true}}
+
+	cases := []struct {
+		name       string
+		token      string
+		tenantID   uint64
+		wantStatus int
+	}{
+		{"same tenant", acmeToken, 2, http.StatusOK},
+		{"other tenant", acmeToken, tenant.DefaultID, http.StatusUnauthorized},
+		{"legacy token in default tenant", legacyToken, tenant.DefaultID, http.StatusOK},
+		{"legacy token in other tenant", legacyToken, 2, http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *Principal
+			handler := NewAuthMiddleware(tokens, sessions).Handle(func(w http.ResponseWriter, r *http.Request) {
+				got = GetPrincipal(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(tenant.NewContext(req.Context(), tenant.Tenant{ID: tc.tenantID}))
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusOK && (got == nil || got.TenantID != tc.tenantID) {
+				t.Fatalf("unexpected principal: %+v", got)
+			}
+		})
+	}
+}
+
+func TestOwnerMiddleware(t *testing.T) {
+	tokens := newTestTokenManager(t)
+	aliceToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice", SessionID: "sid-1"})
+	sessions := &fakeSessions{alive: map[string]bool{"sid-1": true}}
+	owners := &fakeOwners{ids: map[string]string{"alice": "user-1", "bob": "user-2"}}
+
+	cases := []struct {
+		name       string
+		username   string
+		wantStatus int
+	}{
+		{"owner", "alice", http.StatusOK},
+		{"other user", "bob", http.StatusForbidden},
+		{"unknown user", "carol", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAuthMiddleware(tokens, sessions).Handle(
+				NewOwnerMiddleware(owners, "username").Handle(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/"+tc.username, nil)
+			req.Header.Set("Authorization", "Bearer "+aliceToken)
+			req = pathvar.WithVars(req, map[string]string{"username": tc.username})
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+		})
+	}
+}
+
+// TestOwnerMiddleware_AfterRename 变更用户名后，原有的访问令牌（令牌中仍是原用户名）按新用户名访问本人的接口
+func TestOwnerMiddleware_AfterRename(t *testing.T) {
+	tokens := newTestTokenManager(t)
+	aliceToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "alice", SessionID: "sid-1"})
+	sessions := &fakeSessions{alive: map[string]bool{"sid-1": true}}
+	// alice 已变更为 alice.w
+	owners := &fakeOwners{ids: map[string]string{"alice.w": "user-1"}}
+
+	cases := []struct {
+		name       string
+		username   string
+		wantStatus int
+	}{
+		{"new username", "alice.w", http.StatusOK},
+		{"old username", "alice", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAuthMiddleware(tokens, sessions).Handle(
+				NewOwnerMiddleware(owners, "username").Handle(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			req := httptest.NewRequest(http.MethodPut, "/users/"+tc.username+"/password", nil)
+			req.Header.Set("Authorization", "Bearer "+aliceToken)
+			req = pathvar.WithVars(req, map[string]string{"username": tc.username})
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOwnerMiddleware_ResolverError(t *testing.T) {
+	handler := NewOwnerMiddleware(&fakeOwners{err: errors.New("database unavailable")}, "username").Handle(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := pathvar.WithVars(httptest.NewRequest(http.MethodDelete, "/users/alice", nil), map[string]string{"username": "alice"})
+	req = req.WithContext(context.WithValue(req.Context(), principalContextKey{}, &Principal{UserID: "user-1", Username: "alice"}))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	// 无法确认归属时拒绝请求
+	if rec.Code == http.StatusOK {
+		t.Fatalf("status = %d, want request to be rejected", rec.Code)
+	}
+}
+
+func TestOwnerMiddleware_RequiresPrincipal(t *testing.T) {
+	handler := NewOwnerMiddleware(&fakeOwners{}, "username").Handle(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := pathvar.WithVars(httptest.NewRequest(http.MethodDelete, "/users/alice", nil), map[string]string{"username": "alice"})
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+// fakePermissions 内存权限表，用于替代角色仓库
+type fakePermissions struct {
+	granted map[string][]string // 用户名 -> 权限
+	err     error
+}
+
+func (f *fakePermissions) HasPermission(_ context.Context, principal *Principal, permission string) (bool, error) {
+	for _, granted := range f.granted[principal.Username] {
+		if granted == permission {
+			return true, f.err
+		}
+	}
+	return false, f.err
+}
+
+func TestPermissionMiddleware(t *testing.T) {
+	tokens := newTestTokenManager(t)
+	adminToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-1", Username: "admin", SessionID: "sid-1"})
+	aliceToken, _, _ := tokens.GenerateAccessToken(token.Subject{UserID: "user-2", Username: "alice", SessionID: "sid-2"})
+	sessions := &fakeSessions{alive: map[string]bool{"sid-1": true, "sid-2": true}}
+	checker := &fakePermissions{granted: map[string][]string{"admin": {"user:delete:any"}}}
+	owners := &fakeOwners{ids: map[string]string{"admin": "user-1", "alice.w": "user-2", "bob": "user-3"}}
+
+	cases := []struct {
+		name         string
+		token        string
+		ownerPathVar string
+		target       string
+		wantStatus   int
+	}{
+		{"granted", adminToken, "", "bob", http.StatusOK},
+		{"not granted", aliceToken, "", "bob", http.StatusForbidden},
+		{"owner without permission", aliceToken, "username", "alice.w", http.StatusOK},
+		{"owner by old username", aliceToken, "username", "alice", http.StatusForbidden},
+		{"other user without permission", aliceToken, "username", "bob", http.StatusForbidden},
+		{"other user with permission", adminToken, "username", "bob", http.StatusOK},
+		{"owner bypass disabled", aliceToken, "", "alice", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAuthMiddleware(tokens, sessions).Handle(
+				NewPermissionMiddleware(checker, owners, "user:delete:any", tc.ownerPathVar).Handle(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			req := pathvar.WithVars(httptest.NewRequest(http.MethodDelete, "/users/"+tc.target, nil), map[string]string{"username": tc.target})
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPermissionMiddleware_RequiresPrincipal(t *testing.T) {
+	handler := NewPermissionMiddleware(&fakePermissions{}, nil, "user:status:any", "").Handle(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/users/bob/unlock", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestPermissionMiddleware_CheckerError(t *testing.T) {
+	checker := &fakePermissions{err: errors.New("database unavailable")}
+	handler := NewPermissionMiddleware(checker, nil, "user:status:any", "").Handle(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/bob/unlock", nil)
+	req = req.WithContext(context.WithValue(req.Context(), principalContextKey{}, &Principal{UserID: "user-1", Username: "admin"}))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	// 无法确认权限时拒绝请求
+	if rec.Code == http.StatusOK {
+		t.Fatalf("status = %d, want request to be rejected", rec.Code)
+	}
+}
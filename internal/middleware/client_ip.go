@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// 定义一个自定义的上下文 key 类型（非导出，避免外部冲突）
+type clientIPContextKey struct{}
+
+// GetClientIP 从给定的上下文中检索 [ClientIPMiddleware] 解析出的客户端 IP（不含端口）。如果不存在，则返回空字符串。
+func GetClientIP(ctx context.Context) string {
+	if val, ok := ctx.Value(clientIPContextKey{}).(string); ok {
+		return val
+	}
+	return ""
+}
+
+// ClientIPMiddleware 是一个中间件，它解析请求的客户端 IP，并将其存储在请求上下文中以供后续检索（见 [GetClientIP]）。
+// 客户端 IP 用于按 IP 的失败锁定与会话记录，必须不随源端口变化，也不能由客户端任意指定。
+type ClientIPMiddleware struct {
+	// 可信的反向代理网段，只有来自这些网段的请求才采信 X-Forwarded-For
+	trustedProxies []netip.Prefix
+}
+
+// NewClientIPMiddleware 创建客户端 IP 解析中间件，trustedProxies 为可信反向代理的 CIDR（单个 IP 视为 /32 或 /128）
+func NewClientIPMiddleware(trustedProxies []string) (*ClientIPMiddleware, error) {
+	prefixes := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			addr, err := netip.ParseAddr(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return &ClientIPMiddleware{trustedProxies: prefixes}, nil
+}
+
+func (m *ClientIPMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPContextKey{}, m.ClientIP(r))
+
+		// Passthrough to next handler
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// ClientIP 解析请求的客户端 IP
+//   - 直连地址取 r.RemoteAddr 中的主机部分（去掉端口）
+//   - 直连地址是可信代理时，从右向左遍历 X-Forwarded-For，取第一个不是可信代理的地址；
+//     左侧的地址可以由客户端任意填写，不予采信
+//   - X-Forwarded-For 中出现无法解析的地址时，取已经确认的最后一跳
+func (m *ClientIPMiddleware) ClientIP(r *http.Request) string {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		host = h
+	}
+	remote, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	remote = remote.Unmap()
+	if !m.trusted(remote) {
+		return remote.String()
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = hop.Unmap()
+		if !m.trusted(client) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// trusted 判断地址是否属于可信代理
+func (m *ClientIPMiddleware) trusted(addr netip.Addr) bool {
+	for _, prefix := range m.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
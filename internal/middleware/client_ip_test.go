@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIPMiddleware(t *testing.T) {
+	cases := []struct {
+		name       string
+		trusted    []string
+		remoteAddr string
+		xff        []string
+		want       string
+	}{
+		{"strips port", nil, "203.0.113.7:51234", nil, "203.0.113.7"},
+		{"ipv6 strips port", nil, "[2001:db8::1]:443", nil, "2001:db8::1"},
+		{"unmaps ipv4 in ipv6", nil, "[::ffff:203.0.113.7]:80", nil, "203.0.113.7"},
+		{"untrusted remote ignores forwarded header", nil, "203.0.113.7:51234", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"trusted proxy uses forwarded client", []string{"10.0.0.0/8"}, "10.0.0.2:8080", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"takes right-most untrusted hop", []string{"10.0.0.0/8"}, "10.0.0.2:8080", []string{"1.2.3.4, 198.51.100.1, 10.0.0.3"}, "198.51.100.1"},
+		{"joins repeated headers", []string{"10.0.0.0/8"}, "10.0.0.2:8080", []string{"1.2.3.4", "198.51.100.1"}, "198.51.100.1"},
+		{"single trusted ip", []string{"10.0.0.2"}, "10.0.0.2:8080", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"invalid hop stops at last trusted", []string{"10.0.0.0/8"}, "10.0.0.2:8080", []string{"198.51.100.1, garbage, 10.0.0.3"}, "10.0.0.3"},
+		{"all hops trusted", []string{"10.0.0.0/8"}, "10.0.0.2:8080", []string{"10.0.0.4, 10.0.0.3"}, "10.0.0.4"},
+		{"trusted proxy without header", []string{"10.0.0.0/8"}, "10.0.0.2:8080", nil, "10.0.0.2"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewClientIPMiddleware(tc.trusted)
+			if err != nil {
+				t.Fatalf("NewClientIPMiddleware() error = %v", err)
+			}
+			var got string
+			handler := m.Handle(func(w http.ResponseWriter, r *http.Request) {
+				got = GetClientIP(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for _, v := range tc.xff {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			handler(httptest.NewRecorder(), req)
+
+			if got != tc.want {
+				t.Fatalf("client ip = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// 按 IP 的失败锁定以客户端 IP 为计数键，同一主机换用不同源端口或伪造 X-Forwarded-For 都不能得到新的计数键
+func TestClientIPSharesLockoutKeyAcrossPorts(t *testing.T) {
+	m, err := NewClientIPMiddleware(nil)
+	if err != nil {
+		t.Fatalf("NewClientIPMiddleware() error = %v", err)
+	}
+
+	var keys []string
+	for _, tc := range []struct {
+		remoteAddr string
+		xff        string
+	}{
+		{"192.0.2.10:5000", ""},
+		{"192.0.2.10:5001", ""},
+		{"192.0.2.10:5002", "198.51.100.99"},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+		req.RemoteAddr = tc.remoteAddr
+		if tc.xff != "" {
+			req.Header.Set("X-Forwarded-For", tc.xff)
+		}
+		keys = append(keys, m.ClientIP(req))
+	}
+
+	for i, key := range keys {
+		if key != "192.0.2.10" {
+			t.Fatalf("request %d lockout key = %q, want %q", i, key, "192.0.2.10")
+		}
+	}
+}
+
+func TestNewClientIPMiddlewareRejectsInvalidProxy(t *testing.T) {
+	if _, err := NewClientIPMiddleware([]string{"not-a-cidr"}); err == nil {
+		t.Fatal("NewClientIPMiddleware() error = nil, want error")
+	}
+}
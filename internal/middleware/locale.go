@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"hello-gozero/pkg/i18n"
+)
+
+// LocaleMiddleware 是一个中间件，它根据请求的 Accept-Language 头部选择语言（见 [i18n.ParseAcceptLanguage]），
+// 并将其存储在请求上下文中，供错误消息、通知模板等按 [i18n.GetLocale] 渲染。
+type LocaleMiddleware struct{}
+
+func NewLocaleMiddleware() *LocaleMiddleware {
+	return &LocaleMiddleware{}
+}
+
+func (m *LocaleMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		ctx := i18n.SetLocale(r.Context(), string(locale))
+
+		// Passthrough to next handler
+		next(w, r.WithContext(ctx))
+	}
+}
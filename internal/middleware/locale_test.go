@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-gozero/pkg/i18n"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   i18n.Locale
+	}{
+		{"missing header", "", i18n.LocaleEN},
+		{"chinese", "zh-CN,zh;q=0.9,en;q=0.8", i18n.LocaleZH},
+		{"chinese region variant", "zh-TW", i18n.LocaleZH},
+		{"english first", "en-GB,zh-CN;q=0.5", i18n.LocaleEN},
+		{"unsupported then chinese", "fr-FR,zh;q=0.8", i18n.LocaleZH},
+		{"unsupported only", "fr-FR,de;q=0.8", i18n.LocaleEN},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got i18n.Locale
+			handler := NewLocaleMiddleware().Handle(func(w http.ResponseWriter, r *http.Request) {
+				got = i18n.GetLocale(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("Accept-Language", tc.header)
+			}
+			handler(httptest.NewRecorder(), req)
+
+			if got != tc.want {
+				t.Fatalf("locale = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
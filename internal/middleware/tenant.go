@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"hello-gozero/internal/tenant"
+)
+
+var (
+	errMissingTenant  = errors.New("missing tenant")
+	errTenantMismatch = errors.New("tenant in header does not match host")
+)
+
+// TenantResolver 租户查询接口
+type TenantResolver interface {
+	// ResolveTenant 根据租户标识获取租户，不存在时返回 [tenant.ErrNotFound]，已停用时返回 [tenant.ErrDisabled]
+	ResolveTenant(ctx context.Context, code string) (tenant.Tenant, error)
+}
+
+// TenantMiddleware 是一个中间件，它从请求头或子域名中解析请求所属的租户，并将其存储在请求上下文中（见 [tenant.FromContext]）。
+// 租户标识不区分大小写；请求头与子域名都没有携带时使用默认租户，两者同时携带且不一致时返回 400；
+// 租户不存在时返回 404，已停用时返回 403，不会调用后续处理器。
+type TenantMiddleware struct {
+	resolver TenantResolver
+
+	// 携带租户标识的请求头，为空表示不按请求头解析
+	header string
+
+	// 按子域名解析租户时的主域名，为空表示不按子域名解析
+	baseDomain string
+
+	// 请求未携带租户时使用的租户标识，为空表示必须携带
+	defaultCode string
+}
+
+func NewTenantMiddleware(resolver TenantResolver, header, baseDomain, defaultCode string) *TenantMiddleware {
+	return &TenantMiddleware{
+		resolver:    resolver,
+		header:      header,
+		baseDomain:  strings.ToLower(strings.Trim(baseDomain, ".")),
+		defaultCode: strings.ToLower(defaultCode),
+	}
+}
+
+func (m *TenantMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqCtx := r.Context()
+
+		code, err := m.tenantCode(r)
+		if err != nil {
+			httpx.WriteJsonCtx(reqCtx, w, http.StatusBadRequest, map[string]interface{}{
+				"code": http.StatusBadRequest,
+				"msg":  err.Error(),
+			})
+			return
+		}
+
+		t, err := m.resolver.ResolveTenant(reqCtx, code)
+		switch {
+		case errors.Is(err, tenant.ErrNotFound):
+			httpx.WriteJsonCtx(reqCtx, w, http.StatusNotFound, map[string]interface{}{
+				"code": http.StatusNotFound,
+				"msg":  err.Error(),
+			})
+			return
+		case errors.Is(err, tenant.ErrDisabled):
+			writeForbidden(reqCtx, w, err.Error())
+			return
+		case err != nil:
+			// 无法确认租户时拒绝请求（fail closed）
+			logx.WithContext(reqCtx).Errorf("failed to resolve tenant(%s): %v", code, err)
+			httpx.ErrorCtx(reqCtx, w, err)
+			return
+		}
+
+		ctx := tenant.NewContext(reqCtx, t)
+		ctx = logx.ContextWithFields(ctx, logx.Field("tenant_id", t.ID))
+
+		// Passthrough to next handler
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// tenantCode 从请求头或子域名中获取租户标识，都没有携带时使用默认租户
+func (m *TenantMiddleware) tenantCode(r *http.Request) (string, error) {
+	var fromHeader string
+	if m.header != "" {
+		fromHeader = strings.ToLower(strings.TrimSpace(r.Header.Get(m.header)))
+	}
+	fromHost := m.subdomain(r.Host)
+
+	switch {
+	case fromHeader != "" && fromHost != "" && fromHeader != fromHost:
+		return "", errTenantMismatch
+	case fromHeader != "":
+		return fromHeader, nil
+	case fromHost != "":
+		return fromHost, nil
+	case m.defaultCode != "":
+		return m.defaultCode, nil
+	default:
+		return "", errMissingTenant
+	}
+}
+
+// subdomain 返回 host 在主域名下的子域名，如主域名为 example.com 时 acme.example.com 返回 acme；
+// 未配置主域名或 host 不在主域名下时返回空字符串
+func (m *TenantMiddleware) subdomain(host string) string {
+	if m.baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	suffix := "." + m.baseDomain
+	if !strings.HasSuffix(host, suffix) {
+		return ""
+	}
+	return strings.TrimSuffix(host, suffix)
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hello-gozero/internal/tenant"
+)
+
+// fakeTenants 内存租户表，用于替代租户仓库
+type fakeTenants struct {
+	tenants  map[string]tenant.Tenant
+	disabled map[string]bool
+	err      error
+}
+
+func (f *fakeTenants) ResolveTenant(_ context.Context, code string) (tenant.Tenant, error) {
+	if f.err != nil {
+		return tenant.Tenant{}, f.err
+	}
+	if f.disabled[code] {
+		return tenant.Tenant{}, tenant.ErrDisabled
+	}
+	t, ok := f.tenants[code]
+	if !ok {
+		return tenant.Tenant{}, tenant.ErrNotFound
+	}
+	return t, nil
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	tenants := &fakeTenants{
+		tenants: map[string]tenant.Tenant{
+			"default": {ID: 1, Code: "default"},
+			"acme":    {ID: 2, Code: "acme"},
+		},
+		disabled: map[string]bool{"globex": true},
+	}
+
+	cases := []struct {
+		name        string
+		defaultCode string
+		host        string
+		header      string
+		wantStatus  int
+		wantID      uint64
+	}{
+		{"default tenant", "default", "api.test", "", http.StatusOK, 1},
+		{"no default", "", "api.test", "", http.StatusBadRequest, 0},
+		{"header", "default", "api.test", "acme", http.StatusOK, 2},
+		{"header case insensitive", "default", "api.test", " ACME ", http.StatusOK, 2},
+		{"subdomain", "default", "acme.example.com", "", http.StatusOK, 2},
+		{"subdomain with port", "default", "Acme.Example.com:8888", "", http.StatusOK, 2},
+		{"base domain only", "default", "example.com", "", http.StatusOK, 1},
+		{"header matches subdomain", "default", "acme.example.com", "acme", http.StatusOK, 2},
+		{"header mismatches subdomain", "default", "acme.example.com", "default", http.StatusBadRequest, 0},
+		{"unknown tenant", "default", "api.test", "initech", http.StatusNotFound, 0},
+		{"disabled tenant", "default", "globex.example.com", "", http.StatusForbidden, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got tenant.Tenant
+			var ok bool
+			handler := NewTenantMiddleware(tenants, "X-Tenant-ID", "example.com", tc.defaultCode).Handle(func(w http.ResponseWriter, r *http.Request) {
+				got, ok = tenant.FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tc.host
+			if tc.header != "" {
+				req.Header.Set("X-Tenant-ID", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusOK && (!ok || got.ID != tc.wantID) {
+				t.Fatalf("tenant = %+v (found %v), want id %d", got, ok, tc.wantID)
+			}
+		})
+	}
+}
+
+func TestTenantMiddleware_ResolveError(t *testing.T) {
+	tenants := &fakeTenants{err: errors.New("mysql unavailable")}
+
+	called := false
+	handler := NewTenantMiddleware(tenants, "X-Tenant-ID", "", "default").Handle(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if called || rec.Code < http.StatusBadRequest {
+		t.Fatalf("expected request to be rejected, got status %d", rec.Code)
+	}
+}
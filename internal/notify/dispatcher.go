@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 发送器类型
+const (
+	SenderStdout = "stdout" // 写入标准输出（本地开发）
+	SenderFile   = "file"   // 写入本地文件（本地开发、集成测试）
+	SenderSMTP   = "smtp"   // 通过 SMTP 投递（仅邮件渠道）
+)
+
+// drainTimeout 停止时投递队列中剩余通知的最长时间
+const drainTimeout = 5 * time.Second
+
+// Config 通知配置
+type Config struct {
+	// 邮件渠道发送器：stdout、file、smtp
+	EmailSender string `json:"EmailSender,default=stdout,options=stdout|file|smtp"`
+
+	// 短信渠道发送器：stdout、file
+	// 接入真实的短信服务商时，通过 [WithSender] 和 [NewSMSSender] 注入
+	SMSSender string `json:"SMSSender,default=stdout,options=stdout|file"`
+
+	// file 发送器写入的文件路径
+	FilePath string `json:"FilePath,default=logs/notify.log"`
+
+	// SMTP 配置，EmailSender 为 smtp 时必填
+	SMTP SMTPConfig `json:"SMTP,optional"`
+
+	// 发送队列容量，队列满时 [Dispatcher.Notify] 返回 [ErrQueueFull]
+	QueueSize int `json:"QueueSize,default=1024"`
+
+	// 单条通知投递失败后的最大重试次数
+	MaxRetries int `json:"MaxRetries,default=3"`
+}
+
+// DispatcherOption [Dispatcher] 可选配置
+type DispatcherOption func(d *Dispatcher)
+
+// WithSender 为渠道指定发送器，覆盖配置中的发送器
+func WithSender(channel Channel, sender Sender) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.senders[channel] = sender
+	}
+}
+
+// Dispatcher 异步通知分发器
+//
+// Implements [Notifier]，同时实现 worker.Worker 接口，需要注册到 worker.Manager 中运行：
+// [Dispatcher.Notify] 在调用方协程中渲染模板（模板错误立即返回）后放入队列，
+// 由后台 Worker 按渠道投递，投递失败时按指数退避重试
+type Dispatcher struct {
+	senders    map[Channel]Sender
+	queue      chan *Envelope
+	maxRetries int
+	backoff    time.Duration
+	logger     logx.Logger
+}
+
+// NewDispatcher 根据配置创建通知分发器
+func NewDispatcher(conf Config, logger logx.Logger, opts ...DispatcherOption) (*Dispatcher, error) {
+	queueSize := conf.QueueSize
+	if queueSize <= 0 {
+		queueSize = 1024
+	}
+	d := &Dispatcher{
+		senders:    make(map[Channel]Sender),
+		queue:      make(chan *Envelope, queueSize),
+		maxRetries: conf.MaxRetries,
+		backoff:    time.Second,
+		logger:     logger,
+	}
+
+	// 按配置创建发送器，stdout 与 file 发送器在渠道之间共享
+	var fileSender Sender
+	newSender := func(kind string) (Sender, error) {
+		switch kind {
+		case SenderStdout, "":
+			return NewWriterSender(os.Stdout), nil
+		case SenderFile:
+			if fileSender == nil {
+				s, err := NewFileSender(conf.FilePath)
+				if err != nil {
+					return nil, err
+				}
+				fileSender = s
+			}
+			return fileSender, nil
+		case SenderSMTP:
+			return NewSMTPSender(conf.SMTP)
+		default:
+			return nil, fmt.Errorf("unknown notify sender %q", kind)
+		}
+	}
+
+	emailSender, err := newSender(conf.EmailSender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init email sender: %w", err)
+	}
+	d.senders[ChannelEmail] = emailSender
+
+	if conf.SMSSender == SenderSMTP {
+		return nil, fmt.Errorf("notify sender %q does not support channel %s", SenderSMTP, ChannelSMS)
+	}
+	smsSender, err := newSender(conf.SMSSender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init sms sender: %w", err)
+	}
+	d.senders[ChannelSMS] = smsSender
+
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d, nil
+}
+
+// Notify Implements [Notifier.Notify]
+func (d *Dispatcher) Notify(ctx context.Context, msg *Message) error {
+	if _, ok := d.senders[msg.Channel]; !ok {
+		return fmt.Errorf("%w: %s", ErrNoSender, msg.Channel)
+	}
+	env, err := Render(msg)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case d.queue <- env:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Name Implements worker.Worker
+func (d *Dispatcher) Name() string {
+	return "notify-dispatcher"
+}
+
+// Start Implements worker.Worker
+// 阻塞运行直到 ctx 被取消，取消后在 [drainTimeout] 内尽量投递队列中剩余的通知
+func (d *Dispatcher) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			d.drain()
+			return nil
+		case env := <-d.queue:
+			d.deliver(ctx, env)
+		}
+	}
+}
+
+// Stop Implements worker.Worker
+func (d *Dispatcher) Stop() error {
+	return nil
+}
+
+// drain 投递队列中剩余的通知
+func (d *Dispatcher) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case env := <-d.queue:
+			d.deliver(ctx, env)
+		default:
+			return
+		}
+		if ctx.Err() != nil {
+			d.logger.Errorf("notify dispatcher stopped with %d notifications undelivered", len(d.queue))
+			return
+		}
+	}
+}
+
+// deliver 投递单条通知，失败时按指数退避重试
+func (d *Dispatcher) deliver(ctx context.Context, env *Envelope) {
+	sender := d.senders[env.Channel]
+	backoff := d.backoff
+	for attempt := 0; ; attempt++ {
+		err := sender.Send(ctx, env)
+		if err == nil {
+			return
+		}
+		if attempt >= d.maxRetries || ctx.Err() != nil {
+			// 注意：不要在日志中打印正文，正文中可能包含验证码
+			d.logger.Errorf("failed to deliver %s notification to %s after %d attempts: %v", env.Channel, env.To, attempt+1, err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,84 @@
+// Package notify 站外通知（邮件、短信等）
+//
+// 通知的发送分为三层：
+//   - [Notifier]：业务代码调用的入口，只描述「给谁、用哪个模板、什么数据」
+//   - 模板：按 [i18n.Locale] 将模板渲染为可投递的 [Envelope]
+//   - [Sender]：按渠道投递 [Envelope]（SMTP、短信服务商、本地文件/标准输出等）
+//
+// [Dispatcher] 将三者组合起来，并作为后台 Worker 异步投递，HTTP 请求不会阻塞在 SMTP 等外部服务上
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"hello-gozero/pkg/i18n"
+)
+
+// Channel 通知渠道
+type Channel string
+
+const (
+	// ChannelEmail 邮件
+	ChannelEmail Channel = "email"
+
+	// ChannelSMS 短信
+	ChannelSMS Channel = "sms"
+)
+
+var (
+	// ErrUnknownTemplate 模板不存在
+	ErrUnknownTemplate = errors.New("unknown notification template")
+
+	// ErrNoSender 渠道没有配置发送器
+	ErrNoSender = errors.New("no sender for channel")
+
+	// ErrQueueFull 发送队列已满
+	ErrQueueFull = errors.New("notification queue is full")
+)
+
+// Message 待发送的通知
+type Message struct {
+	// 通知渠道
+	Channel Channel
+
+	// 接收方，邮件为邮箱地址，短信为手机号
+	To string
+
+	// 模板名称，见 [TemplatePasswordResetCode] 等
+	Template string
+
+	// 渲染模板使用的语言，模板不支持该语言时回退到 [i18n.LocaleEN]
+	Locale i18n.Locale
+
+	// 模板数据
+	Data map[string]any
+}
+
+// Envelope 渲染完成、可直接投递的通知
+type Envelope struct {
+	// 通知渠道
+	Channel Channel `json:"channel"`
+
+	// 接收方
+	To string `json:"to"`
+
+	// 标题（短信渠道为空）
+	Subject string `json:"subject,omitempty"`
+
+	// 正文
+	Body string `json:"body"`
+}
+
+// Notifier 通知发送接口
+type Notifier interface {
+	// Notify 发送通知
+	// 实现可以是异步的：返回 nil 只表示通知已被接受，不代表已经投递成功
+	Notify(ctx context.Context, msg *Message) error
+}
+
+// Sender 单个渠道的通知投递接口
+type Sender interface {
+	// Send 投递一条已渲染的通知
+	Send(ctx context.Context, env *Envelope) error
+}
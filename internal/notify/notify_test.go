@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"hello-gozero/pkg/i18n"
+)
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name        string
+		msg         *Message
+		wantSubject string
+		wantBody    string
+	}{
+		{
+			name: "english email",
+			msg: &Message{Channel: ChannelEmail, To: "alice@example.com", Template: TemplatePasswordResetCode, Locale: i18n.LocaleEN,
+				Data: map[string]any{"Code": "123456", "ExpireMinutes": 15}},
+			wantSubject: "Your password reset code",
+			wantBody:    "123456",
+		},
+		{
+			name: "chinese email",
+			msg: &Message{Channel: ChannelEmail, To: "alice@example.com", Template: TemplatePasswordResetCode, Locale: i18n.LocaleZH,
+				Data: map[string]any{"Code": "123456", "ExpireMinutes": 15}},
+			wantSubject: "密码重置验证码",
+			wantBody:    "15 分钟内有效",
+		},
+		{
+			name: "unsupported locale falls back to english",
+			msg: &Message{Channel: ChannelEmail, To: "alice@example.com", Template: TemplatePasswordResetCode, Locale: "fr-FR",
+				Data: map[string]any{"Code": "123456", "ExpireMinutes": 15}},
+			wantSubject: "Your password reset code",
+			wantBody:    "expires in 15 minutes",
+		},
+		{
+			name: "sms has no subject",
+			msg: &Message{Channel: ChannelSMS, To: "+8613800000000", Template: TemplatePasswordResetCode, Locale: i18n.LocaleEN,
+				Data: map[string]any{"Code": "654321", "ExpireMinutes": 5}},
+			wantSubject: "",
+			wantBody:    "654321",
+		},
+		{
+			name: "chinese invitation",
+			msg: &Message{Channel: ChannelEmail, To: "jane@example.com", Template: TemplateInvitation, Locale: i18n.LocaleZH,
+				Data: map[string]any{"Inviter": "admin", "Link": "https://app.example.com/invite?token=abc", "ExpireHours": 168}},
+			wantSubject: "邀请您注册账户",
+			wantBody:    "168 小时内有效",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			env, err := Render(tc.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if env.Subject != tc.wantSubject {
+				t.Fatalf("subject = %q, want %q", env.Subject, tc.wantSubject)
+			}
+			if !strings.Contains(env.Body, tc.wantBody) {
+				t.Fatalf("body = %q, want it to contain %q", env.Body, tc.wantBody)
+			}
+			if env.To != tc.msg.To || env.Channel != tc.msg.Channel {
+				t.Fatalf("unexpected envelope: %+v", env)
+			}
+		})
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	if _, err := Render(&Message{Channel: ChannelEmail, Template: "no_such_template"}); !errors.Is(err, ErrUnknownTemplate) {
+		t.Fatalf("err = %v, want %v", err, ErrUnknownTemplate)
+	}
+
+	// 缺少模板数据时返回错误，而不是渲染出 "<no value>"
+	_, err := Render(&Message{Channel: ChannelEmail, Template: TemplatePasswordResetCode, Data: map[string]any{"Code": "123456"}})
+	if err == nil {
+		t.Fatal("expected error for missing template data")
+	}
+}
+
+func TestWriterSender(t *testing.T) {
+	var buf bytes.Buffer
+	sender := NewWriterSender(&buf)
+
+	env := &Envelope{Channel: ChannelEmail, To: "alice@example.com", Subject: "hi", Body: "hello"}
+	if err := sender.Send(context.Background(), env); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sender.Send(context.Background(), env); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var got Envelope
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("line is not valid json: %v", err)
+	}
+	if got != *env {
+		t.Fatalf("got %+v, want %+v", got, *env)
+	}
+}
+
+// recordingSender 记录收到的通知，前 failures 次投递返回错误
+type recordingSender struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	sent     []*Envelope
+	done     chan struct{}
+}
+
+func (s *recordingSender) Send(_ context.Context, env *Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.calls <= s.failures {
+		return errors.New("temporary failure")
+	}
+	s.sent = append(s.sent, env)
+	close(s.done)
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	sender := &recordingSender{failures: 2, done: make(chan struct{})}
+	d, err := NewDispatcher(Config{QueueSize: 1, MaxRetries: 3}, logx.WithContext(context.Background()), WithSender(ChannelEmail, sender))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.backoff = time.Millisecond
+
+	msg := &Message{Channel: ChannelEmail, To: "alice@example.com", Template: TemplatePasswordResetCode,
+		Data: map[string]any{"Code": "123456", "ExpireMinutes": 15}}
+	if err := d.Notify(context.Background(), msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 队列容量为 1 且 worker 尚未启动，第二条通知应被拒绝
+	if err := d.Notify(context.Background(), msg); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("err = %v, want %v", err, ErrQueueFull)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		_ = d.Start(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case <-sender.done:
+	case <-time.After(time.Second):
+		t.Fatal("notification was not delivered")
+	}
+	cancel()
+	<-stopped
+
+	if sender.calls != 3 || len(sender.sent) != 1 || sender.sent[0].To != "alice@example.com" {
+		t.Fatalf("unexpected delivery: calls=%d sent=%d", sender.calls, len(sender.sent))
+	}
+}
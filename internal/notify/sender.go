@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SMSProvider 短信服务商接口
+// 接入具体的短信服务商（阿里云、腾讯云、Twilio 等）时实现该接口，再通过 [NewSMSSender] 适配为 [Sender]
+type SMSProvider interface {
+	// SendSMS 向手机号发送一条短信
+	SendSMS(ctx context.Context, phone, content string) error
+}
+
+// smsSender Implements [Sender]
+type smsSender struct {
+	provider SMSProvider
+}
+
+// NewSMSSender 将短信服务商适配为 [Sender]
+func NewSMSSender(provider SMSProvider) Sender {
+	return &smsSender{provider: provider}
+}
+
+// Send Implements [Sender.Send]
+func (s *smsSender) Send(ctx context.Context, env *Envelope) error {
+	return s.provider.SendSMS(ctx, env.To, env.Body)
+}
+
+// writerSender Implements [Sender]
+// 将通知以 JSON Lines 格式写入 io.Writer，不会真正投递，用于本地开发与测试
+type writerSender struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// writerRecord 写入的单条记录
+type writerRecord struct {
+	*Envelope
+	SentAt string `json:"sent_at"`
+}
+
+// NewWriterSender 创建一个将通知写入 w 的 [Sender]，如 os.Stdout
+func NewWriterSender(w io.Writer) Sender {
+	return &writerSender{w: w}
+}
+
+// NewFileSender 创建一个将通知追加写入文件的 [Sender]，文件及其目录不存在时自动创建
+func NewFileSender(path string) (Sender, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create notify sink directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open notify sink file: %w", err)
+	}
+	return NewWriterSender(f), nil
+}
+
+// Send Implements [Sender.Send]
+func (s *writerSender) Send(_ context.Context, env *Envelope) error {
+	line, err := json.Marshal(writerRecord{
+		Envelope: env,
+		SentAt:   time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,151 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPConfig SMTP 邮件发送配置
+type SMTPConfig struct {
+	Host     string `json:"Host,optional"`
+	Port     int    `json:"Port,default=587"`
+	Username string `json:"Username,optional"`
+	Password string `json:"Password,optional"`
+
+	// 发件人地址，如 "Hello GoZero <no-reply@example.com>"
+	From string `json:"From,optional"`
+
+	// 是否使用隐式 TLS（通常为 465 端口）；为 false 时若服务端支持则自动升级为 STARTTLS
+	ImplicitTLS bool `json:"ImplicitTLS,default=false"`
+
+	// 连接与发送的超时时间，单位秒
+	Timeout int `json:"Timeout,default=10" comment:"unit: seconds"`
+}
+
+// Validate 配置文件校验
+func (c *SMTPConfig) Validate() error {
+	if c.Host == "" {
+		return errors.New("smtp host is required")
+	}
+	if c.Port <= 0 {
+		return errors.New("smtp port must be positive")
+	}
+	if c.From == "" {
+		return errors.New("smtp from address is required")
+	}
+	return nil
+}
+
+// smtpSender Implements [Sender]
+type smtpSender struct {
+	conf SMTPConfig
+}
+
+// NewSMTPSender 创建通过 SMTP 投递邮件的 [Sender]
+func NewSMTPSender(conf SMTPConfig) (Sender, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	return &smtpSender{conf: conf}, nil
+}
+
+// Send Implements [Sender.Send]
+func (s *smtpSender) Send(ctx context.Context, env *Envelope) error {
+	timeout := time.Duration(s.conf.Timeout) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.conf.Host, strconv.Itoa(s.conf.Port))
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to dial smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	tlsConfig := &tls.Config{ServerName: s.conf.Host}
+	if s.conf.ImplicitTLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, s.conf.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer client.Close()
+
+	if !s.conf.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to start tls: %w", err)
+			}
+		}
+	}
+	if s.conf.Username != "" {
+		auth := smtp.PlainAuth("", s.conf.Username, s.conf.Password, s.conf.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	from, err := envelopeAddress(s.conf.From)
+	if err != nil {
+		return err
+	}
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(env.To); err != nil {
+		return fmt.Errorf("smtp RCPT TO failed: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(buildMIMEMessage(s.conf.From, env)); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("failed to write mail body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finish mail body: %w", err)
+	}
+	return client.Quit()
+}
+
+// envelopeAddress 从 "Name <addr>" 形式的地址中提取邮箱地址
+func envelopeAddress(from string) (string, error) {
+	if i := strings.LastIndex(from, "<"); i >= 0 {
+		j := strings.LastIndex(from, ">")
+		if j < i {
+			return "", fmt.Errorf("malformed from address %q", from)
+		}
+		return from[i+1 : j], nil
+	}
+	return from, nil
+}
+
+// buildMIMEMessage 构造纯文本邮件，标题按 RFC 2047 编码以支持中文
+func buildMIMEMessage(from string, env *Envelope) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + env.To + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", env.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(env.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
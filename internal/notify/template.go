@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"hello-gozero/pkg/i18n"
+)
+
+// 模板名称
+const (
+	// TemplatePasswordResetCode 忘记密码验证码，数据：Code、ExpireMinutes
+	TemplatePasswordResetCode = "password_reset_code"
+
+	// TemplateEmailVerifyCode 邮箱验证码，数据：Username、Code、ExpireMinutes
+	TemplateEmailVerifyCode = "email_verify_code"
+
+	// TemplateSecurityAlert 账户安全提醒，数据：Username、Event
+	TemplateSecurityAlert = "security_alert"
+
+	// TemplateInvitation 用户邀请，数据：Inviter、Link（接受邀请的链接或令牌）、ExpireHours
+	TemplateInvitation = "invitation"
+)
+
+// templateText 单个语言的模板文本
+type templateText struct {
+	// 标题模板（短信渠道忽略）
+	Subject string
+
+	// 正文模板
+	Body string
+}
+
+// templateTexts 模板文本，按模板名称与语言组织
+// 每个模板至少需要提供 [i18n.LocaleEN] 版本，作为其他语言缺失时的回退
+var templateTexts = map[string]map[i18n.Locale]templateText{
+	TemplatePasswordResetCode: {
+		i18n.LocaleEN: {
+			Subject: "Your password reset code",
+			Body:    "Your password reset code is {{.Code}}. It expires in {{.ExpireMinutes}} minutes. If you did not request a password reset, please ignore this message.",
+		},
+		i18n.LocaleZH: {
+			Subject: "密码重置验证码",
+			Body:    "您的密码重置验证码为 {{.Code}}，{{.ExpireMinutes}} 分钟内有效。如果这不是您本人的操作，请忽略此消息。",
+		},
+	},
+	TemplateEmailVerifyCode: {
+		i18n.LocaleEN: {
+			Subject: "Verify your email address",
+			Body:    "Hi {{.Username}}, your email verification code is {{.Code}}. It expires in {{.ExpireMinutes}} minutes.",
+		},
+		i18n.LocaleZH: {
+			Subject: "验证您的邮箱",
+			Body:    "{{.Username}}，您好：您的邮箱验证码为 {{.Code}}，{{.ExpireMinutes}} 分钟内有效。",
+		},
+	},
+	TemplateSecurityAlert: {
+		i18n.LocaleEN: {
+			Subject: "Security alert for your account",
+			Body:    "Hi {{.Username}}, we noticed a security event on your account: {{.Event}}. If this was not you, please reset your password immediately.",
+		},
+		i18n.LocaleZH: {
+			Subject: "账户安全提醒",
+			Body:    "{{.Username}}，您好：您的账户发生了安全事件：{{.Event}}。如果这不是您本人的操作，请立即重置密码。",
+		},
+	},
+	TemplateInvitation: {
+		i18n.LocaleEN: {
+			Subject: "You have been invited to create an account",
+			Body:    "{{.Inviter}} has invited you to create an account. Use the following invitation link to sign up: {{.Link}} . The invitation expires in {{.ExpireHours}} hours. If you were not expecting this invitation, please ignore this message.",
+		},
+		i18n.LocaleZH: {
+			Subject: "邀请您注册账户",
+			Body:    "{{.Inviter}} 邀请您注册账户，请通过以下邀请链接完成注册：{{.Link}} ，邀请 {{.ExpireHours}} 小时内有效。如果您不认识邀请人，请忽略此消息。",
+		},
+	},
+}
+
+// compiledTemplate 编译后的模板
+type compiledTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// templates 编译后的模板，在包初始化时编译，模板语法错误会直接 panic
+var templates = mustCompileTemplates(templateTexts)
+
+func mustCompileTemplates(texts map[string]map[i18n.Locale]templateText) map[string]map[i18n.Locale]*compiledTemplate {
+	compiled := make(map[string]map[i18n.Locale]*compiledTemplate, len(texts))
+	for name, locales := range texts {
+		if _, ok := locales[i18n.LocaleEN]; !ok {
+			panic(fmt.Sprintf("notify: template %q has no %s fallback", name, i18n.LocaleEN))
+		}
+		compiled[name] = make(map[i18n.Locale]*compiledTemplate, len(locales))
+		for locale, text := range locales {
+			prefix := name + "." + string(locale)
+			compiled[name][locale] = &compiledTemplate{
+				subject: template.Must(template.New(prefix + ".subject").Option("missingkey=error").Parse(text.Subject)),
+				body:    template.Must(template.New(prefix + ".body").Option("missingkey=error").Parse(text.Body)),
+			}
+		}
+	}
+	return compiled
+}
+
+// Render 将通知渲染为可投递的 [Envelope]
+func Render(msg *Message) (*Envelope, error) {
+	locales, ok := templates[msg.Template]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, msg.Template)
+	}
+	tpl, ok := locales[msg.Locale]
+	if !ok {
+		tpl = locales[i18n.LocaleEN]
+	}
+
+	env := &Envelope{
+		Channel: msg.Channel,
+		To:      msg.To,
+	}
+	var buf strings.Builder
+	if msg.Channel != ChannelSMS {
+		if err := tpl.subject.Execute(&buf, msg.Data); err != nil {
+			return nil, fmt.Errorf("failed to render subject of template %s: %w", msg.Template, err)
+		}
+		env.Subject = buf.String()
+		buf.Reset()
+	}
+	if err := tpl.body.Execute(&buf, msg.Data); err != nil {
+		return nil, fmt.Errorf("failed to render body of template %s: %w", msg.Template, err)
+	}
+	env.Body = buf.String()
+	return env, nil
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"hello-gozero/infra/cache"
+)
+
+const lockoutKeyPrefix = "auth:lockout" // 认证失败锁定缓存键前缀
+
+// LockoutSubject 认证失败计数的维度
+type LockoutSubject string
+
+const (
+	// LockoutSubjectUser 按用户名计数，防止针对单个账户的密码猜测；用户名只在租户内唯一，按上下文中的租户隔离
+	LockoutSubjectUser LockoutSubject = "user"
+
+	// LockoutSubjectIP 按客户端 IP 计数，防止单个来源针对大量账户的撞库；同一来源在所有租户下共享计数
+	LockoutSubjectIP LockoutSubject = "ip"
+)
+
+// LockoutPolicy 锁定策略
+type LockoutPolicy struct {
+	// 时间窗口内允许的最大失败次数，达到后锁定
+	MaxFailures int64
+
+	// 失败计数的时间窗口（从首次失败开始计时）
+	Window time.Duration
+
+	// 首次锁定的时长，之后每次锁定时长翻倍
+	BaseLockout time.Duration
+
+	// 单次锁定的最长时长
+	MaxLockout time.Duration
+
+	// 锁定等级的保留时间，从最近一次锁定开始计时，过期后锁定时长重新从 BaseLockout 开始
+	LevelTTL time.Duration
+}
+
+// LockoutResult 记录一次认证失败后的结果
+type LockoutResult struct {
+	// 当前时间窗口内的失败次数（触发锁定后计数清零）
+	Failures int64
+
+	// 锁定等级（第几次锁定），本次失败未触发锁定时为 0
+	Level int64
+
+	// 本次失败触发的锁定时长，未触发锁定时为 0
+	LockedFor time.Duration
+}
+
+// LockoutRepository 定义认证失败计数与锁定状态的存储接口
+//
+// 每个维度（见 [LockoutSubject]）下的每个键维护三个值：
+//   - 失败计数：时间窗口内的失败次数
+//   - 锁定等级：连续锁定的次数，用于计算指数增长的锁定时长
+//   - 锁定标记：存在即处于锁定状态，过期后自动解锁
+type LockoutRepository interface {
+	// LockedFor 返回剩余锁定时长，未锁定时返回 0
+	LockedFor(ctx context.Context, subject LockoutSubject, key string) (time.Duration, error)
+
+	// RecordFailure 记录一次认证失败，失败次数达到上限时按锁定等级锁定
+	RecordFailure(ctx context.Context, subject LockoutSubject, key string, policy LockoutPolicy) (*LockoutResult, error)
+
+	// ResetFailures 清零失败计数（认证成功时调用），锁定等级保留，直到自然过期
+	ResetFailures(ctx context.Context, subject LockoutSubject, key string) error
+
+	// Clear 清除失败计数、锁定等级与锁定标记（管理员解锁时调用）
+	Clear(ctx context.Context, subject LockoutSubject, key string) error
+}
+
+// lockoutRepositoryImpl Implements [LockoutRepository]
+type lockoutRepositoryImpl struct {
+	redisInfra *cache.RedisInfra
+}
+
+// NewLockoutRepository 创建基于 Redis 的认证失败锁定仓库
+func NewLockoutRepository(redisInfra *cache.RedisInfra) LockoutRepository {
+	return &lockoutRepositoryImpl{redisInfra: redisInfra}
+}
+
+// getKey 获取缓存键，kind 为 failures、level 或 lock
+// 按用户名计数时键包含上下文中的租户 ID，上下文中没有租户时返回 [tenant.ErrMissing]
+func (r *lockoutRepositoryImpl) getKey(ctx context.Context, kind string, subject LockoutSubject, key string) (string, error) {
+	if subject == LockoutSubjectUser {
+		var err error
+		if key, err = tenantScopedKey(ctx, key); err != nil {
+			return "", err
+		}
+	}
+	return lockoutKeyPrefix + ":" + kind + ":" + string(subject) + ":" + key, nil
+}
+
+// getKeys 获取失败计数、锁定等级与锁定标记的缓存键
+func (r *lockoutRepositoryImpl) getKeys(ctx context.Context, subject LockoutSubject, key string) ([]string, error) {
+	keys := make([]string, 0, 3)
+	for _, kind := range []string{"failures", "level", "lock"} {
+		k, err := r.getKey(ctx, kind, subject, key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// LockedFor Implements [LockoutRepository.LockedFor]
+func (r *lockoutRepositoryImpl) LockedFor(ctx context.Context, subject LockoutSubject, key string) (time.Duration, error) {
+	lockKey, err := r.getKey(ctx, "lock", subject, key)
+	if err != nil {
+		return 0, err
+	}
+	ttl, err := r.redisInfra.Client.PTTL(ctx, lockKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	// 键不存在时返回 -2，没有过期时间时返回 -1（不会出现）
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// RecordFailure Implements [LockoutRepository.RecordFailure]
+func (r *lockoutRepositoryImpl) RecordFailure(ctx context.Context, subject LockoutSubject, key string, policy LockoutPolicy) (*LockoutResult, error) {
+	// Lua 脚本：保证「计数 → 判断 → 升级锁定等级 → 加锁」的原子性，并发失败不会漏算或重复加锁
+	// 锁定时长 = min(BaseLockout * 2^(level-1), MaxLockout)，至少 1 毫秒（Redis 不接受 PX 0）
+	luaScript := `
+		local failures = redis.call("INCR", KEYS[1])
+		if failures == 1 then
+			redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		end
+		if failures < tonumber(ARGV[1]) then
+			return {failures, 0, 0}
+		end
+
+		redis.call("DEL", KEYS[1])
+		local level = redis.call("INCR", KEYS[2])
+		redis.call("PEXPIRE", KEYS[2], ARGV[5])
+
+		local duration = tonumber(ARGV[3]) * math.pow(2, math.min(level - 1, 30))
+		if duration > tonumber(ARGV[4]) then
+			duration = tonumber(ARGV[4])
+		end
+		duration = math.floor(duration)
+		if duration < 1 then
+			duration = 1
+		end
+		redis.call("SET", KEYS[3], level, "PX", duration)
+		return {failures, level, duration}
+	`
+	keys, err := r.getKeys(ctx, subject, key)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := r.redisInfra.Client.Eval(ctx, luaScript, keys,
+		policy.MaxFailures,
+		policy.Window.Milliseconds(),
+		policy.BaseLockout.Milliseconds(),
+		policy.MaxLockout.Milliseconds(),
+		policy.LevelTTL.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	return &LockoutResult{
+		Failures:  vals[0],
+		Level:     vals[1],
+		LockedFor: time.Duration(vals[2]) * time.Millisecond,
+	}, nil
+}
+
+// ResetFailures Implements [LockoutRepository.ResetFailures]
+func (r *lockoutRepositoryImpl) ResetFailures(ctx context.Context, subject LockoutSubject, key string) error {
+	failuresKey, err := r.getKey(ctx, "failures", subject, key)
+	if err != nil {
+		return err
+	}
+	return r.redisInfra.Client.Del(ctx, failuresKey).Err()
+}
+
+// Clear Implements [LockoutRepository.Clear]
+func (r *lockoutRepositoryImpl) Clear(ctx context.Context, subject LockoutSubject, key string) error {
+	keys, err := r.getKeys(ctx, subject, key)
+	if err != nil {
+		return err
+	}
+	return r.redisInfra.Client.Del(ctx, keys...).Err()
+}
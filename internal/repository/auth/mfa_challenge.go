@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"hello-gozero/infra/cache"
+)
+
+const mfaChallengeKeyPrefix = "auth:mfa:challenge" // 两步验证挑战缓存键前缀
+
+// MFAChallenge 两步验证挑战
+// 用户名和密码校验通过、但账户启用了两步验证时创建，登录信息暂存在挑战中，动态口令校验通过后据此创建会话
+type MFAChallenge struct {
+	// 用户 ID（UUID 字符串）
+	UserID string
+
+	// 用户名
+	Username string
+
+	// 登录时上报的设备名称
+	Device string
+
+	// 登录时的客户端 IP
+	IP string
+
+	// 登录时的 User-Agent
+	UserAgent string
+
+	// 已尝试校验失败的次数
+	Attempts int64
+}
+
+// MFAChallengeRepository 定义两步验证挑战的存储接口
+// 存储层只保存挑战令牌的摘要（见 [token.HashRefreshToken]），不保存令牌明文
+type MFAChallengeRepository interface {
+	// Save 保存挑战，ttl 到期后自动失效
+	Save(ctx context.Context, tokenHash string, challenge *MFAChallenge, ttl time.Duration) error
+
+	// Get 获取挑战，挑战不存在（已使用或已过期）时返回 nil, nil
+	Get(ctx context.Context, tokenHash string) (*MFAChallenge, error)
+
+	// IncrAttempts 将挑战的失败次数加一并返回最新值，挑战不存在时返回 0
+	IncrAttempts(ctx context.Context, tokenHash string) (int64, error)
+
+	// Delete 删除挑战，返回挑战删除前是否存在
+	// 并发场景下只有一个调用方会得到 true，可用于保证挑战只能使用一次
+	Delete(ctx context.Context, tokenHash string) (bool, error)
+}
+
+// mfaChallengeRepositoryImpl Implements [MFAChallengeRepository]
+type mfaChallengeRepositoryImpl struct {
+	redisInfra *cache.RedisInfra
+}
+
+// NewMFAChallengeRepository 创建基于 Redis 的两步验证挑战仓库
+func NewMFAChallengeRepository(redisInfra *cache.RedisInfra) MFAChallengeRepository {
+	return &mfaChallengeRepositoryImpl{redisInfra: redisInfra}
+}
+
+// getKey 获取挑战的缓存键，值为 Hash（user_id、username、device、ip、user_agent、attempts）
+func (r *mfaChallengeRepositoryImpl) getKey(tokenHash string) string {
+	return mfaChallengeKeyPrefix + ":" + tokenHash
+}
+
+// Save Implements [MFAChallengeRepository.Save]
+func (r *mfaChallengeRepositoryImpl) Save(ctx context.Context, tokenHash string, challenge *MFAChallenge, ttl time.Duration) error {
+	if challenge == nil {
+		return errors.New("mfa challenge is nil")
+	}
+
+	key := r.getKey(tokenHash)
+	_, err := r.redisInfra.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"user_id", challenge.UserID,
+			"username", challenge.Username,
+			"device", challenge.Device,
+			"ip", challenge.IP,
+			"user_agent", challenge.UserAgent,
+			"attempts", 0,
+		)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+// Get Implements [MFAChallengeRepository.Get]
+func (r *mfaChallengeRepositoryImpl) Get(ctx context.Context, tokenHash string) (*MFAChallenge, error) {
+	fields, err := r.redisInfra.Client.HGetAll(ctx, r.getKey(tokenHash)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 || fields["user_id"] == "" {
+		return nil, nil
+	}
+
+	attempts, _ := strconv.ParseInt(fields["attempts"], 10, 64)
+	return &MFAChallenge{
+		UserID:    fields["user_id"],
+		Username:  fields["username"],
+		Device:    fields["device"],
+		IP:        fields["ip"],
+		UserAgent: fields["user_agent"],
+		Attempts:  attempts,
+	}, nil
+}
+
+// IncrAttempts Implements [MFAChallengeRepository.IncrAttempts]
+func (r *mfaChallengeRepositoryImpl) IncrAttempts(ctx context.Context, tokenHash string) (int64, error) {
+	// Lua 脚本：只在挑战存在时计数，避免 HINCRBY 创建出一个没有过期时间的空记录
+	luaScript := `
+		if redis.call("EXISTS", KEYS[1]) == 1 then
+			return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+		else
+			return 0
+		end
+	`
+	return r.redisInfra.Client.Eval(ctx, luaScript, []string{r.getKey(tokenHash)}).Int64()
+}
+
+// Delete Implements [MFAChallengeRepository.Delete]
+func (r *mfaChallengeRepositoryImpl) Delete(ctx context.Context, tokenHash string) (bool, error) {
+	n, err := r.redisInfra.Client.Del(ctx, r.getKey(tokenHash)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,97 @@
+// Package auth provides repository implementations for authentication data.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"hello-gozero/infra/cache"
+)
+
+const refreshTokenKeyPrefix = "auth:refresh" // 刷新令牌缓存键前缀
+
+// RefreshTokenRecord 刷新令牌对应的服务端记录
+type RefreshTokenRecord struct {
+	// 用户 ID（UUID 字符串）
+	UserID string `json:"user_id"`
+
+	// 用户名
+	Username string `json:"username"`
+
+	// 会话 ID，令牌轮换时保持不变
+	SessionID string `json:"session_id"`
+
+	// 签发时间（Unix 秒）
+	IssuedAt int64 `json:"issued_at"`
+}
+
+// RefreshTokenRepository 定义刷新令牌的存储接口
+// 存储层只保存刷新令牌的摘要（见 [token.HashRefreshToken]），不保存令牌明文
+type RefreshTokenRepository interface {
+	// Save 保存刷新令牌记录，ttl 到期后自动失效
+	Save(ctx context.Context, tokenHash string, record *RefreshTokenRecord, ttl time.Duration) error
+
+	// Consume 原子地取出并删除刷新令牌记录，保证每个刷新令牌只能使用一次（令牌轮换）
+	// 记录不存在（已使用、已过期或从未签发）时返回 nil, nil
+	Consume(ctx context.Context, tokenHash string) (*RefreshTokenRecord, error)
+
+	// Delete 删除刷新令牌记录
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+// refreshTokenRepositoryImpl Implements [RefreshTokenRepository]
+type refreshTokenRepositoryImpl struct {
+	redisInfra *cache.RedisInfra
+}
+
+// NewRefreshTokenRepository 创建基于 Redis 的刷新令牌仓库
+func NewRefreshTokenRepository(redisInfra *cache.RedisInfra) RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{redisInfra: redisInfra}
+}
+
+// getKey 获取刷新令牌摘要对应的缓存键
+func (r *refreshTokenRepositoryImpl) getKey(tokenHash string) string {
+	return refreshTokenKeyPrefix + ":" + tokenHash
+}
+
+// Save Implements [RefreshTokenRepository.Save]
+func (r *refreshTokenRepositoryImpl) Save(ctx context.Context, tokenHash string, record *RefreshTokenRecord, ttl time.Duration) error {
+	if record == nil {
+		return errors.New("refresh token record is nil")
+	}
+	val, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal refresh token record: %w", err)
+	}
+	return r.redisInfra.Client.Set(ctx, r.getKey(tokenHash), val, ttl).Err()
+}
+
+// Consume Implements [RefreshTokenRepository.Consume]
+//
+// 使用 GETDEL 保证「读取 + 删除」的原子性：
+// 并发使用同一个刷新令牌时只有一个请求能拿到记录，其余请求视为令牌无效
+func (r *refreshTokenRepositoryImpl) Consume(ctx context.Context, tokenHash string) (*RefreshTokenRecord, error) {
+	val, err := r.redisInfra.Client.GetDel(ctx, r.getKey(tokenHash)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record RefreshTokenRecord
+	if err := json.Unmarshal(val, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal refresh token record: %w", err)
+	}
+	return &record, nil
+}
+
+// Delete Implements [RefreshTokenRepository.Delete]
+func (r *refreshTokenRepositoryImpl) Delete(ctx context.Context, tokenHash string) error {
+	return r.redisInfra.Client.Del(ctx, r.getKey(tokenHash)).Err()
+}
@@ -0,0 +1,297 @@
+package auth
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"hello-gozero/infra/cache"
+)
+
+const (
+	userSessionsKeyPrefix = "user:sessions" // 用户会话集合缓存键前缀，值为该用户所有会话 ID 的 Set
+	sessionKeyPrefix      = "user:session"  // 单个会话缓存键前缀，值为会话记录 JSON
+)
+
+// Session 登录会话
+// 一次登录对应一个会话，会话内的刷新令牌轮换时会话 ID 保持不变
+type Session struct {
+	// 会话 ID
+	ID string `json:"id"`
+
+	// 用户 ID（UUID 字符串）
+	UserID string `json:"user_id"`
+
+	// 用户名
+	Username string `json:"username"`
+
+	// 用户所属租户 ID，为 0 表示引入多租户之前创建的会话（属于默认租户）
+	TenantID uint64 `json:"tenant_id,omitempty"`
+
+	// 设备名称（由客户端登录时上报，可为空）
+	Device string `json:"device"`
+
+	// 登录时的客户端 IP（不含端口，经可信代理转发时取 X-Forwarded-For 中最右侧的非代理地址）
+	IP string `json:"ip"`
+
+	// 登录时的 User-Agent
+	UserAgent string `json:"user_agent"`
+
+	// 会话当前有效的刷新令牌摘要，吊销会话时一并删除
+	RefreshTokenHash string `json:"refresh_token_hash"`
+
+	// 创建时间（Unix 秒）
+	CreatedAt int64 `json:"created_at"`
+
+	// 最近活跃时间（最近一次登录或刷新令牌的时间，Unix 秒）
+	LastSeenAt int64 `json:"last_seen_at"`
+}
+
+// SessionRepository 定义登录会话的存储接口
+type SessionRepository interface {
+	// Save 创建或更新会话，ttl 到期后会话自动失效
+	Save(ctx context.Context, session *Session, ttl time.Duration) error
+
+	// Update 仅在会话仍然存在时更新会话并延长有效期，会话已被吊销或已过期时不写入并返回 false
+	// 用于刷新令牌，避免与登出、吊销并发时把已吊销的会话重新写回
+	Update(ctx context.Context, session *Session, ttl time.Duration) (bool, error)
+
+	// Get 获取会话，会话不存在（已吊销或已过期）时返回 nil, nil
+	Get(ctx context.Context, sessionID string) (*Session, error)
+
+	// Exists 检查会话是否仍然有效，用于在认证中间件中识别已被吊销的访问令牌
+	Exists(ctx context.Context, sessionID string) (bool, error)
+
+	// ListByUser 获取用户所有有效会话
+	ListByUser(ctx context.Context, userID string) ([]*Session, error)
+
+	// Delete 吊销指定会话，同时删除该会话当前的刷新令牌
+	Delete(ctx context.Context, userID, sessionID string) error
+
+	// DeleteAllByUser 吊销用户的所有会话（exceptSessionIDs 中的会话除外），返回吊销的会话数量
+	DeleteAllByUser(ctx context.Context, userID string, exceptSessionIDs ...string) (int, error)
+}
+
+// sessionRepositoryImpl Implements [SessionRepository]
+type sessionRepositoryImpl struct {
+	redisInfra *cache.RedisInfra
+}
+
+// NewSessionRepository 创建基于 Redis 的会话仓库
+func NewSessionRepository(redisInfra *cache.RedisInfra) SessionRepository {
+	return &sessionRepositoryImpl{redisInfra: redisInfra}
+}
+
+// getUserSessionsKey 获取用户会话集合的缓存键
+func (r *sessionRepositoryImpl) getUserSessionsKey(userID string) string {
+	return userSessionsKeyPrefix + ":" + userID
+}
+
+// getSessionKey 获取单个会话的缓存键
+func (r *sessionRepositoryImpl) getSessionKey(sessionID string) string {
+	return sessionKeyPrefix + ":" + sessionID
+}
+
+// Save Implements [SessionRepository.Save]
+//
+// 会话记录与用户会话集合在同一个事务管道中写入；
+// 会话集合的过期时间随最新的会话一起延长，保证集合不会早于其中的会话过期
+func (r *sessionRepositoryImpl) Save(ctx context.Context, session *Session, ttl time.Duration) error {
+	if session == nil {
+		return errors.New("session is nil")
+	}
+	val, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	userSessionsKey := r.getUserSessionsKey(session.UserID)
+	_, err = r.redisInfra.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, r.getSessionKey(session.ID), val, ttl)
+		pipe.SAdd(ctx, userSessionsKey, session.ID)
+		pipe.Expire(ctx, userSessionsKey, ttl)
+		return nil
+	})
+	return err
+}
+
+// Update Implements [SessionRepository.Update]
+//
+// 检查会话存在与写入在同一个 Lua 脚本中完成，吊销（删除会话记录）不会夹在两者之间
+func (r *sessionRepositoryImpl) Update(ctx context.Context, session *Session, ttl time.Duration) (bool, error) {
+	if session == nil {
+		return false, errors.New("session is nil")
+	}
+	val, err := json.Marshal(session)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	// KEYS[1]: 会话键，KEYS[2]: 用户会话集合键
+	// ARGV[1]: 会话记录 JSON，ARGV[2]: 有效期（毫秒），ARGV[3]: 会话 ID
+	luaScript := `
+		if redis.call("EXISTS", KEYS[1]) == 0 then
+			return 0
+		end
+		redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+		redis.call("SADD", KEYS[2], ARGV[3])
+		redis.call("PEXPIRE", KEYS[2], ARGV[2])
+		return 1
+	`
+	keys := []string{r.getSessionKey(session.ID), r.getUserSessionsKey(session.UserID)}
+	updated, err := r.redisInfra.Client.Eval(ctx, luaScript, keys, string(val), ttl.Milliseconds(), session.ID).Int64()
+	if err != nil {
+		return false, err
+	}
+	return updated == 1, nil
+}
+
+// Get Implements [SessionRepository.Get]
+func (r *sessionRepositoryImpl) Get(ctx context.Context, sessionID string) (*Session, error) {
+	val, err := r.redisInfra.Client.Get(ctx, r.getSessionKey(sessionID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(val, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	return &session, nil
+}
+
+// Exists Implements [SessionRepository.Exists]
+func (r *sessionRepositoryImpl) Exists(ctx context.Context, sessionID string) (bool, error) {
+	n, err := r.redisInfra.Client.Exists(ctx, r.getSessionKey(sessionID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ListByUser Implements [SessionRepository.ListByUser]
+//
+// 会话记录过期后，会话 ID 仍可能残留在用户会话集合中，读取时顺带清理
+func (r *sessionRepositoryImpl) ListByUser(ctx context.Context, userID string) ([]*Session, error) {
+	sessionIDs, err := r.redisInfra.Client.SMembers(ctx, r.getUserSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, staleIDs, err := r.getMany(ctx, sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(staleIDs) > 0 {
+		_ = r.redisInfra.Client.SRem(ctx, r.getUserSessionsKey(userID), staleIDs...).Err()
+	}
+
+	// 按最近活跃时间倒序
+	slices.SortFunc(sessions, func(a, b *Session) int {
+		return cmp.Compare(b.LastSeenAt, a.LastSeenAt)
+	})
+	return sessions, nil
+}
+
+// Delete Implements [SessionRepository.Delete]
+func (r *sessionRepositoryImpl) Delete(ctx context.Context, userID, sessionID string) error {
+	session, err := r.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redisInfra.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, r.getSessionKey(sessionID))
+		pipe.SRem(ctx, r.getUserSessionsKey(userID), sessionID)
+		if session != nil && session.RefreshTokenHash != "" {
+			pipe.Del(ctx, refreshTokenKeyPrefix+":"+session.RefreshTokenHash)
+		}
+		return nil
+	})
+	return err
+}
+
+// DeleteAllByUser Implements [SessionRepository.DeleteAllByUser]
+func (r *sessionRepositoryImpl) DeleteAllByUser(ctx context.Context, userID string, exceptSessionIDs ...string) (int, error) {
+	userSessionsKey := r.getUserSessionsKey(userID)
+	sessionIDs, err := r.redisInfra.Client.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	targetIDs := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		if !slices.Contains(exceptSessionIDs, sessionID) {
+			targetIDs = append(targetIDs, sessionID)
+		}
+	}
+	if len(targetIDs) == 0 {
+		return 0, nil
+	}
+
+	sessions, _, err := r.getMany(ctx, targetIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = r.redisInfra.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, sessionID := range targetIDs {
+			pipe.Del(ctx, r.getSessionKey(sessionID))
+			pipe.SRem(ctx, userSessionsKey, sessionID)
+		}
+		for _, session := range sessions {
+			if session.RefreshTokenHash != "" {
+				pipe.Del(ctx, refreshTokenKeyPrefix+":"+session.RefreshTokenHash)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(sessions), nil
+}
+
+// getMany 批量读取会话，返回有效的会话以及已经不存在的会话 ID
+func (r *sessionRepositoryImpl) getMany(ctx context.Context, sessionIDs []string) ([]*Session, []interface{}, error) {
+	sessions := make([]*Session, 0, len(sessionIDs))
+	staleIDs := make([]interface{}, 0)
+	if len(sessionIDs) == 0 {
+		return sessions, staleIDs, nil
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, r.getSessionKey(sessionID))
+	}
+	vals, err := r.redisInfra.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			// 会话已过期或被删除
+			staleIDs = append(staleIDs, sessionIDs[i])
+			continue
+		}
+		var session Session
+		if err := json.Unmarshal([]byte(str), &session); err != nil {
+			// 反序列化失败的记录视为无效会话
+			staleIDs = append(staleIDs, sessionIDs[i])
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+	return sessions, staleIDs, nil
+}
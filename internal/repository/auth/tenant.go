@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+
+	"hello-gozero/internal/tenant"
+)
+
+// tenantScopedKey 为用户名、邮箱等只在租户内唯一的标识加上上下文中的租户 ID，格式为 <租户 ID>:<key>
+// 上下文中没有租户时返回 [tenant.ErrMissing]，避免不同租户中的同名用户共享计数与验证码
+func tenantScopedKey(ctx context.Context, key string) (string, error) {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", tenant.ErrMissing
+	}
+	return strconv.FormatUint(t.ID, 10) + ":" + key, nil
+}
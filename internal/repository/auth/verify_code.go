@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"hello-gozero/infra/cache"
+)
+
+const verifyCodeKeyPrefix = "auth:code" // 验证码缓存键前缀
+
+// VerifyCodePurpose 验证码用途，不同用途的验证码互相隔离
+type VerifyCodePurpose string
+
+const (
+	// PurposePasswordReset 忘记密码
+	PurposePasswordReset VerifyCodePurpose = "password_reset"
+
+	// PurposeEmailVerify 邮箱验证（注册、更换邮箱）
+	PurposeEmailVerify VerifyCodePurpose = "email_verify"
+)
+
+// 计数器名称
+const (
+	// CounterRequests 申请验证码次数
+	CounterRequests = "requests"
+
+	// CounterFailures 校验失败次数
+	CounterFailures = "failures"
+)
+
+// VerifyCode 一次性验证码记录
+type VerifyCode struct {
+	// 验证码所属的用户 ID（UUID 字符串）
+	UserID string
+
+	// 验证码摘要（见 [token.HashVerifyCode]），不保存验证码明文
+	CodeHash string
+
+	// 附加数据，由具体用途决定含义，如邮箱验证时为待验证的邮箱
+	Payload string
+
+	// 已尝试校验失败的次数
+	Attempts int64
+}
+
+// VerifyCodeRepository 定义一次性验证码的存储接口
+//
+// 每个用途下，同一个接收方（如邮箱）同一时间只保留一个有效的验证码，重新下发时覆盖旧验证码；
+// 同时提供按时间窗口计数的计数器，用于限制下发频率与校验失败次数；
+// 接收方（邮箱只在租户内唯一）按上下文中的租户隔离，上下文中没有租户时返回 [tenant.ErrMissing]
+type VerifyCodeRepository interface {
+	// Save 保存验证码，覆盖该接收方已有的验证码并重置尝试次数，ttl 到期后自动失效
+	Save(ctx context.Context, purpose VerifyCodePurpose, target string, code *VerifyCode, ttl time.Duration) error
+
+	// Get 获取验证码，验证码不存在（已使用或已过期）时返回 nil, nil
+	Get(ctx context.Context, purpose VerifyCodePurpose, target string) (*VerifyCode, error)
+
+	// IncrAttempts 将验证码的失败次数加一并返回最新值，验证码不存在时返回 0
+	IncrAttempts(ctx context.Context, purpose VerifyCodePurpose, target string) (int64, error)
+
+	// Delete 仅当当前保存的验证码摘要等于 codeHash 时删除验证码，返回是否删除
+	// 并发场景下只有一个调用方会得到 true，可用于保证验证码只能使用一次；
+	// 校验之后验证码被重新下发时不会误删新的验证码
+	Delete(ctx context.Context, purpose VerifyCodePurpose, target, codeHash string) (bool, error)
+
+	// IncrCounter 将接收方在当前时间窗口内的计数器加一并返回最新值，计数器在首次计数 window 后过期
+	IncrCounter(ctx context.Context, purpose VerifyCodePurpose, counter, target string, window time.Duration) (int64, error)
+
+	// GetCounter 获取接收方在当前时间窗口内的计数器，计数器不存在时返回 0
+	GetCounter(ctx context.Context, purpose VerifyCodePurpose, counter, target string) (int64, error)
+
+	// DeleteCounter 删除接收方的计数器
+	DeleteCounter(ctx context.Context, purpose VerifyCodePurpose, counter, target string) error
+
+	// Purge 删除接收方的验证码与全部计数器
+	Purge(ctx context.Context, purpose VerifyCodePurpose, target string) error
+}
+
+// verifyCodeRepositoryImpl Implements [VerifyCodeRepository]
+type verifyCodeRepositoryImpl struct {
+	redisInfra *cache.RedisInfra
+}
+
+// NewVerifyCodeRepository 创建基于 Redis 的验证码仓库
+func NewVerifyCodeRepository(redisInfra *cache.RedisInfra) VerifyCodeRepository {
+	return &verifyCodeRepositoryImpl{redisInfra: redisInfra}
+}
+
+// getCodeKey 获取验证码的缓存键，值为 Hash（user_id、code_hash、payload、attempts）；target 已包含租户 ID（见 [tenantScopedKey]）
+func (r *verifyCodeRepositoryImpl) getCodeKey(purpose VerifyCodePurpose, target string) string {
+	return verifyCodeKeyPrefix + ":" + string(purpose) + ":" + target
+}
+
+// getCounterKey 获取计数器的缓存键
+func (r *verifyCodeRepositoryImpl) getCounterKey(purpose VerifyCodePurpose, counter, target string) string {
+	return verifyCodeKeyPrefix + ":" + string(purpose) + ":" + counter + ":" + target
+}
+
+// Save Implements [VerifyCodeRepository.Save]
+func (r *verifyCodeRepositoryImpl) Save(ctx context.Context, purpose VerifyCodePurpose, target string, code *VerifyCode, ttl time.Duration) error {
+	if code == nil {
+		return errors.New("verify code is nil")
+	}
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	key := r.getCodeKey(purpose, target)
+	_, err = r.redisInfra.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, "user_id", code.UserID, "code_hash", code.CodeHash, "payload", code.Payload, "attempts", 0)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+// Get Implements [VerifyCodeRepository.Get]
+func (r *verifyCodeRepositoryImpl) Get(ctx context.Context, purpose VerifyCodePurpose, target string) (*VerifyCode, error) {
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := r.redisInfra.Client.HGetAll(ctx, r.getCodeKey(purpose, target)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 || fields["code_hash"] == "" {
+		return nil, nil
+	}
+
+	attempts, _ := strconv.ParseInt(fields["attempts"], 10, 64)
+	return &VerifyCode{
+		UserID:   fields["user_id"],
+		CodeHash: fields["code_hash"],
+		Payload:  fields["payload"],
+		Attempts: attempts,
+	}, nil
+}
+
+// IncrAttempts Implements [VerifyCodeRepository.IncrAttempts]
+func (r *verifyCodeRepositoryImpl) IncrAttempts(ctx context.Context, purpose VerifyCodePurpose, target string) (int64, error) {
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	// Lua 脚本：只在验证码存在时计数，避免 HINCRBY 创建出一个没有过期时间的空记录
+	luaScript := `
+		if redis.call("EXISTS", KEYS[1]) == 1 then
+			return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+		else
+			return 0
+		end
+	`
+	return r.redisInfra.Client.Eval(ctx, luaScript, []string{r.getCodeKey(purpose, target)}).Int64()
+}
+
+// Delete Implements [VerifyCodeRepository.Delete]
+func (r *verifyCodeRepositoryImpl) Delete(ctx context.Context, purpose VerifyCodePurpose, target, codeHash string) (bool, error) {
+	if codeHash == "" {
+		return false, errors.New("verify code hash is empty")
+	}
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return false, err
+	}
+	// Lua 脚本：比较摘要与删除在同一个脚本中完成
+	luaScript := `
+		if redis.call("HGET", KEYS[1], "code_hash") == ARGV[1] then
+			return redis.call("DEL", KEYS[1])
+		else
+			return 0
+		end
+	`
+	n, err := r.redisInfra.Client.Eval(ctx, luaScript, []string{r.getCodeKey(purpose, target)}, codeHash).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// IncrCounter Implements [VerifyCodeRepository.IncrCounter]
+func (r *verifyCodeRepositoryImpl) IncrCounter(ctx context.Context, purpose VerifyCodePurpose, counter, target string, window time.Duration) (int64, error) {
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	// Lua 脚本：首次计数时设置过期时间，保证 INCR 与 EXPIRE 的原子性（固定时间窗口）
+	luaScript := `
+		local count = redis.call("INCR", KEYS[1])
+		if count == 1 then
+			redis.call("EXPIRE", KEYS[1], ARGV[1])
+		end
+		return count
+	`
+	key := r.getCounterKey(purpose, counter, target)
+	return r.redisInfra.Client.Eval(ctx, luaScript, []string{key}, int(window.Seconds())).Int64()
+}
+
+// GetCounter Implements [VerifyCodeRepository.GetCounter]
+func (r *verifyCodeRepositoryImpl) GetCounter(ctx context.Context, purpose VerifyCodePurpose, counter, target string) (int64, error) {
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	count, err := r.redisInfra.Client.Get(ctx, r.getCounterKey(purpose, counter, target)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteCounter Implements [VerifyCodeRepository.DeleteCounter]
+func (r *verifyCodeRepositoryImpl) DeleteCounter(ctx context.Context, purpose VerifyCodePurpose, counter, target string) error {
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return err
+	}
+	return r.redisInfra.Client.Del(ctx, r.getCounterKey(purpose, counter, target)).Err()
+}
+
+// Purge Implements [VerifyCodeRepository.Purge]
+func (r *verifyCodeRepositoryImpl) Purge(ctx context.Context, purpose VerifyCodePurpose, target string) error {
+	target, err := tenantScopedKey(ctx, target)
+	if err != nil {
+		return err
+	}
+	return r.redisInfra.Client.Del(ctx,
+		r.getCodeKey(purpose, target),
+		r.getCounterKey(purpose, CounterRequests, target),
+		r.getCounterKey(purpose, CounterFailures, target),
+	).Err()
+}
@@ -6,11 +6,13 @@ import (
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"hello-gozero/infra/cache"
 	"hello-gozero/internal/constant/infra"
 	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/tenant"
 
 	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
@@ -36,14 +38,16 @@ type CachedUserEntity struct {
 
 // CachedUserRepository 定义用户缓存接口
 // 带缓存的装饰器，用于特殊场景，如：防重复提交、限流
+//
+// 用户名只在租户内唯一，缓存键包含租户 ID；按用户名读取与删除缓存时使用上下文中的租户，上下文中没有租户时返回 [tenant.ErrMissing]
 type CachedUserRepository interface {
-	// GetCachedKey 获取指定用户名的缓存键，通常应该是内部使用
-	GetCachedKey(username string) string
+	// GetCachedKey 获取指定租户中用户名的缓存键，通常应该是内部使用
+	GetCachedKey(tenantID uint64, username string) string
 
 	// GetByUsername 从缓存获取用户，如果未命中则回源数据库
 	GetByUsername(ctx context.Context, username string) (*CachedUserEntity, error)
 
-	// SetByUsername 将用户信息写入缓存
+	// SetByUsername 将用户信息写入缓存（使用用户所属的租户）
 	SetByUsername(ctx context.Context, user *CachedUserEntity) error
 
 	// DeleteByUsername 删除指定用户名的缓存
@@ -75,8 +79,18 @@ func NewCachedUserRepository(redisInfra *cache.RedisInfra, repo UserRepository)
 }
 
 // GetCachedKey Implements [CachedUserRepository.GetCachedKey]
-func (c *CachedUserRepositoryImpl) GetCachedKey(username string) string {
-	return cacheKeyPrefix + ":" + username
+// 格式为 user:profile:<租户 ID>:<用户名>
+func (c *CachedUserRepositoryImpl) GetCachedKey(tenantID uint64, username string) string {
+	return cacheKeyPrefix + ":" + strconv.FormatUint(tenantID, 10) + ":" + username
+}
+
+// getCachedKeyFromContext 获取上下文租户中用户名的缓存键
+func (c *CachedUserRepositoryImpl) getCachedKeyFromContext(ctx context.Context, username string) (string, error) {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", tenant.ErrMissing
+	}
+	return c.GetCachedKey(t.ID, username), nil
 }
 
 // GetByUsername Implements [CachedUserRepository.GetByUsername]
@@ -91,7 +105,10 @@ func (c *CachedUserRepositoryImpl) GetCachedKey(username string) string {
 // 如果需要跨语言支持，建议使用 JSON、MessagePack、Protobuf 等通用格式。
 func (c *CachedUserRepositoryImpl) GetByUsername(ctx context.Context, username string) (*CachedUserEntity, error) {
 	// 尝试从缓存中读取数据
-	key := cacheKeyPrefix + ":" + username
+	key, err := c.getCachedKeyFromContext(ctx, username)
+	if err != nil {
+		return nil, err
+	}
 	val, err := c.redisInfra.Client.Get(ctx, key).Bytes()
 	if err == nil {
 		// 检查是否是空值标记
@@ -114,8 +131,8 @@ func (c *CachedUserRepositoryImpl) GetByUsername(ctx context.Context, username s
 
 	// Cache miss or error, fallback to DB
 	// 缓存未命中或反序列化失败，回源到数据库
-	// ⚡ 使用 singleflight：相同 username 的请求会等待首个 DB 查询结果
-	result, err, _ := c.group.Do(username, func() (interface{}, error) {
+	// ⚡ 使用 singleflight：相同租户中相同 username 的请求会等待首个 DB 查询结果
+	result, err, _ := c.group.Do(key, func() (interface{}, error) {
 		dbUser, dbErr := c.repo.GetByUsername(ctx, username)
 		if dbErr != nil {
 			// 如果是“用户不存在”错误，我们缓存空值
@@ -133,7 +150,7 @@ func (c *CachedUserRepositoryImpl) GetByUsername(ctx context.Context, username s
 		// 如果是“用户不存在”，缓存空值
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// 缓存空值，TTL 较短（如 60 秒）
-			_ = c.setEmptyUserCache(ctx, username, 60*time.Second)
+			_ = c.setEmptyUserCache(ctx, key, 60*time.Second)
 		}
 		// 数据库查询失败，直接返回错误（不缓存错误）
 		return nil, err
@@ -182,7 +199,7 @@ func (c *CachedUserRepositoryImpl) SetByUsername(ctx context.Context, cachedEnti
 		return err
 	}
 
-	key := c.GetCachedKey(cachedEntity.User.Username)
+	key := c.GetCachedKey(cachedEntity.User.TenantID, cachedEntity.User.Username)
 	// 写入 Redis，设置过期时间（cacheTTL）
 	// 	二、缓存雪崩（Cache Avalanche）
 	// 🔍 问题表现
@@ -193,8 +210,7 @@ func (c *CachedUserRepositoryImpl) SetByUsername(ctx context.Context, cachedEnti
 }
 
 // setEmptyUserCache 缓存一个“空用户”标记，防止缓存穿透
-func (c *CachedUserRepositoryImpl) setEmptyUserCache(ctx context.Context, username string, ttl time.Duration) error {
-	key := c.GetCachedKey(username)
+func (c *CachedUserRepositoryImpl) setEmptyUserCache(ctx context.Context, key string, ttl time.Duration) error {
 	// 方式 1：存一个特殊字符串
 	return c.redisInfra.Client.Set(ctx, key, cachedEmptyValue, ttl).Err()
 
@@ -208,6 +224,9 @@ func (c *CachedUserRepositoryImpl) setEmptyUserCache(ctx context.Context, userna
 // 删除指定用户名的缓存，包括正常缓存和空值标记缓存
 // 返回 Redis Del 命令的错误（如果有）
 func (c *CachedUserRepositoryImpl) DeleteByUsername(ctx context.Context, username string) error {
-	key := c.GetCachedKey(username)
+	key, err := c.getCachedKeyFromContext(ctx, username)
+	if err != nil {
+		return err
+	}
 	return c.redisInfra.Client.Del(ctx, key).Err()
 }
@@ -0,0 +1,199 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userEntity "hello-gozero/internal/entity/user"
+)
+
+// DataExportRepository 定义个人数据导出任务的数据操作接口
+// 按任务 ID 或用户获取导出任务时限定在上下文中租户的用户内，其他租户的任务视为不存在；
+// 后台任务按任务 ID 处理时不限定租户
+type DataExportRepository interface {
+	// Create 创建导出任务
+	Create(ctx context.Context, export *userEntity.DataExport) error
+
+	// GetByID 根据任务 ID 获取导出任务
+	GetByID(ctx context.Context, id []byte) (*userEntity.DataExport, error)
+
+	// GetActiveByUser 获取用户等待处理或处理中的导出任务，没有时返回 gorm.ErrRecordNotFound
+	GetActiveByUser(ctx context.Context, userID []byte) (*userEntity.DataExport, error)
+
+	// ClaimNext 领取一个待处理的任务：等待处理的任务，或开始处理时间早于 staleBefore 仍未完成的任务（处理它的实例已退出）
+	// 领取后状态变为处理中并增加尝试次数；多个实例并发领取时不会领到同一个任务，没有任务时返回 nil, nil
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*userEntity.DataExport, error)
+
+	// Complete 标记任务完成
+	Complete(ctx context.Context, id []byte, blobKey string, size int64, completedAt, expiresAt time.Time) error
+
+	// Fail 记录失败原因；final 为 true 时标记为失败，否则重新等待处理
+	Fail(ctx context.Context, id []byte, reason string, final bool) error
+
+	// ListExpired 获取归档已过期但尚未清理的任务（最多 limit 个）
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*userEntity.DataExport, error)
+
+	// MarkExpired 标记任务已过期（归档已删除）
+	MarkExpired(ctx context.Context, id []byte) error
+
+	// ListByUser 获取用户的全部导出任务
+	ListByUser(ctx context.Context, userID []byte) ([]*userEntity.DataExport, error)
+
+	// Delete 删除导出任务
+	Delete(ctx context.Context, id []byte) error
+}
+
+type dataExportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewDataExportRepository 创建一个新的 DataExportRepository 实例
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &dataExportRepositoryImpl{db: db}
+}
+
+// Create Implements [DataExportRepository.Create]
+func (r *dataExportRepositoryImpl) Create(ctx context.Context, export *userEntity.DataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+// GetByID Implements [DataExportRepository.GetByID]
+func (r *dataExportRepositoryImpl) GetByID(ctx context.Context, id []byte) (*userEntity.DataExport, error) {
+	var export userEntity.DataExport
+	if err := r.db.WithContext(ctx).Scopes(byTenantUser(ctx)).Where("id = ?", id).First(&export).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// GetActiveByUser Implements [DataExportRepository.GetActiveByUser]
+func (r *dataExportRepositoryImpl) GetActiveByUser(ctx context.Context, userID []byte) (*userEntity.DataExport, error) {
+	var export userEntity.DataExport
+	err := r.db.WithContext(ctx).
+		Scopes(byTenantUser(ctx)).
+		Where("user_id = ? AND status IN ?", userID, []int8{userConstant.ExportStatusPending, userConstant.ExportStatusRunning}).
+		Order("created_at DESC").
+		First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ClaimNext Implements [DataExportRepository.ClaimNext]
+func (r *dataExportRepositoryImpl) ClaimNext(ctx context.Context, staleBefore time.Time) (*userEntity.DataExport, error) {
+	var claimed *userEntity.DataExport
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var export userEntity.DataExport
+		// SKIP LOCKED：跳过其他实例正在领取的任务（MySQL 8.0+）
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND started_at < ?)",
+				userConstant.ExportStatusPending, userConstant.ExportStatusRunning, staleBefore).
+			Order("created_at").
+			First(&export).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&userEntity.DataExport{}).
+			Where("id = ?", export.ID).
+			Updates(map[string]interface{}{
+				"status":     userConstant.ExportStatusRunning,
+				"started_at": now,
+				"attempts":   gorm.Expr("attempts + 1"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		export.Status = userConstant.ExportStatusRunning
+		export.StartedAt = &now
+		export.Attempts++
+		claimed = &export
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// Complete Implements [DataExportRepository.Complete]
+func (r *dataExportRepositoryImpl) Complete(ctx context.Context, id []byte, blobKey string, size int64, completedAt, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&userEntity.DataExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       userConstant.ExportStatusCompleted,
+			"blob_key":     blobKey,
+			"size":         size,
+			"error":        "",
+			"completed_at": completedAt,
+			"expires_at":   expiresAt,
+		}).Error
+}
+
+// Fail Implements [DataExportRepository.Fail]
+func (r *dataExportRepositoryImpl) Fail(ctx context.Context, id []byte, reason string, final bool) error {
+	status := userConstant.ExportStatusPending
+	if final {
+		status = userConstant.ExportStatusFailed
+	}
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+	return r.db.WithContext(ctx).
+		Model(&userEntity.DataExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status": status,
+			"error":  reason,
+		}).Error
+}
+
+// ListExpired Implements [DataExportRepository.ListExpired]
+func (r *dataExportRepositoryImpl) ListExpired(ctx context.Context, now time.Time, limit int) ([]*userEntity.DataExport, error) {
+	var exports []*userEntity.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", userConstant.ExportStatusCompleted, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// MarkExpired Implements [DataExportRepository.MarkExpired]
+func (r *dataExportRepositoryImpl) MarkExpired(ctx context.Context, id []byte) error {
+	return r.db.WithContext(ctx).
+		Model(&userEntity.DataExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":   userConstant.ExportStatusExpired,
+			"blob_key": "",
+		}).Error
+}
+
+// ListByUser Implements [DataExportRepository.ListByUser]
+func (r *dataExportRepositoryImpl) ListByUser(ctx context.Context, userID []byte) ([]*userEntity.DataExport, error) {
+	var exports []*userEntity.DataExport
+	if err := r.db.WithContext(ctx).Scopes(byTenantUser(ctx)).Where("user_id = ?", userID).Find(&exports).Error; err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// Delete Implements [DataExportRepository.Delete]
+func (r *dataExportRepositoryImpl) Delete(ctx context.Context, id []byte) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&userEntity.DataExport{}).Error
+}
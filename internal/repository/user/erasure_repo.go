@@ -0,0 +1,185 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/tenant"
+)
+
+// ErasureRepository 定义个人数据擦除记录的数据操作接口
+// 按用户 ID 获取与领取限定在上下文中的租户内，其他租户的擦除记录视为不存在；后台任务领取任意记录时不限定租户
+type ErasureRepository interface {
+	// CreateOrGet 创建擦除记录并立即标记为处理中，记录归属到上下文中的租户；用户已有擦除记录时不覆盖，返回已有记录
+	// 返回的 bool 表示是否新建
+	CreateOrGet(ctx context.Context, erasure *userEntity.UserErasure) (*userEntity.UserErasure, bool, error)
+
+	// Get 获取用户的擦除记录，不存在时返回 gorm.ErrRecordNotFound
+	Get(ctx context.Context, userID []byte) (*userEntity.UserErasure, error)
+
+	// Claim 领取指定用户的擦除记录：等待处理（不论是否到达重试时间），或开始处理时间早于 staleBefore 仍未完成（处理它的实例已退出）
+	// 领取后状态变为处理中并增加尝试次数；无法领取（已完成或正在被处理）时返回 nil, nil
+	Claim(ctx context.Context, userID []byte, staleBefore time.Time) (*userEntity.UserErasure, error)
+
+	// ClaimNext 领取任意一个可以处理的擦除记录：已到达重试时间的等待处理记录，或开始处理时间早于 staleBefore 仍未完成的记录
+	// 多个实例并发领取时不会领到同一个记录，没有时返回 nil, nil
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*userEntity.UserErasure, error)
+
+	// SaveStep 记录已完成的步骤
+	SaveStep(ctx context.Context, userID []byte, step int) error
+
+	// Fail 记录失败原因，重新等待处理，retryAt 之前不会被 [ErasureRepository.ClaimNext] 领取
+	Fail(ctx context.Context, userID []byte, reason string, retryAt time.Time) error
+
+	// Complete 标记擦除完成，并清空擦除前的用户名与邮箱
+	Complete(ctx context.Context, userID []byte, completedAt time.Time) error
+}
+
+type erasureRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewErasureRepository 创建一个新的 ErasureRepository 实例
+func NewErasureRepository(db *gorm.DB) ErasureRepository {
+	return &erasureRepositoryImpl{db: db}
+}
+
+// CreateOrGet Implements [ErasureRepository.CreateOrGet]
+func (r *erasureRepositoryImpl) CreateOrGet(ctx context.Context, erasure *userEntity.UserErasure) (*userEntity.UserErasure, bool, error) {
+	now := time.Now()
+	erasure.Status = userConstant.ErasureStatusRunning
+	erasure.StartedAt = &now
+	erasure.Attempts = 1
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, false, tenant.ErrMissing
+	}
+	erasure.TenantID = t.ID
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(erasure)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return erasure, true, nil
+	}
+	existing, err := r.Get(ctx, erasure.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+// Get Implements [ErasureRepository.Get]
+func (r *erasureRepositoryImpl) Get(ctx context.Context, userID []byte) (*userEntity.UserErasure, error) {
+	var erasure userEntity.UserErasure
+	err := r.db.WithContext(ctx).
+		Scopes(byTenantColumn(ctx, "tenant_id")).
+		Where("user_id = ?", userID).
+		First(&erasure).Error
+	if err != nil {
+		return nil, err
+	}
+	return &erasure, nil
+}
+
+// Claim Implements [ErasureRepository.Claim]
+func (r *erasureRepositoryImpl) Claim(ctx context.Context, userID []byte, staleBefore time.Time) (*userEntity.UserErasure, error) {
+	return r.claim(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.Scopes(byTenantColumn(ctx, "tenant_id")).
+			Where("user_id = ?", userID).
+			Where("status = ? OR (status = ? AND started_at < ?)",
+				userConstant.ErasureStatusPending, userConstant.ErasureStatusRunning, staleBefore)
+	})
+}
+
+// ClaimNext Implements [ErasureRepository.ClaimNext]
+func (r *erasureRepositoryImpl) ClaimNext(ctx context.Context, staleBefore time.Time) (*userEntity.UserErasure, error) {
+	return r.claim(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("(status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)) OR (status = ? AND started_at < ?)",
+			userConstant.ErasureStatusPending, time.Now(), userConstant.ErasureStatusRunning, staleBefore).
+			Order("created_at")
+	})
+}
+
+// claim 在事务中锁定并领取一条满足 scope 条件的擦除记录
+func (r *erasureRepositoryImpl) claim(ctx context.Context, scope func(tx *gorm.DB) *gorm.DB) (*userEntity.UserErasure, error) {
+	var claimed *userEntity.UserErasure
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var erasure userEntity.UserErasure
+		// SKIP LOCKED：跳过其他实例正在领取的记录（MySQL 8.0+）
+		err := scope(tx).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			First(&erasure).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&userEntity.UserErasure{}).
+			Where("user_id = ?", erasure.UserID).
+			Updates(map[string]interface{}{
+				"status":     userConstant.ErasureStatusRunning,
+				"started_at": now,
+				"attempts":   gorm.Expr("attempts + 1"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		erasure.Status = userConstant.ErasureStatusRunning
+		erasure.StartedAt = &now
+		erasure.Attempts++
+		claimed = &erasure
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// SaveStep Implements [ErasureRepository.SaveStep]
+func (r *erasureRepositoryImpl) SaveStep(ctx context.Context, userID []byte, step int) error {
+	return r.db.WithContext(ctx).
+		Model(&userEntity.UserErasure{}).
+		Where("user_id = ?", userID).
+		Update("step", step).Error
+}
+
+// Fail Implements [ErasureRepository.Fail]
+func (r *erasureRepositoryImpl) Fail(ctx context.Context, userID []byte, reason string, retryAt time.Time) error {
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+	return r.db.WithContext(ctx).
+		Model(&userEntity.UserErasure{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"status":          userConstant.ErasureStatusPending,
+			"error":           reason,
+			"next_attempt_at": retryAt,
+		}).Error
+}
+
+// Complete Implements [ErasureRepository.Complete]
+func (r *erasureRepositoryImpl) Complete(ctx context.Context, userID []byte, completedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&userEntity.UserErasure{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"status":          userConstant.ErasureStatusCompleted,
+			"subjects":        "",
+			"error":           "",
+			"next_attempt_at": nil,
+			"completed_at":    completedAt,
+		}).Error
+}
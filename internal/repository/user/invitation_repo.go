@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/tenant"
+)
+
+// InvitationRepository 定义用户邀请的数据操作接口
+//
+// 全部操作都限定在上下文中的租户内（见 [tenant.FromContext]），其他租户的邀请视为不存在
+type InvitationRepository interface {
+	// Create 创建邀请，邀请属于上下文中的租户；同一邮箱尚未接受的邀请会被撤销，只有最新的邀请有效
+	Create(ctx context.Context, invitation *userEntity.Invitation) error
+
+	// ListPending 获取未过期且等待接受的邀请，按创建时间倒序
+	ListPending(ctx context.Context, now time.Time) ([]*userEntity.Invitation, error)
+
+	// Revoke 撤销等待接受的邀请，邀请不存在或已接受、已撤销时返回 false
+	Revoke(ctx context.Context, id []byte) (bool, error)
+
+	// Claim 领取未过期且等待接受的邀请并标记为已接受，多个请求并发领取同一邀请时只有一个成功；
+	// 邀请不存在、已过期、已接受或已撤销时返回 nil, nil
+	Claim(ctx context.Context, id []byte, now time.Time) (*userEntity.Invitation, error)
+
+	// Release 将已领取但注册失败的邀请恢复为等待接受
+	Release(ctx context.Context, id []byte) error
+
+	// SetAcceptedUser 记录接受邀请后注册的用户
+	SetAcceptedUser(ctx context.Context, id []byte, userID []byte) error
+}
+
+type invitationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository 创建一个新的 InvitationRepository 实例
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepositoryImpl{db: db}
+}
+
+// scoped 返回限定在上下文租户内的查询
+func (r *invitationRepositoryImpl) scoped(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Scopes(byTenantColumn(ctx, "tenant_id"))
+}
+
+// Create Implements [InvitationRepository.Create]
+func (r *invitationRepositoryImpl) Create(ctx context.Context, invitation *userEntity.Invitation) error {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrMissing
+	}
+	invitation.TenantID = t.ID
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&userEntity.Invitation{}).
+			Where("tenant_id = ? AND email = ? AND status = ?", t.ID, invitation.Email, userConstant.InvitationStatusPending).
+			Update("status", userConstant.InvitationStatusRevoked).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(invitation).Error
+	})
+}
+
+// ListPending Implements [InvitationRepository.ListPending]
+func (r *invitationRepositoryImpl) ListPending(ctx context.Context, now time.Time) ([]*userEntity.Invitation, error) {
+	var invitations []*userEntity.Invitation
+	err := r.scoped(ctx).
+		Where("status = ? AND expires_at > ?", userConstant.InvitationStatusPending, now).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// Revoke Implements [InvitationRepository.Revoke]
+func (r *invitationRepositoryImpl) Revoke(ctx context.Context, id []byte) (bool, error) {
+	result := r.scoped(ctx).
+		Model(&userEntity.Invitation{}).
+		Where("id = ? AND status = ?", id, userConstant.InvitationStatusPending).
+		Update("status", userConstant.InvitationStatusRevoked)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Claim Implements [InvitationRepository.Claim]
+func (r *invitationRepositoryImpl) Claim(ctx context.Context, id []byte, now time.Time) (*userEntity.Invitation, error) {
+	var claimed *userEntity.Invitation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invitation userEntity.Invitation
+		err := tx.Scopes(byTenantColumn(ctx, "tenant_id")).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ? AND expires_at > ?", id, userConstant.InvitationStatusPending, now).
+			First(&invitation).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		err = tx.Model(&userEntity.Invitation{}).
+			Where("id = ?", invitation.ID).
+			Updates(map[string]interface{}{
+				"status":      userConstant.InvitationStatusAccepted,
+				"accepted_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		invitation.Status = userConstant.InvitationStatusAccepted
+		invitation.AcceptedAt = &now
+		claimed = &invitation
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// Release Implements [InvitationRepository.Release]
+func (r *invitationRepositoryImpl) Release(ctx context.Context, id []byte) error {
+	return r.scoped(ctx).
+		Model(&userEntity.Invitation{}).
+		Where("id = ? AND status = ? AND user_id IS NULL", id, userConstant.InvitationStatusAccepted).
+		Updates(map[string]interface{}{
+			"status":      userConstant.InvitationStatusPending,
+			"accepted_at": nil,
+		}).Error
+}
+
+// SetAcceptedUser Implements [InvitationRepository.SetAcceptedUser]
+func (r *invitationRepositoryImpl) SetAcceptedUser(ctx context.Context, id []byte, userID []byte) error {
+	return r.scoped(ctx).
+		Model(&userEntity.Invitation{}).
+		Where("id = ?", id).
+		Update("user_id", userID).Error
+}
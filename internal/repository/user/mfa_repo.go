@@ -0,0 +1,153 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	userEntity "hello-gozero/internal/entity/user"
+)
+
+// MFARepository 定义用户两步验证数据操作的接口
+type MFARepository interface {
+	// Get 获取用户的两步验证配置，不存在时返回 gorm.ErrRecordNotFound
+	Get(ctx context.Context, userID []byte) (*userEntity.UserMFA, error)
+
+	// SavePending 保存待确认的 TOTP 密钥（已加密），覆盖用户之前未确认的密钥
+	SavePending(ctx context.Context, userID []byte, encryptedSecret string) error
+
+	// Enable 启用两步验证，同时记录确认时使用的时间步并替换全部恢复码
+	Enable(ctx context.Context, userID []byte, step int64, recoveryCodeHashes []string, confirmedAt time.Time) error
+
+	// UseStep 记录已使用的动态口令时间步，只有 step 大于已记录的时间步时才会成功
+	// 并发使用同一个口令时只有一个调用方会得到 true，用于防止口令重放
+	UseStep(ctx context.Context, userID []byte, step int64) (bool, error)
+
+	// UseRecoveryCode 将未使用的恢复码标记为已使用，恢复码不存在或已使用时返回 false
+	UseRecoveryCode(ctx context.Context, userID []byte, codeHash string, usedAt time.Time) (bool, error)
+
+	// CountUnusedRecoveryCodes 统计用户剩余未使用的恢复码数量
+	CountUnusedRecoveryCodes(ctx context.Context, userID []byte) (int64, error)
+
+	// Delete 删除用户的两步验证配置及全部恢复码
+	Delete(ctx context.Context, userID []byte) error
+}
+
+type mfaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMFARepository 创建一个新的 MFARepository 实例
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepositoryImpl{db: db}
+}
+
+// Get Implements [MFARepository.Get]
+func (r *mfaRepositoryImpl) Get(ctx context.Context, userID []byte) (*userEntity.UserMFA, error) {
+	var mfa userEntity.UserMFA
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&mfa).Error
+	if err != nil {
+		return nil, err
+	}
+	return &mfa, nil
+}
+
+// SavePending Implements [MFARepository.SavePending]
+func (r *mfaRepositoryImpl) SavePending(ctx context.Context, userID []byte, encryptedSecret string) error {
+	mfa := &userEntity.UserMFA{
+		UserID:     userID,
+		TOTPSecret: encryptedSecret,
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"totp_secret":    encryptedSecret,
+				"enabled":        false,
+				"last_used_step": 0,
+				"confirmed_at":   nil,
+			}),
+		}).
+		Create(mfa).Error
+}
+
+// Enable Implements [MFARepository.Enable]
+func (r *mfaRepositoryImpl) Enable(ctx context.Context, userID []byte, step int64, recoveryCodeHashes []string, confirmedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&userEntity.UserMFA{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"enabled":        true,
+				"last_used_step": step,
+				"confirmed_at":   confirmedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+// replaceRecoveryCodes 删除用户已有的恢复码并写入新的恢复码
+func replaceRecoveryCodes(tx *gorm.DB, userID []byte, hashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&userEntity.MFARecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	codes := make([]*userEntity.MFARecoveryCode, 0, len(hashes))
+	for _, hash := range hashes {
+		codes = append(codes, &userEntity.MFARecoveryCode{UserID: userID, Hash: hash})
+	}
+	return tx.Create(&codes).Error
+}
+
+// UseStep Implements [MFARepository.UseStep]
+func (r *mfaRepositoryImpl) UseStep(ctx context.Context, userID []byte, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&userEntity.UserMFA{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		UpdateColumn("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UseRecoveryCode Implements [MFARepository.UseRecoveryCode]
+func (r *mfaRepositoryImpl) UseRecoveryCode(ctx context.Context, userID []byte, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&userEntity.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		UpdateColumn("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountUnusedRecoveryCodes Implements [MFARepository.CountUnusedRecoveryCodes]
+func (r *mfaRepositoryImpl) CountUnusedRecoveryCodes(ctx context.Context, userID []byte) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&userEntity.MFARecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Delete Implements [MFARepository.Delete]
+func (r *mfaRepositoryImpl) Delete(ctx context.Context, userID []byte) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&userEntity.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&userEntity.UserMFA{}).Error
+	})
+}
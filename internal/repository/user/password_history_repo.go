@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	userEntity "hello-gozero/internal/entity/user"
+)
+
+// PasswordHistoryRepository 定义用户密码历史数据操作的接口
+type PasswordHistoryRepository interface {
+	// Add 记录用户新设置的密码哈希，并只保留最近 keep 条记录
+	Add(ctx context.Context, userID []byte, passwordHash string, keep int) error
+
+	// ListRecentHashes 获取用户最近使用过的 limit 个密码哈希，按时间倒序
+	ListRecentHashes(ctx context.Context, userID []byte, limit int) ([]string, error)
+}
+
+type passwordHistoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPasswordHistoryRepository 创建一个新的 PasswordHistoryRepository 实例
+func NewPasswordHistoryRepository(db *gorm.DB) PasswordHistoryRepository {
+	return &passwordHistoryRepositoryImpl{db: db}
+}
+
+// Add Implements [PasswordHistoryRepository.Add]
+func (r *passwordHistoryRepositoryImpl) Add(ctx context.Context, userID []byte, passwordHash string, keep int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&userEntity.PasswordHistory{
+			UserID:       userID,
+			PasswordHash: passwordHash,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// 找到需要保留的最早一条记录，删除更早的记录
+		var ids []uint64
+		err = tx.Model(&userEntity.PasswordHistory{}).
+			Where("user_id = ?", userID).
+			Order("id DESC").
+			Offset(keep-1).
+			Limit(1).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Where("user_id = ? AND id < ?", userID, ids[0]).
+			Delete(&userEntity.PasswordHistory{}).Error
+	})
+}
+
+// ListRecentHashes Implements [PasswordHistoryRepository.ListRecentHashes]
+func (r *passwordHistoryRepositoryImpl) ListRecentHashes(ctx context.Context, userID []byte, limit int) ([]string, error) {
+	var hashes []string
+	err := r.db.WithContext(ctx).
+		Model(&userEntity.PasswordHistory{}).
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Limit(limit).
+		Pluck("password_hash", &hashes).Error
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
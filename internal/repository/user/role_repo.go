@@ -0,0 +1,181 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	userEntity "hello-gozero/internal/entity/user"
+)
+
+// RoleRepository 定义角色与权限数据操作的接口
+type RoleRepository interface {
+	// ListRoles 获取全部角色，按名称排序
+	ListRoles(ctx context.Context) ([]*userEntity.Role, error)
+
+	// GetRolesByNames 根据名称获取角色，不存在的名称被忽略
+	GetRolesByNames(ctx context.Context, names []string) ([]*userEntity.Role, error)
+
+	// ListPermissions 获取全部权限编码，按编码排序
+	ListPermissions(ctx context.Context) ([]string, error)
+
+	// ListRolePermissions 获取角色的权限编码，返回角色 ID 到权限编码（按编码排序）的映射
+	ListRolePermissions(ctx context.Context, roleIDs []uint64) (map[uint64][]string, error)
+
+	// ListUserRoles 获取用户的角色，按名称排序
+	ListUserRoles(ctx context.Context, userID []byte) ([]*userEntity.Role, error)
+
+	// ListUserPermissions 获取用户通过角色拥有的全部权限编码（去重并排序）
+	ListUserPermissions(ctx context.Context, userID []byte) ([]string, error)
+
+	// HasPermission 判断用户是否通过任一角色拥有指定权限
+	HasPermission(ctx context.Context, userID []byte, code string) (bool, error)
+
+	// SetUserRoles 将用户的角色替换为 roleIDs（为空表示移除全部角色），已有的角色保留原分配人与分配时间
+	SetUserRoles(ctx context.Context, userID []byte, roleIDs []uint64, grantedBy string) error
+}
+
+type roleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository 创建一个新的 RoleRepository 实例
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepositoryImpl{db: db}
+}
+
+// ListRoles Implements [RoleRepository.ListRoles]
+func (r *roleRepositoryImpl) ListRoles(ctx context.Context) ([]*userEntity.Role, error) {
+	var roles []*userEntity.Role
+	if err := r.db.WithContext(ctx).Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// GetRolesByNames Implements [RoleRepository.GetRolesByNames]
+func (r *roleRepositoryImpl) GetRolesByNames(ctx context.Context, names []string) ([]*userEntity.Role, error) {
+	var roles []*userEntity.Role
+	if len(names) == 0 {
+		return roles, nil
+	}
+	if err := r.db.WithContext(ctx).Where("name IN ?", names).Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// ListPermissions Implements [RoleRepository.ListPermissions]
+func (r *roleRepositoryImpl) ListPermissions(ctx context.Context) ([]string, error) {
+	var codes []string
+	if err := r.db.WithContext(ctx).Model(&userEntity.Permission{}).Order("code").Pluck("code", &codes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// ListRolePermissions Implements [RoleRepository.ListRolePermissions]
+func (r *roleRepositoryImpl) ListRolePermissions(ctx context.Context, roleIDs []uint64) (map[uint64][]string, error) {
+	result := make(map[uint64][]string, len(roleIDs))
+	if len(roleIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		RoleID uint64
+		Code   string
+	}
+	err := r.db.WithContext(ctx).
+		Table("t_role_permission AS rp").
+		Select("rp.role_id, p.code").
+		Joins("JOIN t_permission AS p ON p.id = rp.permission_id").
+		Where("rp.role_id IN ?", roleIDs).
+		Order("p.code").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.RoleID] = append(result[row.RoleID], row.Code)
+	}
+	return result, nil
+}
+
+// ListUserRoles Implements [RoleRepository.ListUserRoles]
+func (r *roleRepositoryImpl) ListUserRoles(ctx context.Context, userID []byte) ([]*userEntity.Role, error) {
+	var roles []*userEntity.Role
+	err := r.db.WithContext(ctx).
+		Joins("JOIN t_user_role AS ur ON ur.role_id = t_role.id").
+		Where("ur.user_id = ?", userID).
+		Order("t_role.name").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// ListUserPermissions Implements [RoleRepository.ListUserPermissions]
+func (r *roleRepositoryImpl) ListUserPermissions(ctx context.Context, userID []byte) ([]string, error) {
+	var codes []string
+	err := r.db.WithContext(ctx).
+		Table("t_user_role AS ur").
+		Distinct("p.code").
+		Joins("JOIN t_role_permission AS rp ON rp.role_id = ur.role_id").
+		Joins("JOIN t_permission AS p ON p.id = rp.permission_id").
+		Where("ur.user_id = ?", userID).
+		Order("p.code").
+		Pluck("p.code", &codes).Error
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// HasPermission Implements [RoleRepository.HasPermission]
+func (r *roleRepositoryImpl) HasPermission(ctx context.Context, userID []byte, code string) (bool, error) {
+	var found []int
+	err := r.db.WithContext(ctx).
+		Table("t_user_role AS ur").
+		Select("1").
+		Joins("JOIN t_role_permission AS rp ON rp.role_id = ur.role_id").
+		Joins("JOIN t_permission AS p ON p.id = rp.permission_id").
+		Where("ur.user_id = ? AND p.code = ?", userID, code).
+		Limit(1).
+		Pluck("1", &found).Error
+	if err != nil {
+		return false, err
+	}
+	return len(found) > 0, nil
+}
+
+// SetUserRoles Implements [RoleRepository.SetUserRoles]
+func (r *roleRepositoryImpl) SetUserRoles(ctx context.Context, userID []byte, roleIDs []uint64, grantedBy string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		remove := tx.Where("user_id = ?", userID)
+		if len(roleIDs) > 0 {
+			remove = remove.Where("role_id NOT IN ?", roleIDs)
+		}
+		if err := remove.Delete(&userEntity.UserRole{}).Error; err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		userRoles := make([]*userEntity.UserRole, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			userRoles = append(userRoles, &userEntity.UserRole{
+				UserID:    userID,
+				RoleID:    roleID,
+				GrantedBy: grantedBy,
+				CreatedAt: now,
+			})
+		}
+		// 已有的角色保持不变
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&userRoles).Error
+	})
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/collection"
+	"gorm.io/gorm"
+
+	userEntity "hello-gozero/internal/entity/user"
+)
+
+// tenantCacheLimit 进程内缓存的租户数量上限，避免随机的租户标识撑满内存
+const tenantCacheLimit = 10000
+
+// TenantRepository 定义租户数据操作的接口
+type TenantRepository interface {
+	// GetByCode 根据租户标识获取租户，不存在时返回 [gorm.ErrRecordNotFound]
+	// 每个请求都需要解析租户，结果（包括不存在）在进程内缓存，租户变更后最长经过缓存有效期生效
+	GetByCode(ctx context.Context, code string) (*userEntity.Tenant, error)
+}
+
+type tenantRepositoryImpl struct {
+	db    *gorm.DB
+	cache *collection.Cache
+}
+
+// NewTenantRepository 创建一个新的 TenantRepository 实例，cacheExpire 为进程内缓存的有效期
+func NewTenantRepository(db *gorm.DB, cacheExpire time.Duration) (TenantRepository, error) {
+	cache, err := collection.NewCache(cacheExpire, collection.WithLimit(tenantCacheLimit), collection.WithName("tenant"))
+	if err != nil {
+		return nil, err
+	}
+	return &tenantRepositoryImpl{db: db, cache: cache}, nil
+}
+
+// GetByCode Implements [TenantRepository.GetByCode]
+func (r *tenantRepositoryImpl) GetByCode(ctx context.Context, code string) (*userEntity.Tenant, error) {
+	val, err := r.cache.Take(code, func() (any, error) {
+		var tenant userEntity.Tenant
+		err := r.db.WithContext(ctx).Where("code = ?", code).First(&tenant).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// 缓存不存在的结果，防止不存在的租户标识每次都查询数据库
+				return (*userEntity.Tenant)(nil), nil
+			}
+			return nil, err
+		}
+		return &tenant, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	tenant, _ := val.(*userEntity.Tenant)
+	if tenant == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return tenant, nil
+}
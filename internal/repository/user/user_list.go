@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	userEntity "hello-gozero/internal/entity/user"
+)
+
+// 用户列表的排序字段，同时也是数据库列名
+const (
+	ListSortCreatedAt = "created_at"
+	ListSortUpdatedAt = "updated_at"
+	ListSortUsername  = "username"
+)
+
+// ListQuery 用户列表查询条件
+//
+// 支持两种分页方式：
+//   - 偏移分页：设置 Offset，适合管理后台按页码跳转，页码越大越慢
+//   - 游标分页（keyset）：设置 After，按排序字段与 ID 定位上一页的最后一条记录，翻页耗时与页码无关，适合大表深度翻页
+type ListQuery struct {
+	UsernamePrefix string     // 用户名前缀
+	Status         *int8      // 用户状态，为空表示不限
+	CreatedFrom    *time.Time // 创建时间下限（包含）
+	CreatedTo      *time.Time // 创建时间上限（不包含）
+	EmailDomain    string     // 邮箱域名，如 "example.com"
+
+	SortBy string // 排序字段，见 ListSort* 常量；排序字段相同时按 ID 排序，保证顺序稳定
+	Desc   bool   // 是否降序
+
+	Offset     int         // 偏移量，与 After 二选一
+	After      *ListCursor // 游标位置，只返回排在该位置之后的记录
+	Limit      int         // 返回数量
+	CountTotal bool        // 是否统计符合条件的总数（COUNT(*)），游标分页通常不需要
+}
+
+// ListCursor 游标分页的位置：上一页最后一条记录的排序字段值与 ID
+type ListCursor struct {
+	Value any // 排序字段的值，created_at / updated_at 为 time.Time，username 为 string
+	ID    []byte
+}
+
+// NewListCursor 返回用户在指定排序字段下的游标位置
+func NewListCursor(user *userEntity.User, sortBy string) (ListCursor, error) {
+	cursor := ListCursor{ID: user.ID}
+	switch sortBy {
+	case ListSortCreatedAt:
+		cursor.Value = user.CreatedAt
+	case ListSortUpdatedAt:
+		cursor.Value = user.UpdatedAt
+	case ListSortUsername:
+		cursor.Value = user.Username
+	default:
+		return ListCursor{}, fmt.Errorf("unsupported sort field %q", sortBy)
+	}
+	return cursor, nil
+}
+
+// likeEscaper 转义 LIKE 模式中的通配符，MySQL 默认使用反斜杠作为转义字符
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// List Implements [UserRepository.List]
+func (r *userRepositoryImpl) List(ctx context.Context, query ListQuery) ([]*userEntity.User, int64, error) {
+	if _, err := NewListCursor(&userEntity.User{}, query.SortBy); err != nil {
+		return nil, 0, err
+	}
+
+	db := r.scoped(ctx).Model(&userEntity.User{})
+	if query.UsernamePrefix != "" {
+		db = db.Where("username LIKE ?", likeEscaper.Replace(query.UsernamePrefix)+"%")
+	}
+	if query.Status != nil {
+		db = db.Where("status = ?", *query.Status)
+	}
+	if query.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		db = db.Where("created_at < ?", *query.CreatedTo)
+	}
+	if query.EmailDomain != "" {
+		db = db.Where("email LIKE ?", "%@"+likeEscaper.Replace(query.EmailDomain))
+	}
+
+	var total int64
+	if query.CountTotal {
+		// Session 复制查询条件，避免 Count 修改后续查询
+		if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// 排序字段来自上面的白名单校验，可以安全地拼接到 SQL 中
+	op, dir := ">", "ASC"
+	if query.Desc {
+		op, dir = "<", "DESC"
+	}
+	if query.After != nil {
+		db = db.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", query.SortBy, op),
+			query.After.Value, query.After.Value, query.After.ID,
+		)
+	} else if query.Offset > 0 {
+		db = db.Offset(query.Offset)
+	}
+
+	users := make([]*userEntity.User, 0, query.Limit)
+	err := db.Order(fmt.Sprintf("%s %s, id %s", query.SortBy, dir, dir)).
+		Limit(query.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
@@ -3,14 +3,21 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	userConstant "hello-gozero/internal/constant/user"
 	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/tenant"
 )
 
 // UserRepository 定义用户数据操作的接口
+//
+// 全部操作都限定在上下文中的租户内（见 [tenant.FromContext]），上下文中没有租户时返回 [tenant.ErrMissing]；
+// 按用户 ID 处理数据的后台任务可以通过 [tenant.WithAllTenants] 跨租户访问
 type UserRepository interface {
 	// Transaction 执行事务操作
 	// 接受一个函数，该函数接收事务版本的 Repository 并执行业务逻辑
@@ -37,9 +44,12 @@ type UserRepository interface {
 	//   })
 	Transaction(ctx context.Context, fn func(repo UserRepository) error) error
 
-	// Create 创建新用户
+	// Create 创建新用户，用户属于上下文中的租户
 	Create(ctx context.Context, user *userEntity.User) error
 
+	// GetByID 根据用户 ID 获取用户
+	GetByID(ctx context.Context, id uuid.UUID) (*userEntity.User, error)
+
 	// GetByUsername 根据用户名获取用户
 	GetByUsername(ctx context.Context, username string) (*userEntity.User, error)
 
@@ -49,6 +59,9 @@ type UserRepository interface {
 	// GetByEmail 根据邮箱获取用户
 	GetByEmail(ctx context.Context, email string) (*userEntity.User, error)
 
+	// UpdateVerifiedEmail 更新用户邮箱并标记为已验证
+	UpdateVerifiedEmail(ctx context.Context, id []byte, email string, verifiedAt time.Time) error
+
 	// GetByPhone 根据手机号获取用户
 	GetByPhone(ctx context.Context, phoneCountryCode, phoneNumber string) (*userEntity.User, error)
 
@@ -56,17 +69,67 @@ type UserRepository interface {
 	// 通常是检查，给定区号和手机号的组合是否已被注册，避免相同手机号注册多个账户
 	ExistsByPhone(ctx context.Context, phoneCountryCode, phoneNumber string) (bool, error)
 
-	// Update 更新已有用户
+	// Update 更新已有用户，用户必须属于上下文中的租户；不更新用户名，用户名只能通过 Rename 修改
 	Update(ctx context.Context, user *userEntity.User) error
 
+	// UpdateLastLoginTime 更新用户最后登录时间
+	// 只更新 last_login_time 一列，不触发 updated_at 的变更
+	UpdateLastLoginTime(ctx context.Context, id []byte, loginTime time.Time) error
+
+	// UpdatePasswordHash 重新生成哈希后替换已存储的密码哈希（密码本身不变）
+	// 仅当已存储的哈希仍为 oldHash 时才替换，避免覆盖并发修改的新密码；只更新 password 一列，不触发 updated_at 的变更
+	// 返回是否替换成功
+	UpdatePasswordHash(ctx context.Context, id []byte, oldHash, newHash string) (bool, error)
+
+	// UpdateProfile 更新用户的昵称与手机号，只更新这几列（以及 updated_at），不影响并发修改的密码等其他字段
+	UpdateProfile(ctx context.Context, id []byte, nickname, phoneCountryCode, phoneNumber string) error
+
+	// ChangeStatus 按变更记录迁移用户状态并保存变更记录（同一事务）
+	// 仅当用户当前状态仍为 change.FromStatus 时才迁移，避免覆盖并发的状态变更；返回是否迁移成功
+	ChangeStatus(ctx context.Context, change *userEntity.StatusHistory) (bool, error)
+
+	// ListStatusHistory 获取用户的全部状态变更记录，按时间正序
+	ListStatusHistory(ctx context.Context, userID []byte) ([]*userEntity.StatusHistory, error)
+
+	// Rename 按变更记录修改用户名并保存变更记录（同一事务），变更记录属于上下文中的租户
+	// 仅当用户名仍为 change.OldUsername 时才修改，避免覆盖并发的变更；返回是否修改成功
+	// 新用户名已被其他用户占用时由唯一索引拒绝（MySQL 1062）
+	Rename(ctx context.Context, change *userEntity.UsernameHistory) (bool, error)
+
+	// GetUsernameReservation 获取用户名在 now 时仍未到期的保留记录（最近一次变更），没有时返回 gorm.ErrRecordNotFound
+	GetUsernameReservation(ctx context.Context, username string, now time.Time) (*userEntity.UsernameHistory, error)
+
+	// ListUsernameHistory 获取用户的全部用户名变更记录，按时间正序
+	ListUsernameHistory(ctx context.Context, userID []byte) ([]*userEntity.UsernameHistory, error)
+
 	// Delete 通过 ID 软删除用户
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// DeleteByUsername 通过用户名删除用户
 	DeleteByUsername(ctx context.Context, username string) error
 
-	// List 分页获取用户列表，返回用户切片和总数
-	List(ctx context.Context, offset, limit int) ([]*userEntity.User, int64, error)
+	// GetDeletedByUsername 获取指定用户名最近一次被软删除的用户
+	GetDeletedByUsername(ctx context.Context, username string) (*userEntity.User, error)
+
+	// Restore 恢复被软删除的用户，返回是否恢复成功（用户不存在或未被删除时为 false）
+	// 用户名、邮箱或手机号已被其他活跃用户占用时由唯一索引拒绝（MySQL 1062）
+	Restore(ctx context.Context, id []byte) (bool, error)
+
+	// Anonymize 匿名化用户（包括已软删除的用户）：用户名替换为 placeholder，清空邮箱、手机号、昵称与密码，
+	// 禁用并软删除（已删除的保留原删除时间）；可以重复执行
+	Anonymize(ctx context.Context, id []byte, placeholder string) error
+
+	// EraseRelated 删除用户的密码历史、两步验证数据、用户名变更历史、用户接受的邀请与发送到 email 的邀请，清空状态变更记录中的原因，
+	// 并将用户以当前用户名 username 或变更前的用户名作为操作人的记录（状态变更、用户名变更、角色分配、邀请，包括该用户对其他用户的操作）替换为 placeholder；
+	// 可以重复执行
+	EraseRelated(ctx context.Context, id []byte, username, email, placeholder string) error
+
+	// PurgeDeleted 彻底删除软删除时间早于 deletedBefore 的用户（最多 limit 个）及其密码历史、两步验证、状态变更历史、用户名变更历史、角色、接受的邀请等关联数据
+	// 返回彻底删除的用户数量
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+
+	// List 按条件分页获取用户列表，返回用户切片和总数（仅在 query.CountTotal 为 true 时统计，否则为 0）
+	List(ctx context.Context, query ListQuery) ([]*userEntity.User, int64, error)
 }
 
 type userRepositoryImpl struct {
@@ -88,13 +151,26 @@ func (r *userRepositoryImpl) Transaction(ctx context.Context, fn func(repo UserR
 
 // Create Implements [UserRepository.Create]
 func (r *userRepositoryImpl) Create(ctx context.Context, user *userEntity.User) error {
+	if err := assignTenant(ctx, user); err != nil {
+		return err
+	}
 	return r.db.WithContext(ctx).Create(user).Error
 }
 
+// GetByID Implements [UserRepository.GetByID]
+func (r *userRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*userEntity.User, error) {
+	var user userEntity.User
+	err := r.scoped(ctx).Where("id = ?", id[:]).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // GetByUsername Implements [UserRepository.GetByUsername]
 func (r *userRepositoryImpl) GetByUsername(ctx context.Context, username string) (*userEntity.User, error) {
 	var user userEntity.User
-	err := r.db.WithContext(ctx).Where(&userEntity.User{Username: username}).First(&user).Error
+	err := r.scoped(ctx).Where(&userEntity.User{Username: username}).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +182,7 @@ func (r *userRepositoryImpl) ExistsByUsername(ctx context.Context, username stri
 	var count int64
 	// var exists bool
 	// 💡 额外提示：考虑使用 Select("1").Limit(1) 优化 EXISTS
-	err := r.db.WithContext(ctx).
+	err := r.scoped(ctx).
 		Model(&userEntity.User{}).
 		Where(&userEntity.User{Username: username}).
 		Count(&count).Error
@@ -119,7 +195,7 @@ func (r *userRepositoryImpl) ExistsByUsername(ctx context.Context, username stri
 // GetByEmail Implements [UserRepository.GetByEmail]
 func (r *userRepositoryImpl) GetByEmail(ctx context.Context, email string) (*userEntity.User, error) {
 	var user userEntity.User
-	err := r.db.WithContext(ctx).Where(&userEntity.User{Email: email}).First(&user).Error
+	err := r.scoped(ctx).Where(&userEntity.User{Email: email}).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +205,7 @@ func (r *userRepositoryImpl) GetByEmail(ctx context.Context, email string) (*use
 // GetByPhone Implements [UserRepository.GetByPhone]
 func (r *userRepositoryImpl) GetByPhone(ctx context.Context, phoneCountryCode, phoneNumber string) (*userEntity.User, error) {
 	var user userEntity.User
-	err := r.db.WithContext(ctx).
+	err := r.scoped(ctx).
 		Where(&userEntity.User{
 			PhoneCountryCode: phoneCountryCode,
 			PhoneNumber:      phoneNumber,
@@ -145,7 +221,7 @@ func (r *userRepositoryImpl) GetByPhone(ctx context.Context, phoneCountryCode, p
 func (r *userRepositoryImpl) ExistsByPhone(ctx context.Context, phoneCountryCode, phoneNumber string) (bool, error) {
 	var exists bool
 	// 💡 额外提示：考虑使用 Select("1").Limit(1) 优化 EXISTS
-	err := r.db.WithContext(ctx).
+	err := r.scoped(ctx).
 		Model(&userEntity.User{}). // ✅ 必要，不能省略。它是 Count 正确执行的前提，且与结构体绑定，支持表名自定义、软删除等 GORM 特性
 		Select("1").
 		Where(&userEntity.User{PhoneCountryCode: phoneCountryCode, PhoneNumber: phoneNumber}).
@@ -159,36 +235,424 @@ func (r *userRepositoryImpl) ExistsByPhone(ctx context.Context, phoneCountryCode
 
 // Update Implements [UserRepository.Update]
 func (r *userRepositoryImpl) Update(ctx context.Context, user *userEntity.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	if err := assignTenant(ctx, user); err != nil {
+		return err
+	}
+	// 显式 Select 全部字段：Save 在没有更新到记录时会改为 INSERT ... ON DUPLICATE KEY UPDATE，可能覆盖其他租户的同 ID 记录
+	// 不更新用户名：调用方通常先读取用户再保存，读取之后用户名被并发变更时不会被写回原用户名
+	return r.scoped(ctx).Select("*").Omit("username").Save(user).Error
+}
+
+// UpdateLastLoginTime Implements [UserRepository.UpdateLastLoginTime]
+func (r *userRepositoryImpl) UpdateLastLoginTime(ctx context.Context, id []byte, loginTime time.Time) error {
+	return r.scoped(ctx).
+		Model(&userEntity.User{}).
+		Where("id = ?", id).
+		UpdateColumn("last_login_time", loginTime).
+		Error
+}
+
+// UpdateVerifiedEmail Implements [UserRepository.UpdateVerifiedEmail]
+func (r *userRepositoryImpl) UpdateVerifiedEmail(ctx context.Context, id []byte, email string, verifiedAt time.Time) error {
+	return r.scoped(ctx).
+		Model(&userEntity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"email":             email,
+			"email_verified_at": verifiedAt,
+		}).
+		Error
+}
+
+// UpdatePasswordHash Implements [UserRepository.UpdatePasswordHash]
+func (r *userRepositoryImpl) UpdatePasswordHash(ctx context.Context, id []byte, oldHash, newHash string) (bool, error) {
+	result := r.scoped(ctx).
+		Model(&userEntity.User{}).
+		Where("id = ? AND password = ?", id, oldHash).
+		UpdateColumn("password", newHash)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateProfile Implements [UserRepository.UpdateProfile]
+func (r *userRepositoryImpl) UpdateProfile(ctx context.Context, id []byte, nickname, phoneCountryCode, phoneNumber string) error {
+	// 使用 map 才能将字段更新为空字符串
+	return r.scoped(ctx).
+		Model(&userEntity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"nickname":           nickname,
+			"phone_country_code": phoneCountryCode,
+			"phone_number":       phoneNumber,
+		}).
+		Error
+}
+
+// ChangeStatus Implements [UserRepository.ChangeStatus]
+func (r *userRepositoryImpl) ChangeStatus(ctx context.Context, change *userEntity.StatusHistory) (bool, error) {
+	changed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(byTenant(ctx)).
+			Model(&userEntity.User{}).
+			Where("id = ? AND status = ?", change.UserID, change.FromStatus).
+			Updates(map[string]interface{}{
+				"status":          change.ToStatus,
+				"suspended_until": change.SuspendedUntil,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		changed = true
+		return tx.Create(change).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return changed, nil
+}
+
+// ListStatusHistory Implements [UserRepository.ListStatusHistory]
+func (r *userRepositoryImpl) ListStatusHistory(ctx context.Context, userID []byte) ([]*userEntity.StatusHistory, error) {
+	var history []*userEntity.StatusHistory
+	err := r.db.WithContext(ctx).
+		Scopes(byTenantUser(ctx)).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Rename Implements [UserRepository.Rename]
+func (r *userRepositoryImpl) Rename(ctx context.Context, change *userEntity.UsernameHistory) (bool, error) {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return false, tenant.ErrMissing
+	}
+	change.TenantID = t.ID
+
+	renamed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(byTenant(ctx)).
+			Model(&userEntity.User{}).
+			Where("id = ? AND username = ?", change.UserID, change.OldUsername).
+			Update("username", change.NewUsername)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		renamed = true
+		return tx.Create(change).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return renamed, nil
+}
+
+// GetUsernameReservation Implements [UserRepository.GetUsernameReservation]
+func (r *userRepositoryImpl) GetUsernameReservation(ctx context.Context, username string, now time.Time) (*userEntity.UsernameHistory, error) {
+	var change userEntity.UsernameHistory
+	err := r.db.WithContext(ctx).
+		Scopes(byTenantColumn(ctx, "tenant_id")).
+		Where("old_username = ? AND reserved_until > ?", username, now).
+		Order("id DESC").
+		First(&change).Error
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ListUsernameHistory Implements [UserRepository.ListUsernameHistory]
+func (r *userRepositoryImpl) ListUsernameHistory(ctx context.Context, userID []byte) ([]*userEntity.UsernameHistory, error) {
+	var history []*userEntity.UsernameHistory
+	err := r.db.WithContext(ctx).
+		Scopes(byTenantUser(ctx)).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
 }
 
 // Delete Implements [UserRepository.Delete]
 func (r *userRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id[:]).Delete(&userEntity.User{}).Error
+	return r.scoped(ctx).Where("id = ?", id[:]).Delete(&userEntity.User{}).Error
 }
 
 // DeleteByUsername implements [UserRepository.DeleteByUsername].
 func (r *userRepositoryImpl) DeleteByUsername(ctx context.Context, username string) error {
-	return r.db.WithContext(ctx).
+	return r.scoped(ctx).
 		Where(&userEntity.User{Username: username}).
 		Delete(&userEntity.User{}).
 		Error
 }
 
-// List Implements [UserRepository.List]
-func (r *userRepositoryImpl) List(ctx context.Context, offset, limit int) ([]*userEntity.User, int64, error) {
-	users := make([]*userEntity.User, 0)
-	var total int64
+// GetDeletedByUsername Implements [UserRepository.GetDeletedByUsername]
+func (r *userRepositoryImpl) GetDeletedByUsername(ctx context.Context, username string) (*userEntity.User, error) {
+	var user userEntity.User
+	err := r.scoped(ctx).
+		Unscoped().
+		Where("username = ? AND deleted_at IS NOT NULL", username).
+		Order("deleted_at DESC").
+		First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Restore Implements [UserRepository.Restore]
+func (r *userRepositoryImpl) Restore(ctx context.Context, id []byte) (bool, error) {
+	result := r.scoped(ctx).
+		Unscoped().
+		Model(&userEntity.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// PurgeDeleted Implements [UserRepository.PurgeDeleted]
+func (r *userRepositoryImpl) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids [][]byte
+		err := tx.Unscoped().
+			Scopes(byTenant(ctx)).
+			Model(&userEntity.User{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+			Order("deleted_at").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		// 先删除关联数据，再删除用户
+		for _, model := range []interface{}{
+			&userEntity.PasswordHistory{},
+			&userEntity.StatusHistory{},
+			&userEntity.UsernameHistory{},
+			&userEntity.MFARecoveryCode{},
+			&userEntity.UserMFA{},
+			&userEntity.DataExport{},
+			&userEntity.UserRole{},
+			&userEntity.Invitation{},
+		} {
+			if err := tx.Where("user_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Unscoped().
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Delete(&userEntity.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+// Anonymize Implements [UserRepository.Anonymize]
+func (r *userRepositoryImpl) Anonymize(ctx context.Context, id []byte, placeholder string) error {
+	return r.scoped(ctx).
+		Unscoped().
+		Model(&userEntity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"username":           placeholder,
+			"password":           "",
+			"email":              "",
+			"email_verified_at":  nil,
+			"phone_country_code": "",
+			"phone_number":       "",
+			"nickname":           "",
+			"status":             userConstant.StatusDisabled,
+			"suspended_until":    nil,
+			"last_login_time":    nil,
+			"deleted_at":         gorm.Expr("COALESCE(deleted_at, ?)", time.Now()),
+		}).Error
+}
+
+// EraseRelated Implements [UserRepository.EraseRelated]
+func (r *userRepositoryImpl) EraseRelated(ctx context.Context, id []byte, username, email, placeholder string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先按用户名变更历史整理用户使用过的用户名，再删除变更历史
+		held, err := heldUsernames(ctx, tx, id, username)
+		if err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&userEntity.PasswordHistory{},
+			&userEntity.MFARecoveryCode{},
+			&userEntity.UserMFA{},
+			&userEntity.UsernameHistory{},
+			&userEntity.Invitation{},
+		} {
+			if err := tx.Scopes(byTenantUser(ctx)).Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		// 发送到该用户邮箱但没有被接受的邀请
+		if email != "" {
+			err := tx.Scopes(byTenantColumn(ctx, "tenant_id")).
+				Where("email = ?", email).
+				Delete(&userEntity.Invitation{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Scopes(byTenantUser(ctx)).
+			Model(&userEntity.StatusHistory{}).
+			Where("user_id = ? AND reason <> ''", id).
+			Update("reason", "").Error
+		if err != nil {
+			return err
+		}
+
+		// 用户名只在租户内唯一，原用户名保留期满后可以被其他用户使用，
+		// 因此只替换本租户内、在该用户使用该用户名期间以它作为操作人的记录
+		targets := []struct {
+			model  interface{}
+			column string
+			scope  func(*gorm.DB) *gorm.DB
+		}{
+			{&userEntity.StatusHistory{}, "actor", byTenantUser(ctx)},
+			{&userEntity.UsernameHistory{}, "actor", byTenantUser(ctx)},
+			{&userEntity.UserRole{}, "granted_by", byTenantUser(ctx)},
+			{&userEntity.Invitation{}, "invited_by", byTenantColumn(ctx, "tenant_id")},
+		}
+		for _, h := range held {
+			for _, target := range targets {
+				query := tx.Scopes(target.scope).
+					Model(target.model).
+					Where(target.column+" = ? AND created_at >= ?", h.name, h.from)
+				if h.until != nil {
+					query = query.Where("created_at <= ?", *h.until)
+				}
+				if err := query.Update(target.column, placeholder).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// heldUsername 用户使用过的用户名及使用期间，until 为空表示至今
+type heldUsername struct {
+	name  string
+	from  time.Time
+	until *time.Time
+}
+
+// heldUsernames 按用户名变更历史整理用户使用过的用户名，current 为当前（匿名化之前的）用户名，为空表示不包含当前用户名
+func heldUsernames(ctx context.Context, tx *gorm.DB, id []byte, current string) ([]heldUsername, error) {
+	var createdAt []time.Time
+	err := tx.Unscoped().
+		Scopes(byTenant(ctx)).
+		Model(&userEntity.User{}).
+		Where("id = ?", id).
+		Pluck("created_at", &createdAt).Error
+	if err != nil {
+		return nil, err
+	}
+	var history []*userEntity.UsernameHistory
+	err = tx.Scopes(byTenantUser(ctx)).
+		Where("user_id = ?", id).
+		Order("id").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 用户记录已被彻底删除时不限制开始时间
+	var from time.Time
+	if len(createdAt) > 0 {
+		from = createdAt[0]
+	}
+	held := make([]heldUsername, 0, len(history)+1)
+	for _, h := range history {
+		until := h.CreatedAt
+		held = append(held, heldUsername{name: h.OldUsername, from: from, until: &until})
+		from = h.CreatedAt
+	}
+	if current != "" {
+		held = append(held, heldUsername{name: current, from: from})
+	}
+	return held, nil
+}
+
+// scoped 返回限定在上下文租户内的 t_user 查询
+func (r *userRepositoryImpl) scoped(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Scopes(byTenant(ctx))
+}
+
+// byTenant 将 t_user 的查询限定在上下文中的租户内，上下文中没有租户时查询失败
+func byTenant(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return byTenantColumn(ctx, "t_user.tenant_id")
+}
 
-	// Get total count
-	if err := r.db.WithContext(ctx).Model(&userEntity.User{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+// byTenantColumn 将查询限定在上下文中的租户内，column 为表中的租户 ID 列，上下文中没有租户时查询失败
+func byTenantColumn(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tenant.IsAllTenants(ctx) {
+			return db
+		}
+		t, ok := tenant.FromContext(ctx)
+		if !ok {
+			_ = db.AddError(tenant.ErrMissing)
+			return db
+		}
+		return db.Where(column+" = ?", t.ID)
 	}
+}
 
-	// Get paginated results
-	if err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Find(&users).Error; err != nil {
-		return nil, 0, err
+// byTenantUser 将关联表（按 user_id 关联用户）的查询限定在上下文租户的用户内
+func byTenantUser(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tenant.IsAllTenants(ctx) {
+			return db
+		}
+		t, ok := tenant.FromContext(ctx)
+		if !ok {
+			_ = db.AddError(tenant.ErrMissing)
+			return db
+		}
+		return db.Where("user_id IN (SELECT id FROM t_user WHERE tenant_id = ?)", t.ID)
 	}
+}
 
-	return users, total, nil
+// assignTenant 将用户归属到上下文中的租户，用户已属于其他租户时返回错误
+func assignTenant(ctx context.Context, user *userEntity.User) error {
+	if tenant.IsAllTenants(ctx) && user.TenantID != 0 {
+		return nil
+	}
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrMissing
+	}
+	if user.TenantID == 0 {
+		user.TenantID = t.ID
+	}
+	if user.TenantID != t.ID {
+		return fmt.Errorf("user belongs to tenant %d, not %d", user.TenantID, t.ID)
+	}
+	return nil
 }
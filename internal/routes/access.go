@@ -0,0 +1,68 @@
+// Package routes 路由访问控制
+package routes
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest"
+
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/authz"
+	"hello-gozero/internal/service/tenancy"
+	"hello-gozero/internal/svc"
+)
+
+// accessRoute 带访问控制声明的路由
+// 路由在注册时根据声明自动挂载认证、归属校验等中间件，避免在每个 handler 中重复校验；所有路由都先解析请求所属的租户
+type accessRoute struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+
+	// 是否要求调用方已登录（携带有效的访问令牌）
+	RequireAuth bool
+
+	// 是否要求调用方就是路径参数 `:username` 所指向的用户本人（按用户 ID 判断，变更用户名后原有的访问令牌仍然有效）
+	// 为 true 时隐含 RequireAuth
+	RequireOwner bool
+
+	// 要求调用方拥有的权限（见 constant/rbac），为空表示不校验权限
+	// 与 RequireOwner 同时设置时，本人或拥有该权限的调用方均可访问；不为空时隐含 RequireAuth
+	Permission string
+}
+
+// toRestRoutes 将带访问控制声明的路由转换为 go-zero 路由，并按声明挂载中间件
+//
+// 中间件执行顺序：租户解析 → 认证 → 归属校验 / 权限校验 → handler
+func toRestRoutes(serverCtx *svc.ServiceContext, routes []accessRoute) []rest.Route {
+	tenantConf := serverCtx.Config.Tenant
+	tenantMiddleware := middleware.NewTenantMiddleware(tenancy.NewResolver(serverCtx), tenantConf.Header, tenantConf.BaseDomain, tenantConf.Default)
+	authMiddleware := middleware.NewAuthMiddleware(serverCtx.Security.Token, serverCtx.Repository.Session)
+	authorizer := authz.NewAuthorizer(serverCtx)
+	ownerMiddleware := middleware.NewOwnerMiddleware(authorizer, "username")
+
+	restRoutes := make([]rest.Route, 0, len(routes))
+	for _, route := range routes {
+		handler := route.Handler
+		// 中间件按逆序包装，保证先挂载的先执行
+		switch {
+		case route.Permission != "" && route.RequireOwner:
+			handler = middleware.NewPermissionMiddleware(authorizer, authorizer, route.Permission, "username").Handle(handler)
+		case route.Permission != "":
+			handler = middleware.NewPermissionMiddleware(authorizer, nil, route.Permission, "").Handle(handler)
+		case route.RequireOwner:
+			handler = ownerMiddleware.Handle(handler)
+		}
+		if route.RequireAuth || route.RequireOwner || route.Permission != "" {
+			handler = authMiddleware.Handle(handler)
+		}
+		handler = tenantMiddleware.Handle(handler)
+
+		restRoutes = append(restRoutes, rest.Route{
+			Method:  route.Method,
+			Path:    route.Path,
+			Handler: handler,
+		})
+	}
+	return restRoutes
+}
@@ -0,0 +1,128 @@
+// Package routes 认证相关路由注册
+package routes
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest"
+
+	auth "hello-gozero/internal/handler/auth"
+	"hello-gozero/internal/svc"
+)
+
+type authRouter struct {
+	server    *rest.Server
+	serverCtx *svc.ServiceContext
+}
+
+func NewAuthRouter(server *rest.Server, serverCtx *svc.ServiceContext) *authRouter {
+	return &authRouter{
+		server:    server,
+		serverCtx: serverCtx,
+	}
+}
+
+func (r *authRouter) Register() {
+	r.addToken()   // 令牌签发与刷新
+	r.addSession() // 会话管理
+	r.addMFA()     // 两步验证
+}
+
+// addToken 令牌签发与刷新
+//   - POST /api/v1/auth/login - 用户登录
+//   - POST /api/v1/auth/login/mfa - 两步验证登录
+//   - POST /api/v1/auth/refresh - 刷新令牌
+func (r *authRouter) addToken() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 用户登录
+				Method:  http.MethodPost,
+				Path:    "/auth/login",
+				Handler: auth.LoginHandler(r.serverCtx),
+			},
+			{
+				// 两步验证登录（使用登录挑战与动态口令）
+				Method:  http.MethodPost,
+				Path:    "/auth/login/mfa",
+				Handler: auth.LoginMFAHandler(r.serverCtx),
+			},
+			{
+				// 刷新令牌
+				Method:  http.MethodPost,
+				Path:    "/auth/refresh",
+				Handler: auth.RefreshTokenHandler(r.serverCtx),
+			},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
+
+// addSession 会话管理
+//   - POST /api/v1/auth/logout - 登出当前会话
+//   - POST /api/v1/auth/logout-all - 登出所有会话
+//   - GET /api/v1/users/:username/sessions - 获取用户会话列表
+func (r *authRouter) addSession() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 登出当前会话
+				Method:      http.MethodPost,
+				Path:        "/auth/logout",
+				Handler:     auth.LogoutHandler(r.serverCtx),
+				RequireAuth: true,
+			},
+			{
+				// 登出所有会话
+				Method:      http.MethodPost,
+				Path:        "/auth/logout-all",
+				Handler:     auth.LogoutAllHandler(r.serverCtx),
+				RequireAuth: true,
+			},
+			{
+				// 获取用户会话列表（仅本人）
+				Method:       http.MethodGet,
+				Path:         "/users/:username/sessions",
+				Handler:      auth.ListSessionsHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
+
+// addMFA 两步验证
+//   - POST /api/v1/users/:username/mfa/totp - 开始绑定身份验证器
+//   - POST /api/v1/users/:username/mfa/totp/confirm - 确认绑定，启用两步验证并返回恢复码
+//   - DELETE /api/v1/users/:username/mfa/totp - 关闭两步验证
+func (r *authRouter) addMFA() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 开始绑定身份验证器（仅本人）
+				Method:       http.MethodPost,
+				Path:         "/users/:username/mfa/totp",
+				Handler:      auth.EnrollTOTPHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+			{
+				// 确认绑定身份验证器（仅本人）
+				Method:       http.MethodPost,
+				Path:         "/users/:username/mfa/totp/confirm",
+				Handler:      auth.ConfirmTOTPHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+			{
+				// 关闭两步验证（仅本人）
+				Method:       http.MethodDelete,
+				Path:         "/users/:username/mfa/totp",
+				Handler:      auth.DisableTOTPHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
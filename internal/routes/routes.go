@@ -13,6 +13,10 @@ import (
 func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 	registerGlobalHandlers(server, serverCtx)
 
+	// 注册认证相关路由
+	authRouter := NewAuthRouter(server, serverCtx)
+	authRouter.Register()
+
 	// 注册用户相关路由
 	userRouter := NewUserRouter(server, serverCtx)
 	userRouter.Register()
@@ -6,6 +6,7 @@ import (
 
 	"github.com/zeromicro/go-zero/rest"
 
+	"hello-gozero/internal/constant/rbac"
 	user "hello-gozero/internal/handler/user"
 	"hello-gozero/internal/svc"
 )
@@ -24,10 +25,15 @@ func NewUserRouter(server *rest.Server, serverCtx *svc.ServiceContext) *userRout
 
 func (r *userRouter) Register() {
 	r.addRegisterUser()                   // 用户注册
+	r.addInvitation()                     // 用户邀请
 	r.addAccountStatusManagement()        // 账户状态管理
 	r.addUserInformationManagement()      // 用户信息管理
 	r.addBatchUserInformationManagement() // 用户批量管理
 	r.addPasswordManagement()             // 密码管理
+	r.addAccountVerification()            // 账户验证
+	r.addDataExport()                     // 个人数据导出
+	r.addErasure()                        // 个人数据擦除
+	r.addRoleManagement()                 // 角色与权限
 }
 
 // addRegisterUser 用户注册
@@ -35,87 +41,204 @@ func (r *userRouter) Register() {
 func (r *userRouter) addRegisterUser() {
 	// v1 接口组
 	r.server.AddRoutes(
-		[]rest.Route{
+		toRestRoutes(r.serverCtx, []accessRoute{
 			{
 				// 注册用户
 				Method:  http.MethodPost,
 				Path:    "/users/register",
 				Handler: user.RegisterUserHandler(r.serverCtx),
 			},
-		},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
+
+// addInvitation 用户邀请
+//   - POST /api/v1/invitations - 邀请用户注册（管理员）
+//   - GET /api/v1/invitations - 获取等待接受的邀请（管理员）
+//   - DELETE /api/v1/invitations/:id - 撤销邀请（管理员）
+//   - POST /api/v1/invitations/:token/accept - 接受邀请并注册（不需要登录，凭邀请令牌）
+func (r *userRouter) addInvitation() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 邀请用户注册（需要 user:invite:any 权限）
+				Method:     http.MethodPost,
+				Path:       "/invitations",
+				Handler:    user.CreateInvitationHandler(r.serverCtx),
+				Permission: rbac.PermUserInviteAny,
+			},
+			{
+				// 获取等待接受的邀请（需要 user:invite:any 权限）
+				Method:     http.MethodGet,
+				Path:       "/invitations",
+				Handler:    user.ListInvitationsHandler(r.serverCtx),
+				Permission: rbac.PermUserInviteAny,
+			},
+			{
+				// 撤销邀请（需要 user:invite:any 权限）
+				Method:     http.MethodDelete,
+				Path:       "/invitations/:id",
+				Handler:    user.RevokeInvitationHandler(r.serverCtx),
+				Permission: rbac.PermUserInviteAny,
+			},
+			{
+				// 接受邀请并注册
+				Method:  http.MethodPost,
+				Path:    "/invitations/:token/accept",
+				Handler: user.AcceptInvitationHandler(r.serverCtx),
+			},
+		}),
 		rest.WithPrefix("/api/v1"),
 	)
 }
 
 // addUserInformationManagement 用户信息管理
 //   - GET /api/v1/users/:username - 获取单个用户基础信息 【新增】
-//   - PUT /api/v1/users/:username - 更新用户信息（完整更新）
-//   - PATCH /api/v1/users/:username - 部分更新用户信息
+//   - PUT /api/v1/users/:username - 更新用户信息（完整更新，本人或管理员）
+//   - PATCH /api/v1/users/:username - 部分更新用户信息（JSON Merge Patch，本人或管理员）
+//   - PUT /api/v1/users/:username/username - 变更用户名（本人或管理员）
 //   - GET /api/v1/users/:username/profile - 获取用户详细资料
 func (r *userRouter) addUserInformationManagement() {
 	// v1 接口组
 	r.server.AddRoutes(
-		[]rest.Route{
+		toRestRoutes(r.serverCtx, []accessRoute{
 			{
 				// 获取单个用户
 				Method:  http.MethodGet,
 				Path:    "/users/:username",
 				Handler: user.GetUserHandler(r.serverCtx),
 			},
-		},
+			{
+				// 更新用户信息（完整更新，本人或拥有 user:update:any 权限）
+				Method:       http.MethodPut,
+				Path:         "/users/:username",
+				Handler:      user.UpdateUserHandler(r.serverCtx),
+				RequireOwner: true,
+				Permission:   rbac.PermUserUpdateAny,
+			},
+			{
+				// 部分更新用户信息（JSON Merge Patch，本人或拥有 user:update:any 权限）
+				Method:       http.MethodPatch,
+				Path:         "/users/:username",
+				Handler:      user.PatchUserHandler(r.serverCtx),
+				RequireOwner: true,
+				Permission:   rbac.PermUserUpdateAny,
+			},
+			{
+				// 变更用户名（本人或拥有 user:update:any 权限）
+				Method:       http.MethodPut,
+				Path:         "/users/:username/username",
+				Handler:      user.RenameUserHandler(r.serverCtx),
+				RequireOwner: true,
+				Permission:   rbac.PermUserUpdateAny,
+			},
+		}),
 		rest.WithPrefix("/api/v1"),
 	)
 }
 
 // addBatchUserInformationManagement 用户批量管理
+//   - GET /api/v1/users - 获取用户列表（管理员，支持过滤、排序、偏移分页与游标分页）
 func (r *userRouter) addBatchUserInformationManagement() {
 	// v1 接口组
 	r.server.AddRoutes(
-		[]rest.Route{
+		toRestRoutes(r.serverCtx, []accessRoute{
 			{
-				// 获取用户列表
-				Method:  http.MethodGet,
-				Path:    "/users",
-				Handler: user.GetUserListHandler(r.serverCtx),
+				// 获取用户列表（需要 user:list:any 权限）
+				Method:     http.MethodGet,
+				Path:       "/users",
+				Handler:    user.GetUserListHandler(r.serverCtx),
+				Permission: rbac.PermUserListAny,
 			},
-		},
+		}),
 		rest.WithPrefix("/api/v1"),
 	)
 }
 
 // addAccountStatusManagement 账户状态管理
-//   - DELETE /api/v1/users/:username - 删除用户 【新增】
-//   - PUT /api/v1/users/:username/status - 更新用户状态（启用/禁用/锁定）
-//   - PUT /api/v1/users/:username/activate - 激活用户
-//   - PUT /api/v1/users/:username/deactivate - 停用用户
+//   - DELETE /api/v1/users/:username - 删除用户（本人或管理员） 【新增】
+//   - PUT /api/v1/users/:username/status - 更新用户状态（管理员，按状态机迁移：正常/禁用/锁定/暂停）
+//   - PUT /api/v1/users/:username/activate - 激活用户（管理员）
+//   - PUT /api/v1/users/:username/deactivate - 停用用户（管理员）
+//   - POST /api/v1/users/:username/unlock - 解锁用户（管理员）
+//   - POST /api/v1/users/:username/restore - 恢复已删除的用户（管理员）
 func (r *userRouter) addAccountStatusManagement() {
 	// v1 接口组
 	r.server.AddRoutes(
-		[]rest.Route{
+		toRestRoutes(r.serverCtx, []accessRoute{
 			{
-				// 删除用户
-				Method:  http.MethodDelete,
-				Path:    "/users/:username",
-				Handler: user.DeleteUserHandler(r.serverCtx),
+				// 删除用户（本人或拥有 user:delete:any 权限）
+				Method:       http.MethodDelete,
+				Path:         "/users/:username",
+				Handler:      user.DeleteUserHandler(r.serverCtx),
+				RequireOwner: true,
+				Permission:   rbac.PermUserDeleteAny,
 			},
-		},
+			{
+				// 更新用户状态（需要 user:status:any 权限）
+				Method:     http.MethodPut,
+				Path:       "/users/:username/status",
+				Handler:    user.UpdateUserStatusHandler(r.serverCtx),
+				Permission: rbac.PermUserStatusAny,
+			},
+			{
+				// 激活用户（需要 user:status:any 权限）
+				Method:     http.MethodPut,
+				Path:       "/users/:username/activate",
+				Handler:    user.ActivateUserHandler(r.serverCtx),
+				Permission: rbac.PermUserStatusAny,
+			},
+			{
+				// 停用用户（需要 user:status:any 权限）
+				Method:     http.MethodPut,
+				Path:       "/users/:username/deactivate",
+				Handler:    user.DeactivateUserHandler(r.serverCtx),
+				Permission: rbac.PermUserStatusAny,
+			},
+			{
+				// 解锁用户（需要 user:status:any 权限）
+				Method:     http.MethodPost,
+				Path:       "/users/:username/unlock",
+				Handler:    user.UnlockUserHandler(r.serverCtx),
+				Permission: rbac.PermUserStatusAny,
+			},
+			{
+				// 恢复已删除的用户（需要 user:restore:any 权限）
+				Method:     http.MethodPost,
+				Path:       "/users/:username/restore",
+				Handler:    user.RestoreUserHandler(r.serverCtx),
+				Permission: rbac.PermUserRestoreAny,
+			},
+		}),
 		rest.WithPrefix("/api/v1"),
 	)
 }
 
 // addPasswordManagement 密码管理
 // - `PUT /api/v1/users/:username/password` - 修改密码
+// - `POST /api/v1/users/password/reset/request` - 申请重置密码（发送重置验证码）
 // - `POST /api/v1/users/password/reset` - 重置密码（忘记密码）
 // - `POST /api/v1/users/password/reset/verify` - 验证重置密码令牌
+// - `GET /api/v1/password/policy` - 获取密码策略
+// - `POST /api/v1/password/strength` - 评估密码强度
 func (r *userRouter) addPasswordManagement() {
 	// v1 接口组
 	r.server.AddRoutes(
-		[]rest.Route{
+		toRestRoutes(r.serverCtx, []accessRoute{
 			{
-				// 修改密码
-				Method:  http.MethodPut,
-				Path:    "/users/:username/password",
-				Handler: user.UpdatePasswordHandler(r.serverCtx),
+				// 修改密码（仅限本人）
+				Method:       http.MethodPut,
+				Path:         "/users/:username/password",
+				Handler:      user.UpdatePasswordHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+			{
+				// 申请重置密码（发送重置验证码）
+				Method:  http.MethodPost,
+				Path:    "/users/password/reset/request",
+				Handler: user.RequestPasswordResetHandler(r.serverCtx),
 			},
 			{
 				// 重置密码（忘记密码）
@@ -129,7 +252,156 @@ func (r *userRouter) addPasswordManagement() {
 				Path:    "/users/password/reset/verify",
 				Handler: user.VerifyResetPasswordTokenHandler(r.serverCtx),
 			},
-		},
+			{
+				// 获取密码策略（供前端渲染密码规则）
+				Method:  http.MethodGet,
+				Path:    "/password/policy",
+				Handler: user.GetPasswordPolicyHandler(r.serverCtx),
+			},
+			{
+				// 评估密码强度（供前端渲染实时强度条）
+				Method:  http.MethodPost,
+				Path:    "/password/strength",
+				Handler: user.EstimatePasswordStrengthHandler(r.serverCtx),
+			},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
+
+// addAccountVerification 账户验证
+//   - POST /api/v1/users/email/verify - 验证邮箱
+//   - POST /api/v1/users/email/verify/resend - 重新发送邮箱验证码
+//   - PUT /api/v1/users/:username/email - 更换邮箱（新邮箱验证通过后生效）
+func (r *userRouter) addAccountVerification() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 验证邮箱
+				Method:      http.MethodPost,
+				Path:        "/users/email/verify",
+				Handler:     user.VerifyEmailHandler(r.serverCtx),
+				RequireAuth: true,
+			},
+			{
+				// 重新发送邮箱验证码
+				Method:      http.MethodPost,
+				Path:        "/users/email/verify/resend",
+				Handler:     user.ResendEmailVerificationHandler(r.serverCtx),
+				RequireAuth: true,
+			},
+			{
+				// 更换邮箱（仅限本人）
+				Method:       http.MethodPut,
+				Path:         "/users/:username/email",
+				Handler:      user.ChangeEmailHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
+
+// addDataExport 个人数据导出
+//   - POST /api/v1/users/:username/export - 申请导出个人数据（异步生成归档）
+//   - GET /api/v1/users/:username/export/:id - 查询导出任务，完成后返回限时下载地址
+//   - GET /api/v1/exports/:id/download - 下载导出归档（限时签名地址，不需要登录）
+func (r *userRouter) addDataExport() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 申请导出个人数据（仅限本人）
+				Method:       http.MethodPost,
+				Path:         "/users/:username/export",
+				Handler:      user.RequestDataExportHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+			{
+				// 查询导出任务（仅限本人）
+				Method:       http.MethodGet,
+				Path:         "/users/:username/export/:id",
+				Handler:      user.GetDataExportHandler(r.serverCtx),
+				RequireOwner: true,
+			},
+			{
+				// 下载导出归档（凭签名访问）
+				Method:  http.MethodGet,
+				Path:    "/exports/:id/download",
+				Handler: user.DownloadDataExportHandler(r.serverCtx),
+			},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
+
+// addErasure 个人数据擦除（被遗忘权）
+//   - POST /api/v1/users/:username/erase - 擦除用户个人数据（管理员，活跃与已删除用户均可）
+//   - GET /api/v1/erasures/:id - 按用户 ID 查询擦除进度（管理员）
+func (r *userRouter) addErasure() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 擦除用户个人数据（需要 user:erase:any 权限）
+				Method:     http.MethodPost,
+				Path:       "/users/:username/erase",
+				Handler:    user.EraseUserHandler(r.serverCtx),
+				Permission: rbac.PermUserEraseAny,
+			},
+			{
+				// 查询擦除进度（需要 user:erase:any 权限）
+				Method:     http.MethodGet,
+				Path:       "/erasures/:id",
+				Handler:    user.GetErasureHandler(r.serverCtx),
+				Permission: rbac.PermUserEraseAny,
+			},
+		}),
+		rest.WithPrefix("/api/v1"),
+	)
+}
+
+// addRoleManagement 角色与权限
+//   - GET /api/v1/roles - 获取全部角色及其权限
+//   - GET /api/v1/users/:username/roles - 获取用户角色（本人或管理员）
+//   - PUT /api/v1/users/:username/roles - 更新用户角色（管理员）
+//   - GET /api/v1/users/:username/permissions - 获取用户权限（本人或管理员）
+func (r *userRouter) addRoleManagement() {
+	// v1 接口组
+	r.server.AddRoutes(
+		toRestRoutes(r.serverCtx, []accessRoute{
+			{
+				// 获取全部角色（需要 role:read:any 权限）
+				Method:     http.MethodGet,
+				Path:       "/roles",
+				Handler:    user.ListRolesHandler(r.serverCtx),
+				Permission: rbac.PermRoleReadAny,
+			},
+			{
+				// 获取用户角色（本人或拥有 role:read:any 权限）
+				Method:       http.MethodGet,
+				Path:         "/users/:username/roles",
+				Handler:      user.GetUserRolesHandler(r.serverCtx),
+				RequireOwner: true,
+				Permission:   rbac.PermRoleReadAny,
+			},
+			{
+				// 更新用户角色（需要 role:assign:any 权限）
+				Method:     http.MethodPut,
+				Path:       "/users/:username/roles",
+				Handler:    user.UpdateUserRolesHandler(r.serverCtx),
+				Permission: rbac.PermRoleAssignAny,
+			},
+			{
+				// 获取用户权限（本人或拥有 role:read:any 权限）
+				Method:       http.MethodGet,
+				Path:         "/users/:username/permissions",
+				Handler:      user.GetUserPermissionsHandler(r.serverCtx),
+				RequireOwner: true,
+				Permission:   rbac.PermRoleReadAny,
+			},
+		}),
 		rest.WithPrefix("/api/v1"),
 	)
 }
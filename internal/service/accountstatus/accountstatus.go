@@ -0,0 +1,221 @@
+// Package accountstatus 账户状态机，供登录、修改密码、账户状态管理等流程复用
+//
+// 状态（见 [userConstant] 的 Status* 常量）与允许的迁移：
+//
+//	待验证 → 正常、禁用
+//	正常   → 禁用、锁定、暂停
+//	锁定   → 正常、禁用
+//	暂停   → 正常、禁用
+//	禁用   → 正常
+//
+// 每次迁移都记录原因与操作人（见 [userEntity.StatusHistory]），使用户缓存失效并发布 [event.TypeUserStatusChanged] 事件；
+// 迁移到禁用、锁定、暂停状态时吊销该用户的所有会话
+package accountstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/svc"
+)
+
+// ActorSystem 系统自动变更状态时的操作人（如连续认证失败锁定、暂停到期恢复）
+const ActorSystem = "system"
+
+var (
+	// ErrDisabled 账户被禁用
+	ErrDisabled = errors.New("account is disabled")
+
+	// ErrLocked 账户被锁定（连续认证失败次数过多），需要管理员解锁
+	ErrLocked = errors.New("account is locked")
+
+	// ErrSuspended 账户被暂停，见 [SuspendedError]
+	ErrSuspended = errors.New("account is suspended")
+
+	// ErrInvalidTransition 不允许的状态迁移
+	ErrInvalidTransition = errors.New("invalid account status transition")
+
+	// ErrConflict 状态已被并发修改
+	ErrConflict = errors.New("account status was changed concurrently")
+)
+
+// SuspendedError 账户暂停错误，携带到期时间，errors.Is(err, ErrSuspended) 为 true
+type SuspendedError struct {
+	// 暂停到期时间，为空表示无限期暂停
+	Until *time.Time
+}
+
+func (e *SuspendedError) Error() string {
+	if e.Until == nil {
+		return ErrSuspended.Error()
+	}
+	return fmt.Sprintf("%s until %s", ErrSuspended.Error(), e.Until.Format(time.RFC3339))
+}
+
+func (e *SuspendedError) Is(target error) bool {
+	return target == ErrSuspended
+}
+
+// transitions 允许的状态迁移
+var transitions = map[int8][]int8{
+	userConstant.StatusPendingVerification: {userConstant.StatusActive, userConstant.StatusDisabled},
+	userConstant.StatusActive:              {userConstant.StatusDisabled, userConstant.StatusLocked, userConstant.StatusSuspended},
+	userConstant.StatusLocked:              {userConstant.StatusActive, userConstant.StatusDisabled},
+	userConstant.StatusSuspended:           {userConstant.StatusActive, userConstant.StatusDisabled},
+	userConstant.StatusDisabled:            {userConstant.StatusActive},
+}
+
+// CanTransition 判断是否允许从 from 状态迁移到 to 状态
+func CanTransition(from, to int8) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Valid 判断状态值是否存在
+func Valid(status int8) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// Change 一次状态变更
+type Change struct {
+	// 目标状态
+	To int8
+	// 暂停到期时间，迁移到暂停状态时必须晚于当前时间
+	SuspendedUntil *time.Time
+	// 变更原因
+	Reason string
+	// 操作人用户名，系统自动变更时为 [ActorSystem]
+	Actor string
+}
+
+// Manager 账户状态机
+type Manager struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewManager 创建账户状态机
+func NewManager(svcCtx *svc.ServiceContext) *Manager {
+	return &Manager{svcCtx: svcCtx}
+}
+
+// Check 检查账户状态是否允许认证（登录、刷新令牌、修改密码等），不允许时返回对应的错误
+//   - 正常、待验证：允许（待验证的账户需要登录后才能验证邮箱）
+//   - 暂停：未到期时返回 [*SuspendedError]；已到期时自动恢复为正常状态后允许
+//   - 锁定：返回 [ErrLocked]
+//   - 禁用及其他状态：返回 [ErrDisabled]
+func (m *Manager) Check(ctx context.Context, existUser *userEntity.User) error {
+	switch existUser.Status {
+	case userConstant.StatusActive, userConstant.StatusPendingVerification:
+		return nil
+	case userConstant.StatusSuspended:
+		if existUser.SuspendedUntil == nil || time.Now().Before(*existUser.SuspendedUntil) {
+			return &SuspendedError{Until: existUser.SuspendedUntil}
+		}
+		err := m.Transition(ctx, existUser, Change{
+			To:     userConstant.StatusActive,
+			Reason: "suspension expired",
+			Actor:  ActorSystem,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resume suspended user(%s): %w", existUser.Username, err)
+		}
+		return nil
+	case userConstant.StatusLocked:
+		return ErrLocked
+	default:
+		return ErrDisabled
+	}
+}
+
+// Transition 按状态机迁移账户状态，成功后同步更新 existUser
+// 不允许的迁移返回 [ErrInvalidTransition]，状态已被并发修改返回 [ErrConflict]；
+// 使缓存失效、吊销会话与发布事件失败只记录日志，不影响迁移结果
+func (m *Manager) Transition(ctx context.Context, existUser *userEntity.User, change Change) error {
+	from := existUser.Status
+	if !CanTransition(from, change.To) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, Name(from), Name(change.To))
+	}
+	if change.To == userConstant.StatusSuspended {
+		if change.SuspendedUntil == nil || !change.SuspendedUntil.After(time.Now()) {
+			return fmt.Errorf("%w: suspension end time must be in the future", ErrInvalidTransition)
+		}
+	} else {
+		change.SuspendedUntil = nil
+	}
+
+	changed, err := m.svcCtx.Repository.User.ChangeStatus(ctx, &userEntity.StatusHistory{
+		UserID:         existUser.ID,
+		FromStatus:     from,
+		ToStatus:       change.To,
+		SuspendedUntil: change.SuspendedUntil,
+		Reason:         change.Reason,
+		Actor:          change.Actor,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change status of user(%s): %w", existUser.Username, err)
+	}
+	if !changed {
+		return ErrConflict
+	}
+	existUser.Status = change.To
+	existUser.SuspendedUntil = change.SuspendedUntil
+
+	logger := logx.WithContext(ctx)
+	logger.Infof("user(%s) status changed from %s to %s by %s: %s", existUser.Username, Name(from), Name(change.To), change.Actor, change.Reason)
+
+	if err := m.svcCtx.Repository.CachedUser.DeleteByUsername(ctx, existUser.Username); err != nil {
+		logger.Errorf("failed to delete user cache for user(%s): %v", existUser.Username, err)
+	}
+	// 不再允许认证的账户立即下线
+	if change.To != userConstant.StatusActive {
+		if _, err := m.svcCtx.Repository.Session.DeleteAllByUser(ctx, existUser.GetIDAsString()); err != nil {
+			logger.Errorf("failed to revoke sessions for user(%s): %v", existUser.Username, err)
+		}
+	}
+
+	data := map[string]interface{}{
+		"username": existUser.Username,
+		"from":     Name(from),
+		"to":       Name(change.To),
+		"reason":   change.Reason,
+		"actor":    change.Actor,
+	}
+	if change.SuspendedUntil != nil {
+		data["suspended_until"] = change.SuspendedUntil.Unix()
+	}
+	err = m.svcCtx.Publisher.Publish(ctx, &event.UserEvent{
+		EventType: event.TypeUserStatusChanged,
+		UserID:    existUser.GetIDAsString(),
+		Data:      data,
+	})
+	if err != nil {
+		logger.Errorf("failed to publish %s event: %v", event.TypeUserStatusChanged, err)
+	}
+	return nil
+}
+
+// Name 状态名称，用于日志、事件与接口响应
+func Name(status int8) string {
+	switch status {
+	case userConstant.StatusActive:
+		return "active"
+	case userConstant.StatusDisabled:
+		return "disabled"
+	case userConstant.StatusLocked:
+		return "locked"
+	case userConstant.StatusPendingVerification:
+		return "pending_verification"
+	case userConstant.StatusSuspended:
+		return "suspended"
+	default:
+		return fmt.Sprintf("unknown(%d)", status)
+	}
+}
@@ -0,0 +1,52 @@
+// Package auth 错误定义
+package auth
+
+import (
+	"errors"
+
+	"hello-gozero/internal/service/accountstatus"
+)
+
+var (
+	// 用户名或密码错误（不区分用户不存在与密码错误，避免用户名枚举）
+	ErrInvalidCredentials = errors.New("invalid username or password")
+
+	// 账户被禁用，见 [accountstatus.ErrDisabled]
+	ErrAccountDisabled = accountstatus.ErrDisabled
+
+	// 账户被锁定（连续认证失败次数过多），需要管理员解锁，见 [accountstatus.ErrLocked]
+	ErrAccountLocked = accountstatus.ErrLocked
+
+	// 账户被暂停，到期后自动恢复，见 [accountstatus.SuspendedError]
+	ErrAccountSuspended = accountstatus.ErrSuspended
+
+	// 刷新令牌无效（不存在、已使用或已过期）
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+	// 会话已被吊销（刷新令牌的同时会话被登出或吊销）
+	ErrSessionRevoked = errors.New("session has been revoked")
+
+	// 请求未携带已认证的调用方身份
+	ErrUnauthenticated = errors.New("unauthenticated")
+
+	// 用户不存在
+	ErrUserNotFound = errors.New("user not found")
+
+	// 密码错误（已登录用户执行敏感操作时的二次确认）
+	ErrPasswordMismatch = errors.New("password is incorrect")
+
+	// 两步验证已启用
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	// 尚未开始绑定身份验证器
+	ErrMFANotEnrolled = errors.New("two-factor authentication is not enrolled")
+
+	// 两步验证未启用
+	ErrMFANotEnabled = errors.New("two-factor authentication is not enabled")
+
+	// 动态口令或恢复码无效
+	ErrInvalidMFACode = errors.New("invalid two-factor authentication code")
+
+	// 登录挑战无效（不存在、已使用、已过期或失败次数过多）
+	ErrInvalidMFAChallenge = errors.New("invalid or expired mfa challenge")
+)
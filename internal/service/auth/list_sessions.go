@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/svc"
+)
+
+type ListSessionsService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewListSessionsService 获取用户会话列表
+func NewListSessionsService(ctx context.Context, svcCtx *svc.ServiceContext) *ListSessionsService {
+	return &ListSessionsService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *ListSessionsService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// ListSessions 获取用户所有有效会话，按最近活跃时间倒序
+// 路由已通过归属校验，路径中的用户即当前调用方
+func (s *ListSessionsService) ListSessions(_ *authDto.ListSessionsReq) (*authDto.ListSessionsResp, error) {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	sessions, err := s.svcCtx.Repository.Session.ListByUser(s.ctx, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions for user(%s): %w", principal.UserID, err)
+	}
+
+	resp := &authDto.ListSessionsResp{
+		Sessions: make([]authDto.SessionInfo, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, authDto.SessionInfo{
+			ID:         session.ID,
+			Device:     session.Device,
+			IP:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID == principal.SessionID,
+		})
+	}
+	return resp, nil
+}
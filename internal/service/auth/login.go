@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	authDto "hello-gozero/internal/dto/auth"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/middleware"
+	authRepo "hello-gozero/internal/repository/auth"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/service/credential"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/utils/token"
+)
+
+type LoginService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	guard  *lockout.Guard
+}
+
+// NewLoginService 用户登录
+func NewLoginService(ctx context.Context, svcCtx *svc.ServiceContext) *LoginService {
+	return &LoginService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		guard:  lockout.NewGuard(svcCtx),
+	}
+}
+
+func (s *LoginService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// Login 校验用户名和密码，成功后签发访问令牌与刷新令牌，并更新最后登录时间
+// 账户启用了两步验证时不签发令牌，而是返回登录挑战，由 [LoginMFAService.LoginMFA] 完成登录
+//
+// 用户名或客户端 IP 连续认证失败次数过多时暂时锁定（见 [lockout.Guard]），锁定期间直接拒绝，不再校验密码
+func (s *LoginService) Login(req *authDto.LoginReq) (*authDto.LoginResp, error) {
+	if err := s.guard.Check(s.ctx, req.Username, req.ClientIP); err != nil {
+		return nil, err
+	}
+
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 与虚拟哈希比对，保证「用户不存在」与「密码错误」两种情况的耗时一致
+			credential.NewVerifier(s.svcCtx).Verify(s.ctx, nil, req.Password)
+			s.guard.RecordFailure(s.ctx, req.Username, req.ClientIP)
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to get user by name(%s): %w", req.Username, err)
+	}
+
+	// 对比密码，哈希已过时时自动重新生成（见 [credential.Verifier]）
+	if !credential.NewVerifier(s.svcCtx).Verify(s.ctx, existUser, req.Password) {
+		s.guard.RecordFailure(s.ctx, req.Username, req.ClientIP)
+		return nil, ErrInvalidCredentials
+	}
+
+	// 密码正确后再检查账户状态，避免向未通过认证的调用方泄露账户状态
+	if err := accountstatus.NewManager(s.svcCtx).Check(s.ctx, existUser); err != nil {
+		return nil, err
+	}
+
+	userID := existUser.GetIDAsString()
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", userID))
+
+	// 启用了两步验证的账户先返回登录挑战，动态口令校验通过后才创建会话
+	mfa, err := getUserMFA(s.ctx, s.svcCtx, existUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa != nil && mfa.Enabled {
+		return s.challenge(existUser, req)
+	}
+
+	// 启用了两步验证的账户在动态口令校验通过后才清零失败计数，避免攻击者借助正确的密码反复重置计数
+	s.guard.RecordSuccess(s.ctx, existUser.Username)
+
+	tokenPair, err := startSession(s.ctx, s.svcCtx, existUser, req.Device, middleware.GetClientIP(s.ctx), middleware.GetUserAgent(s.ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &authDto.LoginResp{
+		TokenPair:              tokenPair,
+		PasswordChangeRequired: credential.NewVerifier(s.svcCtx).PasswordChangeRequired(s.ctx, existUser),
+	}, nil
+}
+
+// challenge 创建两步验证登录挑战，登录信息暂存在挑战中，挑战令牌只返回给客户端，服务端只保存摘要
+func (s *LoginService) challenge(existUser *userEntity.User, req *authDto.LoginReq) (*authDto.LoginResp, error) {
+	mfaToken, err := token.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(s.svcCtx.Config.Auth.MFA.ChallengeExpire) * time.Second
+	err = s.svcCtx.Repository.MFAChallenge.Save(s.ctx, token.HashRefreshToken(mfaToken), &authRepo.MFAChallenge{
+		UserID:    existUser.GetIDAsString(),
+		Username:  existUser.Username,
+		Device:    req.Device,
+		IP:        middleware.GetClientIP(s.ctx),
+		UserAgent: middleware.GetUserAgent(s.ctx),
+	}, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save mfa challenge: %w", err)
+	}
+
+	return &authDto.LoginResp{
+		MFARequired:  true,
+		MFAToken:     mfaToken,
+		MFAExpiresIn: int64(ttl.Seconds()),
+	}, nil
+}
+
+// startSession 为通过认证的用户创建新的会话并签发令牌，同时更新最后登录时间
+// 每次登录创建一个新的会话，刷新令牌轮换时沿用该会话
+func startSession(ctx context.Context, svcCtx *svc.ServiceContext, existUser *userEntity.User, device, ip, userAgent string) (*authDto.TokenPair, error) {
+	logger := logx.WithContext(ctx)
+
+	now := time.Now()
+	tokenPair, err := issueTokenPair(ctx, svcCtx, &authRepo.Session{
+		ID:        uuid.New().String(),
+		UserID:    existUser.GetIDAsString(),
+		Username:  existUser.Username,
+		TenantID:  existUser.TenantID,
+		Device:    device,
+		IP:        ip,
+		UserAgent: userAgent,
+		CreatedAt: now.Unix(),
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新最后登录时间，失败不影响登录结果
+	if err := svcCtx.Repository.User.UpdateLastLoginTime(ctx, existUser.ID, now); err != nil {
+		logger.Errorf("failed to update last login time for user(%s): %v", existUser.Username, err)
+	} else if err := svcCtx.Repository.CachedUser.DeleteByUsername(ctx, existUser.Username); err != nil {
+		// 缓存中的用户信息包含最后登录时间，需要使其失效
+		logger.Errorf("failed to delete user cache for user(%s): %v", existUser.Username, err)
+	}
+	return tokenPair, nil
+}
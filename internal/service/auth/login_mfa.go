@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	authDto "hello-gozero/internal/dto/auth"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/service/credential"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/utils/token"
+)
+
+type LoginMFAService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewLoginMFAService 两步验证登录
+func NewLoginMFAService(ctx context.Context, svcCtx *svc.ServiceContext) *LoginMFAService {
+	return &LoginMFAService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *LoginMFAService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// LoginMFA 使用登录挑战与动态口令（或恢复码）完成登录
+//
+// 暴力破解防护：单个挑战的失败次数达到上限后挑战作废，需要重新使用用户名和密码登录
+func (s *LoginMFAService) LoginMFA(req *authDto.LoginMFAReq) (*authDto.LoginMFAResp, error) {
+	if req.MFAToken == "" {
+		return nil, ErrInvalidMFAChallenge
+	}
+	challengeHash := token.HashRefreshToken(req.MFAToken)
+	repo := s.svcCtx.Repository.MFAChallenge
+
+	challenge, err := repo.Get(s.ctx, challengeHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+	if challenge == nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", challenge.UserID))
+
+	guard := lockout.NewGuard(s.svcCtx)
+	if err := guard.Check(s.ctx, challenge.Username, challenge.IP); err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(challenge.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed user id %q: %w", challenge.UserID, err)
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByID(s.ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidMFAChallenge
+		}
+		return nil, fmt.Errorf("failed to get user by id(%s): %w", challenge.UserID, err)
+	}
+	// 挑战创建之后账户可能被禁用、锁定或暂停
+	if err := accountstatus.NewManager(s.svcCtx).Check(s.ctx, existUser); err != nil {
+		return nil, err
+	}
+
+	mfa, err := getUserMFA(s.ctx, s.svcCtx, existUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil || !mfa.Enabled {
+		// 挑战创建之后两步验证被关闭，需要重新登录
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	usedRecovery, err := verifySecondFactor(s.ctx, s.svcCtx, mfa, challenge.UserID, req.Code, req.RecoveryCode)
+	if err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			s.recordFailure(challengeHash)
+			// 动态口令错误同样计入用户名与 IP 的认证失败次数
+			guard.RecordFailure(s.ctx, existUser.Username, challenge.IP)
+		}
+		return nil, err
+	}
+
+	// 消费挑战，并发使用同一个挑战时只有一个请求能够成功
+	consumed, err := repo.Delete(s.ctx, challengeHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume mfa challenge: %w", err)
+	}
+	if !consumed {
+		return nil, ErrInvalidMFAChallenge
+	}
+	guard.RecordSuccess(s.ctx, existUser.Username)
+
+	tokenPair, err := startSession(s.ctx, s.svcCtx, existUser, challenge.Device, challenge.IP, challenge.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &authDto.LoginMFAResp{
+		TokenPair:              *tokenPair,
+		PasswordChangeRequired: credential.NewVerifier(s.svcCtx).PasswordChangeRequired(s.ctx, existUser),
+	}
+	if usedRecovery {
+		remaining, err := s.svcCtx.Repository.MFA.CountUnusedRecoveryCodes(s.ctx, existUser.ID)
+		if err != nil {
+			s.Logger.WithContext(s.ctx).Errorf("failed to count recovery codes: %v", err)
+		} else {
+			resp.RecoveryCodesRemaining = &remaining
+		}
+		notifySecurityEvent(s.ctx, s.svcCtx, existUser, "signed in with a recovery code")
+	}
+	return resp, nil
+}
+
+// recordFailure 累计挑战的失败次数，达到上限后挑战作废
+func (s *LoginMFAService) recordFailure(challengeHash string) {
+	repo := s.svcCtx.Repository.MFAChallenge
+	logger := s.Logger.WithContext(s.ctx)
+
+	attempts, err := repo.IncrAttempts(s.ctx, challengeHash)
+	if err != nil {
+		logger.Errorf("failed to count mfa challenge attempts: %v", err)
+		return
+	}
+	if attempts >= s.svcCtx.Config.Auth.MFA.ChallengeMaxAttempts {
+		if _, err := repo.Delete(s.ctx, challengeHash); err != nil {
+			logger.Errorf("failed to delete mfa challenge: %v", err)
+		}
+	}
+}
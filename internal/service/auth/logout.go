@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/svc"
+)
+
+type LogoutService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewLogoutService 用户登出
+func NewLogoutService(ctx context.Context, svcCtx *svc.ServiceContext) *LogoutService {
+	return &LogoutService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *LogoutService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// Logout 吊销当前会话
+// 会话删除后，该会话签发的访问令牌会被认证中间件拒绝，刷新令牌也随之失效
+func (s *LogoutService) Logout(_ *authDto.LogoutReq) (*authDto.LogoutResp, error) {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := s.svcCtx.Repository.Session.Delete(s.ctx, principal.UserID, principal.SessionID); err != nil {
+		return nil, fmt.Errorf("failed to delete session(%s): %w", principal.SessionID, err)
+	}
+	return &authDto.LogoutResp{}, nil
+}
+
+// LogoutAll 吊销当前用户的所有会话（包括当前会话），用于怀疑账户泄露时一键下线所有设备
+func (s *LogoutService) LogoutAll(_ *authDto.LogoutAllReq) (*authDto.LogoutAllResp, error) {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	revoked, err := s.svcCtx.Repository.Session.DeleteAllByUser(s.ctx, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete sessions for user(%s): %w", principal.UserID, err)
+	}
+	s.Logger.WithContext(s.ctx).Infof("revoked %d sessions for user(%s)", revoked, principal.Username)
+
+	return &authDto.LogoutAllResp{Revoked: revoked}, nil
+}
@@ -0,0 +1,293 @@
+package auth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	"hello-gozero/infra/cache"
+	authDto "hello-gozero/internal/dto/auth"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/notify"
+	"hello-gozero/internal/service/credential"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/utils/password"
+	"hello-gozero/internal/utils/totp"
+	"hello-gozero/pkg/i18n"
+)
+
+// recoveryCodeHashLabel 计算恢复码摘要时使用的用途标签，与 pepper 派生的其他密钥互相隔离
+const recoveryCodeHashLabel = "mfa-recovery-code"
+
+type MFAService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewMFAService 两步验证管理
+func NewMFAService(ctx context.Context, svcCtx *svc.ServiceContext) *MFAService {
+	return &MFAService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *MFAService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// EnrollTOTP 开始绑定身份验证器，生成新的 TOTP 密钥
+// 密钥加密后以「待确认」状态保存，用户使用身份验证器生成的口令调用 [MFAService.ConfirmTOTP] 后才会启用；
+// 重复调用会覆盖之前未确认的密钥
+func (s *MFAService) EnrollTOTP(_ *authDto.EnrollTOTPReq) (*authDto.EnrollTOTPResp, error) {
+	existUser, err := s.getPrincipalUser()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *authDto.EnrollTOTPResp
+	err = s.withinLock(existUser, func() error {
+		mfa, err := getUserMFA(s.ctx, s.svcCtx, existUser.ID)
+		if err != nil {
+			return err
+		}
+		if mfa != nil && mfa.Enabled {
+			return ErrMFAAlreadyEnabled
+		}
+
+		secret, err := totp.GenerateSecret()
+		if err != nil {
+			return err
+		}
+		encrypted, err := s.svcCtx.Security.TOTPCipher.Encrypt(secret)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt totp secret: %w", err)
+		}
+		if err := s.svcCtx.Repository.MFA.SavePending(s.ctx, existUser.ID, encrypted); err != nil {
+			return fmt.Errorf("failed to save pending totp secret: %w", err)
+		}
+
+		resp = &authDto.EnrollTOTPResp{
+			Secret:     secret,
+			OTPAuthURI: totp.KeyURI(s.svcCtx.Config.Auth.MFA.Issuer, existUser.Username, secret),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ConfirmTOTP 使用身份验证器生成的口令确认绑定，启用两步验证并生成恢复码
+func (s *MFAService) ConfirmTOTP(req *authDto.ConfirmTOTPReq) (*authDto.ConfirmTOTPResp, error) {
+	existUser, err := s.getPrincipalUser()
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	err = s.withinLock(existUser, func() error {
+		mfa, err := getUserMFA(s.ctx, s.svcCtx, existUser.ID)
+		if err != nil {
+			return err
+		}
+		if mfa == nil {
+			return ErrMFANotEnrolled
+		}
+		if mfa.Enabled {
+			return ErrMFAAlreadyEnabled
+		}
+
+		secret, err := s.svcCtx.Security.TOTPCipher.Decrypt(mfa.TOTPSecret)
+		if err != nil {
+			return err
+		}
+		step, ok := totp.Validate(secret, req.Code, time.Now())
+		if !ok {
+			return ErrInvalidMFACode
+		}
+
+		codes, err = totp.GenerateRecoveryCodes(s.svcCtx.Config.Auth.MFA.RecoveryCodes)
+		if err != nil {
+			return err
+		}
+		hashes := make([]string, 0, len(codes))
+		for _, code := range codes {
+			hashes = append(hashes, hashRecoveryCode(s.svcCtx, existUser.GetIDAsString(), code))
+		}
+		if err := s.svcCtx.Repository.MFA.Enable(s.ctx, existUser.ID, step, hashes, time.Now()); err != nil {
+			return fmt.Errorf("failed to enable mfa: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notifySecurityEvent(s.ctx, s.svcCtx, existUser, "two-factor authentication enabled")
+	return &authDto.ConfirmTOTPResp{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP 关闭两步验证，删除密钥与全部恢复码
+func (s *MFAService) DisableTOTP(req *authDto.DisableTOTPReq) (*authDto.DisableTOTPResp, error) {
+	existUser, err := s.getPrincipalUser()
+	if err != nil {
+		return nil, err
+	}
+
+	// 密码与动态口令的校验失败都计入认证失败次数
+	guard := lockout.NewGuard(s.svcCtx)
+	if err := guard.Check(s.ctx, existUser.Username, req.ClientIP); err != nil {
+		return nil, err
+	}
+	if !credential.NewVerifier(s.svcCtx).Verify(s.ctx, existUser, req.Password) {
+		guard.RecordFailure(s.ctx, existUser.Username, req.ClientIP)
+		return nil, ErrPasswordMismatch
+	}
+
+	err = s.withinLock(existUser, func() error {
+		mfa, err := getUserMFA(s.ctx, s.svcCtx, existUser.ID)
+		if err != nil {
+			return err
+		}
+		if mfa == nil || !mfa.Enabled {
+			return ErrMFANotEnabled
+		}
+
+		if _, err := verifySecondFactor(s.ctx, s.svcCtx, mfa, existUser.GetIDAsString(), req.Code, req.RecoveryCode); err != nil {
+			if errors.Is(err, ErrInvalidMFACode) {
+				guard.RecordFailure(s.ctx, existUser.Username, req.ClientIP)
+			}
+			return err
+		}
+		if err := s.svcCtx.Repository.MFA.Delete(s.ctx, existUser.ID); err != nil {
+			return fmt.Errorf("failed to delete mfa: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notifySecurityEvent(s.ctx, s.svcCtx, existUser, "two-factor authentication disabled")
+	return &authDto.DisableTOTPResp{}, nil
+}
+
+// getPrincipalUser 获取当前登录用户
+func (s *MFAService) getPrincipalUser() (*userEntity.User, error) {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, ErrUnauthenticated
+	}
+	userID, err := uuid.Parse(principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed user id %q: %w", principal.UserID, err)
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByID(s.ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by id(%s): %w", principal.UserID, err)
+	}
+	return existUser, nil
+}
+
+// withinLock 在用户维度的分布式锁保护下执行两步验证配置的变更，避免并发绑定、确认与关闭互相覆盖
+func (s *MFAService) withinLock(existUser *userEntity.User, fn func() error) error {
+	lockKey := fmt.Sprintf("lock:user:mfa:%s", existUser.GetIDAsString())
+	lockValue := uuid.New().String() // 锁的唯一标识
+	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
+	return cache.WithLock(s.ctx, s.svcCtx.Infra.Redis.Client, lockKey, lockValue, lockTTL, fn)
+}
+
+// getUserMFA 获取用户的两步验证配置，用户从未绑定时返回 nil, nil
+func getUserMFA(ctx context.Context, svcCtx *svc.ServiceContext, userID []byte) (*userEntity.UserMFA, error) {
+	mfa, err := svcCtx.Repository.MFA.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	return mfa, nil
+}
+
+// verifySecondFactor 校验动态口令或恢复码（二选一，优先使用动态口令），校验通过后立即消费，返回是否使用了恢复码
+//   - 动态口令：记录口令对应的时间步，同一个口令（及更早的口令）不能再次使用
+//   - 恢复码：标记为已使用
+func verifySecondFactor(ctx context.Context, svcCtx *svc.ServiceContext, mfa *userEntity.UserMFA, userID, code, recoveryCode string) (bool, error) {
+	repo := svcCtx.Repository.MFA
+
+	if code != "" {
+		secret, err := svcCtx.Security.TOTPCipher.Decrypt(mfa.TOTPSecret)
+		if err != nil {
+			return false, err
+		}
+		step, ok := totp.Validate(secret, code, time.Now())
+		if !ok || step <= mfa.LastUsedStep {
+			return false, ErrInvalidMFACode
+		}
+		used, err := repo.UseStep(ctx, mfa.UserID, step)
+		if err != nil {
+			return false, fmt.Errorf("failed to record totp step: %w", err)
+		}
+		if !used {
+			// 并发请求已使用了该口令
+			return false, ErrInvalidMFACode
+		}
+		return false, nil
+	}
+
+	if recoveryCode != "" {
+		used, err := repo.UseRecoveryCode(ctx, mfa.UserID, hashRecoveryCode(svcCtx, userID, recoveryCode), time.Now())
+		if err != nil {
+			return false, fmt.Errorf("failed to use recovery code: %w", err)
+		}
+		if !used {
+			return false, ErrInvalidMFACode
+		}
+		return true, nil
+	}
+
+	return false, ErrInvalidMFACode
+}
+
+// hashRecoveryCode 计算恢复码摘要
+// 沿用 [password.PasswordCryptoTool] 的 pepper 方案：恢复码随机且熵足够高，使用 HMAC 而不是 bcrypt，便于按摘要直接查找；
+// 摘要中混入用户 ID，不同用户的相同恢复码摘要不同
+func hashRecoveryCode(svcCtx *svc.ServiceContext, userID, code string) string {
+	input := recoveryCodeHashLabel + ":" + userID + ":" + totp.NormalizeRecoveryCode(code)
+	return hex.EncodeToString(password.HMACWithPepper(svcCtx.Config.Auth.MFA.SecretKey, []byte(input)))
+}
+
+// notifySecurityEvent 向用户邮箱发送账户安全提醒，发送失败只记录日志
+func notifySecurityEvent(ctx context.Context, svcCtx *svc.ServiceContext, user *userEntity.User, event string) {
+	if user.Email == "" {
+		return
+	}
+	err := svcCtx.Notifier.Notify(ctx, &notify.Message{
+		Channel:  notify.ChannelEmail,
+		To:       user.Email,
+		Template: notify.TemplateSecurityAlert,
+		Locale:   i18n.GetLocale(ctx),
+		Data: map[string]any{
+			"Username": user.Username,
+			"Event":    event,
+		},
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorf("failed to send security alert(%s) to user(%s): %v", event, user.Username, err)
+	}
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	authDto "hello-gozero/internal/dto/auth"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/utils/token"
+)
+
+type RefreshTokenService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewRefreshTokenService 刷新令牌
+func NewRefreshTokenService(ctx context.Context, svcCtx *svc.ServiceContext) *RefreshTokenService {
+	return &RefreshTokenService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *RefreshTokenService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// RefreshToken 使用刷新令牌换取新的令牌对
+//
+// 刷新令牌轮换（Refresh Token Rotation）：
+//  1. 原子地消费旧的刷新令牌，保证每个刷新令牌只能使用一次
+//  2. 校验刷新令牌所属的会话仍然有效（会话可能已通过登出等操作被吊销）
+//  3. 重新校验用户是否存在且状态正常（用户可能在令牌有效期内被删除或禁用）
+//  4. 沿用原会话签发新的访问令牌与刷新令牌，仅在会话仍然存在时写回，期间被吊销返回 [ErrSessionRevoked]
+func (s *RefreshTokenService) RefreshToken(req *authDto.RefreshTokenReq) (*authDto.RefreshTokenResp, error) {
+	if req.RefreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	record, err := s.svcCtx.Repository.RefreshToken.Consume(s.ctx, token.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume refresh token: %w", err)
+	}
+	if record == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", record.UserID))
+
+	session, err := s.svcCtx.Repository.Session.Get(s.ctx, record.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session(%s): %w", record.SessionID, err)
+	}
+	if session == nil || session.UserID != record.UserID {
+		// 会话已被吊销或已过期
+		return nil, ErrInvalidRefreshToken
+	}
+
+	userID, err := uuid.Parse(record.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed user id %q", ErrInvalidRefreshToken, record.UserID)
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByID(s.ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get user by id(%s): %w", record.UserID, err)
+	}
+	if err := accountstatus.NewManager(s.svcCtx).Check(s.ctx, existUser); err != nil {
+		return nil, err
+	}
+
+	session.Username = existUser.Username
+	session.TenantID = existUser.TenantID
+	tokenPair, err := issueTokenPair(s.ctx, s.svcCtx, session, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authDto.RefreshTokenResp{TokenPair: *tokenPair}, nil
+}
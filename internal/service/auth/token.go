@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	authDto "hello-gozero/internal/dto/auth"
+	authRepo "hello-gozero/internal/repository/auth"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+	"hello-gozero/internal/utils/token"
+)
+
+// tokenTypeBearer 令牌类型
+const tokenTypeBearer = "Bearer"
+
+// issueTokenPair 为指定会话签发一对访问令牌与刷新令牌
+//
+// 刷新令牌以摘要形式保存到 Redis，并记录到会话中（吊销会话时一并删除）；
+// 会话与刷新令牌的过期时间均与配置的刷新令牌有效期一致；
+// renew 为 true 表示沿用已有的会话（刷新令牌），会话在此期间被吊销时不会被重新写回，返回 [ErrSessionRevoked]
+func issueTokenPair(ctx context.Context, svcCtx *svc.ServiceContext, session *authRepo.Session, renew bool) (*authDto.TokenPair, error) {
+	if session.TenantID == 0 {
+		// 引入多租户之前创建的会话属于默认租户
+		session.TenantID = tenant.DefaultID
+	}
+	accessToken, expiresAt, err := svcCtx.Security.Token.GenerateAccessToken(token.Subject{
+		UserID:    session.UserID,
+		Username:  session.Username,
+		SessionID: session.ID,
+		TenantID:  session.TenantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := token.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenHash := token.HashRefreshToken(refreshToken)
+
+	now := time.Now()
+	refreshTTL := time.Duration(svcCtx.Config.Auth.RefreshExpire) * time.Second
+	record := &authRepo.RefreshTokenRecord{
+		UserID:    session.UserID,
+		Username:  session.Username,
+		SessionID: session.ID,
+		IssuedAt:  now.Unix(),
+	}
+	if err := svcCtx.Repository.RefreshToken.Save(ctx, refreshTokenHash, record, refreshTTL); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	session.RefreshTokenHash = refreshTokenHash
+	session.LastSeenAt = now.Unix()
+	if renew {
+		updated, err := svcCtx.Repository.Session.Update(ctx, session, refreshTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update session: %w", err)
+		}
+		if !updated {
+			// 会话已被吊销，刚签发的刷新令牌随之作废
+			if err := svcCtx.Repository.RefreshToken.Delete(ctx, refreshTokenHash); err != nil {
+				logx.WithContext(ctx).Errorf("failed to delete refresh token of revoked session(%s): %v", session.ID, err)
+			}
+			return nil, ErrSessionRevoked
+		}
+	} else if err := svcCtx.Repository.Session.Save(ctx, session, refreshTTL); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return &authDto.TokenPair{
+		AccessToken:      accessToken,
+		TokenType:        tokenTypeBearer,
+		ExpiresIn:        int64(expiresAt.Sub(now).Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int64(refreshTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,119 @@
+// Package authz 基于角色的访问控制（RBAC）：用户通过角色获得权限，供路由的权限与归属校验中间件以及需要区分角色的业务流程使用
+//
+// 配置 Auth.Admins 中的用户始终拥有管理员角色与全部权限（即使数据库中没有分配），用于初始化与紧急恢复；
+// 配置项绑定租户（见 [Authorizer.IsConfiguredAdmin]），这些用户名不能通过注册、接受邀请或变更用户名获得
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"hello-gozero/internal/constant/rbac"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+)
+
+// Authorizer 角色与权限查询
+type Authorizer struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewAuthorizer 创建角色与权限查询
+func NewAuthorizer(svcCtx *svc.ServiceContext) *Authorizer {
+	return &Authorizer{svcCtx: svcCtx}
+}
+
+// IsConfiguredAdmin 判断上下文中租户的用户是否是配置 Auth.Admins 中的管理员
+// 配置项为 "<租户标识>:<用户名>" 时只在该租户下生效，只有用户名时只在默认租户下生效；上下文中没有租户时不是管理员
+func (a *Authorizer) IsConfiguredAdmin(ctx context.Context, username string) bool {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, admin := range a.svcCtx.Config.Auth.Admins {
+		code, name, found := strings.Cut(admin, ":")
+		if !found {
+			if admin == username && t.ID == tenant.DefaultID {
+				return true
+			}
+			continue
+		}
+		if name == username && t.Code != "" && strings.EqualFold(code, t.Code) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission Implements [middleware.PermissionChecker.HasPermission]
+func (a *Authorizer) HasPermission(ctx context.Context, principal *middleware.Principal, permission string) (bool, error) {
+	if a.IsConfiguredAdmin(ctx, principal.Username) {
+		return true, nil
+	}
+	userID, err := uuid.Parse(principal.UserID)
+	if err != nil {
+		// 令牌中的用户 ID 无效，视为没有权限
+		return false, nil
+	}
+	ok, err := a.svcCtx.Repository.Role.HasPermission(ctx, userID[:], permission)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission(%s) for user(%s): %w", permission, principal.Username, err)
+	}
+	return ok, nil
+}
+
+// ResolveUserID Implements [middleware.OwnerResolver.ResolveUserID]
+func (a *Authorizer) ResolveUserID(ctx context.Context, username string) (string, error) {
+	cached, err := a.svcCtx.Repository.CachedUser.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user by name(%s): %w", username, err)
+	}
+	if cached == nil || cached.User == nil {
+		return "", nil
+	}
+	return cached.User.GetIDAsString(), nil
+}
+
+// Roles 获取用户的角色名称（包括配置的管理员角色），按名称排序
+func (a *Authorizer) Roles(ctx context.Context, userID []byte, username string) ([]string, error) {
+	roles, err := a.svcCtx.Repository.Role.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles for user(%s): %w", username, err)
+	}
+	names := make([]string, 0, len(roles)+1)
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	if a.IsConfiguredAdmin(ctx, username) && !slices.Contains(names, rbac.RoleAdmin) {
+		names = append(names, rbac.RoleAdmin)
+		slices.Sort(names)
+	}
+	return names, nil
+}
+
+// Permissions 获取用户拥有的全部权限编码，按编码排序
+func (a *Authorizer) Permissions(ctx context.Context, userID []byte, username string) ([]string, error) {
+	var (
+		codes []string
+		err   error
+	)
+	if a.IsConfiguredAdmin(ctx, username) {
+		codes, err = a.svcCtx.Repository.Role.ListPermissions(ctx)
+	} else {
+		codes, err = a.svcCtx.Repository.Role.ListUserPermissions(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions for user(%s): %w", username, err)
+	}
+	return codes, nil
+}
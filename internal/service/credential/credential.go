@@ -0,0 +1,199 @@
+// Package credential 密码哈希、校验与密码策略，供注册、登录、修改密码等需要处理密码的流程复用
+//
+// 所有密码都通过 [svc.Security.Password]（HMAC pepper + Argon2id / bcrypt）处理：
+//   - 生成：使用当前 pepper 版本、配置的算法与算法参数
+//   - 校验：兼容迁移前不含 pepper 的 bcrypt 旧哈希、其他算法与旧 pepper 版本，
+//     校验通过后如果哈希的 pepper 版本、算法或算法参数已过时，使用明文密码重新生成并替换，用户无感知
+package credential
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"hello-gozero/internal/constant/rbac"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/service/authz"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+	"hello-gozero/internal/utils/password"
+)
+
+// dummyPassword 用户不存在时参与比对的密码
+const dummyPassword = "dummy-password-for-timing"
+
+// dummy 用户不存在时参与比对的哈希，进程内只生成一次
+var dummy struct {
+	once sync.Once
+	hash string
+}
+
+// Verifier 密码哈希与校验
+type Verifier struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewVerifier 创建密码哈希与校验
+func NewVerifier(svcCtx *svc.ServiceContext) *Verifier {
+	return &Verifier{svcCtx: svcCtx}
+}
+
+// RoleAdmin 管理员角色（配置 Auth.Admins 中的用户或数据库中分配了该角色的用户）
+const RoleAdmin = rbac.RoleAdmin
+
+// PolicySubject 选择新用户适用的密码策略的依据：租户取自上下文中请求所属的租户（见 [tenant.FromContext]）
+// 新用户还没有分配角色，只有配置 Auth.Admins 中的用户按管理员选择策略；已有用户使用 [Verifier.UserPolicySubject]
+func (v *Verifier) PolicySubject(ctx context.Context, username string) password.PolicySubject {
+	subject := tenantSubject(ctx)
+	if authz.NewAuthorizer(v.svcCtx).IsConfiguredAdmin(ctx, username) {
+		subject.Roles = append(subject.Roles, RoleAdmin)
+	}
+	return subject
+}
+
+// UserPolicySubject 选择已有用户适用的密码策略的依据：角色取自 [authz.Authorizer.Roles]，包括数据库中分配的角色与配置的管理员角色
+// 策略按顺序匹配第一个配置了策略的角色，管理员角色排在最前面，其余按名称排序
+func (v *Verifier) UserPolicySubject(ctx context.Context, existUser *userEntity.User) (password.PolicySubject, error) {
+	roles, err := authz.NewAuthorizer(v.svcCtx).Roles(ctx, existUser.ID, existUser.Username)
+	if err != nil {
+		return password.PolicySubject{}, err
+	}
+	if i := slices.Index(roles, RoleAdmin); i > 0 {
+		roles = append([]string{RoleAdmin}, slices.Delete(roles, i, i+1)...)
+	}
+	subject := tenantSubject(ctx)
+	subject.Roles = roles
+	return subject, nil
+}
+
+// tenantSubject 只包含请求所属租户的密码策略选择依据
+func tenantSubject(ctx context.Context) password.PolicySubject {
+	var subject password.PolicySubject
+	if t, ok := tenant.FromContext(ctx); ok {
+		subject.Tenant = t.Code
+	}
+	return subject
+}
+
+// CheckPolicy 校验新密码是否符合用户适用的密码策略，不符合时返回 [*password.PolicyError]
+// 用户名与 userInputs（邮箱、昵称等个人信息）参与强度评估
+func (v *Verifier) CheckPolicy(ctx context.Context, username, newPassword string, userInputs ...string) error {
+	inputs := append([]string{username}, userInputs...)
+	return v.svcCtx.Security.PasswordPolicy.Checker(v.PolicySubject(ctx, username), inputs...).Check(newPassword)
+}
+
+// CheckPolicyForUser 校验已有用户的新密码是否符合适用的密码策略，不符合时返回 [*password.PolicyError]
+// 策略配置了 HistorySize 时，新密码还不能与当前密码及最近 HistorySize 次使用过的密码相同
+func (v *Verifier) CheckPolicyForUser(ctx context.Context, existUser *userEntity.User, newPassword string) error {
+	subject, err := v.UserPolicySubject(ctx, existUser)
+	if err != nil {
+		return err
+	}
+	policies := v.svcCtx.Security.PasswordPolicy
+	rules := policies.Rules(subject, existUser.Username, existUser.Email, existUser.Nickname, existUser.PhoneNumber)
+
+	if size := policies.Resolve(subject).HistorySize; size > 0 {
+		previous, err := v.svcCtx.Repository.PasswordHistory.ListRecentHashes(ctx, existUser.ID, size)
+		if err != nil {
+			return fmt.Errorf("failed to list password history for user(%s): %w", existUser.Username, err)
+		}
+		// 升级前创建的用户没有历史记录，当前密码始终参与比对
+		if !slices.Contains(previous, existUser.Password) {
+			previous = append([]string{existUser.Password}, previous...)
+		}
+		rules = append(rules, password.NewHistoryRule(v.svcCtx.Security.Password, size, previous...))
+	}
+	return password.NewPasswordChecker(rules...).Check(newPassword)
+}
+
+// RecordPasswordChange 记录用户新设置的密码哈希，供 [Verifier.CheckPolicyForUser] 禁止重复使用
+// 策略未配置 HistorySize 时不记录；密码已经更新成功，记录失败只记录日志
+func (v *Verifier) RecordPasswordChange(ctx context.Context, existUser *userEntity.User) {
+	subject, err := v.UserPolicySubject(ctx, existUser)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("failed to record password history for user(%s): %v", existUser.Username, err)
+		return
+	}
+	size := v.svcCtx.Security.PasswordPolicy.Resolve(subject).HistorySize
+	if size <= 0 {
+		return
+	}
+	if err := v.svcCtx.Repository.PasswordHistory.Add(ctx, existUser.ID, existUser.Password, size); err != nil {
+		logx.WithContext(ctx).Errorf("failed to record password history for user(%s): %v", existUser.Username, err)
+	}
+}
+
+// PasswordChangeRequired 判断用户的密码是否已超过适用策略的最长使用期限（MaxAge），需要修改密码
+// 查询用户角色失败时只记录日志，按不需要修改处理，不影响登录
+func (v *Verifier) PasswordChangeRequired(ctx context.Context, existUser *userEntity.User) bool {
+	subject, err := v.UserPolicySubject(ctx, existUser)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("failed to resolve password policy for user(%s): %v", existUser.Username, err)
+		return false
+	}
+	policy := v.svcCtx.Security.PasswordPolicy.Resolve(subject)
+	return policy.Expired(existUser.GetPasswordChangedAt(), time.Now())
+}
+
+// Hash 生成密码哈希，用于持久化存储
+func (v *Verifier) Hash(password string) (string, error) {
+	hash, err := v.svcCtx.Security.Password.GenerateHash(password)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return hash, nil
+}
+
+// Verify 校验用户的密码，校验通过且哈希已过时时重新生成哈希
+// existUser 为 nil（用户不存在）时与虚拟哈希比对后返回 false，保证「用户不存在」与「密码错误」两种情况的耗时一致，
+// 避免通过响应时间枚举用户名；重新生成哈希失败只记录日志，不影响校验结果
+func (v *Verifier) Verify(ctx context.Context, existUser *userEntity.User, password string) bool {
+	tool := v.svcCtx.Security.Password
+
+	if existUser == nil {
+		_ = tool.VerifyHash(password, v.getDummyHash())
+		return false
+	}
+
+	if !tool.VerifyHash(password, existUser.Password) {
+		return false
+	}
+	if tool.NeedsRehash(existUser.Password) {
+		v.rehash(ctx, existUser, password)
+	}
+	return true
+}
+
+// rehash 使用当前 pepper 版本与算法重新生成哈希并替换
+func (v *Verifier) rehash(ctx context.Context, existUser *userEntity.User, password string) {
+	logger := logx.WithContext(ctx)
+
+	newHash, err := v.svcCtx.Security.Password.GenerateHash(password)
+	if err != nil {
+		logger.Errorf("failed to rehash password for user(%s): %v", existUser.Username, err)
+		return
+	}
+	replaced, err := v.svcCtx.Repository.User.UpdatePasswordHash(ctx, existUser.ID, existUser.Password, newHash)
+	if err != nil {
+		logger.Errorf("failed to save rehashed password for user(%s): %v", existUser.Username, err)
+		return
+	}
+	if !replaced {
+		// 密码已被并发修改
+		return
+	}
+	existUser.Password = newHash
+	logger.Infof("password hash upgraded for user(%s)", existUser.Username)
+}
+
+// getDummyHash 惰性生成虚拟哈希，使用与新哈希相同的 pepper 与算法
+func (v *Verifier) getDummyHash() string {
+	dummy.once.Do(func() {
+		dummy.hash, _ = v.svcCtx.Security.Password.GenerateHash(dummyPassword)
+	})
+	return dummy.hash
+}
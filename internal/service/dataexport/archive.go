@@ -0,0 +1,257 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/service/accountstatus"
+)
+
+// archiveFormatVersion 归档格式版本，归档内容结构变化时递增
+const archiveFormatVersion = 1
+
+// 归档中的文件
+const (
+	fileManifest = "manifest.json"
+	fileProfile  = "profile.json"
+	fileSecurity = "security.json"
+	fileSessions = "sessions.json"
+	fileAudit    = "audit.json"
+	fileEvents   = "events.json"
+)
+
+// manifest 归档说明
+type manifest struct {
+	FormatVersion int       `json:"format_version"`
+	ExportID      string    `json:"export_id"`
+	UserID        string    `json:"user_id"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	Files         []string  `json:"files"`
+}
+
+// profile 用户资料，不包含密码哈希
+type profile struct {
+	ID                string     `json:"id"`
+	Username          string     `json:"username"`
+	Email             string     `json:"email,omitempty"`
+	EmailVerifiedAt   *time.Time `json:"email_verified_at,omitempty"`
+	PhoneCountryCode  string     `json:"phone_country_code,omitempty"`
+	PhoneNumber       string     `json:"phone_number,omitempty"`
+	Nickname          string     `json:"nickname,omitempty"`
+	Status            string     `json:"status"`
+	SuspendedUntil    *time.Time `json:"suspended_until,omitempty"`
+	LastLoginTime     *time.Time `json:"last_login_time,omitempty"`
+	PasswordChangedAt time.Time  `json:"password_changed_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// security 安全设置，不包含 TOTP 密钥与恢复码
+type security struct {
+	MFAEnabled          bool       `json:"mfa_enabled"`
+	MFAConfirmedAt      *time.Time `json:"mfa_confirmed_at,omitempty"`
+	UnusedRecoveryCodes int64      `json:"unused_recovery_codes"`
+}
+
+// session 登录会话，不包含刷新令牌摘要
+type session struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device,omitempty"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// auditEntry 账户状态与用户名变更记录
+type auditEntry struct {
+	Action         string     `json:"action"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	Actor          string     `json:"actor"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// accountEvent 账户事件时间线中的一条记录
+type accountEvent struct {
+	Type string    `json:"type"`
+	At   time.Time `json:"at"`
+}
+
+// buildArchive 汇总用户的个人数据并打包为 ZIP
+func (e *Exporter) buildArchive(ctx context.Context, export *userEntity.DataExport, existUser *userEntity.User) (*bytes.Buffer, error) {
+	userID := existUser.GetIDAsString()
+
+	sec := security{}
+	mfa, err := e.svcCtx.Repository.MFA.Get(ctx, existUser.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if mfa != nil && mfa.Enabled {
+		sec.MFAEnabled = true
+		sec.MFAConfirmedAt = mfa.ConfirmedAt
+		if sec.UnusedRecoveryCodes, err = e.svcCtx.Repository.MFA.CountUnusedRecoveryCodes(ctx, existUser.ID); err != nil {
+			return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+		}
+	}
+
+	storedSessions, err := e.svcCtx.Repository.Session.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	sessions := make([]session, 0, len(storedSessions))
+	for _, s := range storedSessions {
+		sessions = append(sessions, session{
+			ID:         s.ID,
+			Device:     s.Device,
+			IP:         s.IP,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  time.Unix(s.CreatedAt, 0).UTC(),
+			LastSeenAt: time.Unix(s.LastSeenAt, 0).UTC(),
+		})
+	}
+
+	history, err := e.svcCtx.Repository.User.ListStatusHistory(ctx, existUser.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status history: %w", err)
+	}
+	audit := make([]auditEntry, 0, len(history))
+	for _, h := range history {
+		audit = append(audit, auditEntry{
+			Action:         "status_changed",
+			From:           accountstatus.Name(h.FromStatus),
+			To:             accountstatus.Name(h.ToStatus),
+			SuspendedUntil: h.SuspendedUntil,
+			Reason:         h.Reason,
+			Actor:          h.Actor,
+			CreatedAt:      h.CreatedAt,
+		})
+	}
+	renames, err := e.svcCtx.Repository.User.ListUsernameHistory(ctx, existUser.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list username history: %w", err)
+	}
+	for _, h := range renames {
+		audit = append(audit, auditEntry{
+			Action:    "username_changed",
+			From:      h.OldUsername,
+			To:        h.NewUsername,
+			Actor:     h.Actor,
+			CreatedAt: h.CreatedAt,
+		})
+	}
+	slices.SortStableFunc(audit, func(a, b auditEntry) int {
+		return cmp.Compare(a.CreatedAt.UnixNano(), b.CreatedAt.UnixNano())
+	})
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{fileProfile, toProfile(existUser)},
+		{fileSecurity, sec},
+		{fileSessions, sessions},
+		{fileAudit, audit},
+		{fileEvents, accountEvents(existUser, sec, audit)},
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.name)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	err = writeJSON(zw, fileManifest, manifest{
+		FormatVersion: archiveFormatVersion,
+		ExportID:      export.GetIDAsString(),
+		UserID:        userID,
+		GeneratedAt:   time.Now().UTC(),
+		Files:         names,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if err := writeJSON(zw, f.name, f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return &buf, nil
+}
+
+// toProfile 转换为归档中的用户资料
+func toProfile(user *userEntity.User) profile {
+	return profile{
+		ID:                user.GetIDAsString(),
+		Username:          user.Username,
+		Email:             user.Email,
+		EmailVerifiedAt:   user.EmailVerifiedAt,
+		PhoneCountryCode:  user.PhoneCountryCode,
+		PhoneNumber:       user.PhoneNumber,
+		Nickname:          user.Nickname,
+		Status:            accountstatus.Name(user.Status),
+		SuspendedUntil:    user.SuspendedUntil,
+		LastLoginTime:     user.LastLoginTime,
+		PasswordChangedAt: user.GetPasswordChangedAt(),
+		CreatedAt:         user.CreatedAt,
+		UpdatedAt:         user.UpdatedAt,
+	}
+}
+
+// accountEvents 根据已保存的数据整理账户事件时间线，按时间正序
+// 发布到 Kafka 的用户事件由外部系统消费，本服务不保存，这里只包含能从本服务数据中还原的事件
+func accountEvents(user *userEntity.User, sec security, audit []auditEntry) []accountEvent {
+	events := []accountEvent{{Type: "registered", At: user.CreatedAt}}
+	if user.EmailVerifiedAt != nil {
+		events = append(events, accountEvent{Type: "email_verified", At: *user.EmailVerifiedAt})
+	}
+	if user.PasswordChangedAt != nil && user.PasswordChangedAt.After(user.CreatedAt) {
+		events = append(events, accountEvent{Type: "password_changed", At: *user.PasswordChangedAt})
+	}
+	if sec.MFAConfirmedAt != nil {
+		events = append(events, accountEvent{Type: "mfa_enabled", At: *sec.MFAConfirmedAt})
+	}
+	if user.LastLoginTime != nil {
+		events = append(events, accountEvent{Type: "last_login", At: *user.LastLoginTime})
+	}
+	for _, entry := range audit {
+		if entry.Action == "username_changed" {
+			events = append(events, accountEvent{Type: entry.Action, At: entry.CreatedAt})
+			continue
+		}
+		events = append(events, accountEvent{Type: "status_changed_to_" + entry.To, At: entry.CreatedAt})
+	}
+	slices.SortStableFunc(events, func(a, b accountEvent) int {
+		return cmp.Compare(a.At.UnixNano(), b.At.UnixNano())
+	})
+	return events
+}
+
+// writeJSON 将数据以缩进 JSON 写入归档中的文件
+func writeJSON(zw *zip.Writer, name string, data any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s in archive: %w", name, err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to write %s in archive: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// Package dataexport 个人数据导出：异步生成归档、限时签名下载地址与过期归档清理
+//
+// 导出任务保存在数据库中（见 [userEntity.DataExport]），由后台任务（见 worker/export_job）领取处理：
+// 处理中的任务超过 Export.JobTimeout 未完成时视为处理它的实例已退出，会被其他实例重新领取，因此实例重启不会丢失任务
+package dataexport
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+)
+
+var (
+	// ErrInvalidDownloadLink 下载地址无效（签名错误）或已过期
+	ErrInvalidDownloadLink = errors.New("invalid or expired download link")
+)
+
+// cleanupBatchSize 每次清理的过期归档数量
+const cleanupBatchSize = 100
+
+// Exporter 个人数据导出
+type Exporter struct {
+	svcCtx     *svc.ServiceContext
+	signingKey []byte
+}
+
+// NewExporter 创建个人数据导出
+func NewExporter(svcCtx *svc.ServiceContext) *Exporter {
+	key := svcCtx.Config.Export.SigningKey
+	if key == "" {
+		key = svcCtx.Config.Auth.AccessSecret
+	}
+	return &Exporter{
+		svcCtx:     svcCtx,
+		signingKey: []byte(key),
+	}
+}
+
+// DownloadURL 生成导出归档的限时下载地址
+func (e *Exporter) DownloadURL(export *userEntity.DataExport) (string, time.Time) {
+	expiresAt := time.Now().Add(time.Duration(e.svcCtx.Config.Export.URLExpire) * time.Second)
+	// 下载地址不能晚于归档过期时间
+	if export.ExpiresAt != nil && export.ExpiresAt.Before(expiresAt) {
+		expiresAt = *export.ExpiresAt
+	}
+
+	id := export.GetIDAsString()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", e.sign(id, expiresAt.Unix()))
+	return fmt.Sprintf("%s/api/v1/exports/%s/download?%s", e.svcCtx.Config.Export.BaseURL, id, query.Encode()), expiresAt
+}
+
+// VerifyDownload 校验下载地址的签名与有效期
+func (e *Exporter) VerifyDownload(id string, expires int64, signature string) error {
+	if time.Now().Unix() > expires {
+		return ErrInvalidDownloadLink
+	}
+	if !hmac.Equal([]byte(e.sign(id, expires)), []byte(signature)) {
+		return ErrInvalidDownloadLink
+	}
+	return nil
+}
+
+// sign 计算下载地址签名：HMAC-SHA256(任务 ID + 过期时间)
+func (e *Exporter) sign(id string, expires int64) string {
+	mac := hmac.New(sha256.New, e.signingKey)
+	mac.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ProcessNext 领取并处理一个待处理的导出任务，没有待处理的任务时返回 false
+// 处理失败时重新等待处理，尝试次数用尽后标记为失败
+func (e *Exporter) ProcessNext(ctx context.Context) (bool, error) {
+	conf := e.svcCtx.Config.Export
+	staleBefore := time.Now().Add(-time.Duration(conf.JobTimeout) * time.Second)
+	export, err := e.svcCtx.Repository.DataExport.ClaimNext(ctx, staleBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim data export: %w", err)
+	}
+	if export == nil {
+		return false, nil
+	}
+
+	ctx = logx.ContextWithFields(ctx, logx.Field("export_id", export.GetIDAsString()))
+	logger := logx.WithContext(ctx)
+
+	if export.Attempts > conf.MaxAttempts {
+		// 超时被重新领取时尝试次数可能已经用尽
+		if err := e.svcCtx.Repository.DataExport.Fail(ctx, export.ID, "too many attempts", true); err != nil {
+			return true, fmt.Errorf("failed to mark data export as failed: %w", err)
+		}
+		return true, nil
+	}
+
+	if err := e.process(ctx, export); err != nil {
+		final := export.Attempts >= conf.MaxAttempts || errors.Is(err, gorm.ErrRecordNotFound)
+		logger.Errorf("data export attempt %d failed (final: %t): %v", export.Attempts, final, err)
+		if err := e.svcCtx.Repository.DataExport.Fail(ctx, export.ID, err.Error(), final); err != nil {
+			return true, fmt.Errorf("failed to record data export failure: %w", err)
+		}
+		return true, nil
+	}
+	return true, nil
+}
+
+// process 生成归档、写入对象存储并标记任务完成
+func (e *Exporter) process(ctx context.Context, export *userEntity.DataExport) error {
+	userID, err := uuid.FromBytes(export.UserID)
+	if err != nil {
+		return fmt.Errorf("malformed user id: %w", err)
+	}
+	// 后台任务没有请求租户，用户 ID 全局唯一，按 ID 跨租户获取用户即可
+	ctx = tenant.WithAllTenants(ctx)
+	existUser, err := e.svcCtx.Repository.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user(%s): %w", userID, err)
+	}
+
+	archive, err := e.buildArchive(ctx, export, existUser)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.zip", userID, export.GetIDAsString())
+	if err := e.svcCtx.BlobStore.Put(ctx, key, archive); err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(e.svcCtx.Config.Export.Retention) * time.Second)
+	if err := e.svcCtx.Repository.DataExport.Complete(ctx, export.ID, key, int64(archive.Len()), now, expiresAt); err != nil {
+		return fmt.Errorf("failed to complete data export: %w", err)
+	}
+	logx.WithContext(ctx).Infof("data export for user(%s) completed, %d bytes", existUser.Username, archive.Len())
+
+	err = e.svcCtx.Publisher.Publish(ctx, &event.UserEvent{
+		EventType: event.TypeUserDataExported,
+		UserID:    existUser.GetIDAsString(),
+		Data: map[string]interface{}{
+			"username":  existUser.Username,
+			"export_id": export.GetIDAsString(),
+		},
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorf("failed to publish %s event: %v", event.TypeUserDataExported, err)
+	}
+	return nil
+}
+
+// CleanupExpired 删除过期的导出归档并将任务标记为已过期
+func (e *Exporter) CleanupExpired(ctx context.Context) error {
+	for {
+		exports, err := e.svcCtx.Repository.DataExport.ListExpired(ctx, time.Now(), cleanupBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list expired data exports: %w", err)
+		}
+		for _, export := range exports {
+			if export.BlobKey != "" {
+				if err := e.svcCtx.BlobStore.Delete(ctx, export.BlobKey); err != nil {
+					return err
+				}
+			}
+			if err := e.svcCtx.Repository.DataExport.MarkExpired(ctx, export.ID); err != nil {
+				return fmt.Errorf("failed to mark data export(%s) as expired: %w", export.GetIDAsString(), err)
+			}
+		}
+		if len(exports) < cleanupBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Package erasure 个人数据擦除（被遗忘权）：匿名化用户记录，并清理缓存、会话、关联数据、导出归档与验证码等各存储中的个人信息
+//
+// 擦除涉及数据库、Redis、对象存储与 Kafka，无法在一个事务中完成，因此拆分为多个步骤依次执行：
+// 每个步骤都可以重复执行，完成后在擦除记录（见 [userEntity.UserErasure]）中保存进度；
+// 中途失败或实例退出时，由后台任务（见 worker/erasure_job）从未完成的步骤继续，直到全部完成
+//
+// 用户事件消费者不保存消息去重键（幂等由业务逻辑保证，见 worker/user_event），因此没有去重数据需要擦除；
+// 以后引入去重存储且键中包含用户标识时，需要增加对应的擦除步骤
+package erasure
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	authRepo "hello-gozero/internal/repository/auth"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+)
+
+// placeholderPrefix 匿名化后的用户名前缀，注册时用户名不允许包含 "-"，不会与正常用户冲突
+const placeholderPrefix = "erased-"
+
+// subjects 擦除前的用户标识，匿名化之后仍需要用它们定位缓存与 Redis 中的数据
+type subjects struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+
+	// 用户所属租户，后台任务没有请求租户，按它限定用户仓库的操作；引入多租户之前创建的记录没有该字段，属于默认租户
+	TenantID uint64 `json:"tenant_id,omitempty"`
+}
+
+// step 擦除步骤，必须可以重复执行
+type step struct {
+	name string
+	run  func(ctx context.Context, erasure *userEntity.UserErasure, subj subjects) error
+}
+
+// Eraser 个人数据擦除
+type Eraser struct {
+	svcCtx *svc.ServiceContext
+	steps  []step
+}
+
+// NewEraser 创建个人数据擦除
+func NewEraser(svcCtx *svc.ServiceContext) *Eraser {
+	e := &Eraser{svcCtx: svcCtx}
+	// 先匿名化用户记录，使用户立即无法登录、无法被查询到，再清理其他存储；事件最后发布，保证消费方收到时本服务已清理完毕
+	e.steps = []step{
+		{name: "anonymize", run: e.anonymize},
+		{name: "revoke_sessions", run: e.revokeSessions},
+		{name: "erase_related", run: e.eraseRelated},
+		{name: "delete_exports", run: e.deleteExports},
+		{name: "clear_cache", run: e.clearCache},
+		{name: "publish_event", run: e.publishEvent},
+	}
+	return e
+}
+
+// TotalSteps 擦除的总步骤数
+func (e *Eraser) TotalSteps() int {
+	return len(e.steps)
+}
+
+// Placeholder 用户匿名化后的用户名
+func Placeholder(userID []byte) string {
+	return placeholderPrefix + hex.EncodeToString(userID)
+}
+
+// Request 为用户创建擦除记录并立即处理，actor 为操作人用户名
+//
+// 用户已有擦除记录时不会重复创建：已完成或正在被其他实例处理时直接返回，否则继续处理未完成的步骤；
+// 处理失败只记录日志，记录重新等待处理并由后台任务重试，返回的记录状态反映处理结果
+func (e *Eraser) Request(ctx context.Context, user *userEntity.User, actor string) (*userEntity.UserErasure, error) {
+	subj, err := json.Marshal(subjects{Username: user.Username, Email: user.Email, TenantID: user.TenantID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal erasure subjects: %w", err)
+	}
+
+	erasure, created, err := e.svcCtx.Repository.Erasure.CreateOrGet(ctx, &userEntity.UserErasure{
+		UserID:   user.ID,
+		Subjects: string(subj),
+		Actor:    actor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create erasure: %w", err)
+	}
+	if !created {
+		if erasure.Status == userConstant.ErasureStatusCompleted {
+			return erasure, nil
+		}
+		claimed, err := e.svcCtx.Repository.Erasure.Claim(ctx, erasure.UserID, e.staleBefore())
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim erasure: %w", err)
+		}
+		if claimed == nil {
+			// 正在被其他实例处理
+			return erasure, nil
+		}
+		erasure = claimed
+	}
+
+	// 擦除一旦开始就应尽量完成，不随请求取消而中断
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.jobTimeout())
+	defer cancel()
+	e.process(ctx, erasure)
+	return erasure, nil
+}
+
+// ProcessNext 领取并处理一个未完成的擦除，没有未完成的擦除时返回 false
+func (e *Eraser) ProcessNext(ctx context.Context) (bool, error) {
+	erasure, err := e.svcCtx.Repository.Erasure.ClaimNext(ctx, e.staleBefore())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim erasure: %w", err)
+	}
+	if erasure == nil {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.jobTimeout())
+	defer cancel()
+	e.process(ctx, erasure)
+	return true, nil
+}
+
+// process 从未完成的步骤开始依次执行，失败时记录原因并重新等待处理；erasure 会被更新为处理后的状态
+func (e *Eraser) process(ctx context.Context, erasure *userEntity.UserErasure) {
+	if userID, err := uuidString(erasure.UserID); err == nil {
+		ctx = logx.ContextWithFields(ctx, logx.Field("user_id", userID))
+	}
+	logger := logx.WithContext(ctx)
+
+	if err := e.run(ctx, erasure); err != nil {
+		logger.Errorf("erasure attempt %d failed at step %d: %v", erasure.Attempts, erasure.Step+1, err)
+		// 至少等待一个检查间隔再重试，避免后台任务连续重试同一个失败的擦除
+		retryAt := time.Now().Add(time.Duration(e.svcCtx.Config.Erasure.PollInterval) * time.Second)
+		if err := e.svcCtx.Repository.Erasure.Fail(ctx, erasure.UserID, err.Error(), retryAt); err != nil {
+			// 记录失败原因失败时，擦除会在超时后被重新领取
+			logger.Errorf("failed to record erasure failure: %v", err)
+			return
+		}
+		erasure.Status = userConstant.ErasureStatusPending
+		erasure.Error = err.Error()
+		erasure.NextAttemptAt = &retryAt
+		return
+	}
+	logger.Infof("erasure completed after %d attempt(s)", erasure.Attempts)
+}
+
+// run 执行未完成的步骤并标记完成
+func (e *Eraser) run(ctx context.Context, erasure *userEntity.UserErasure) error {
+	var subj subjects
+	if erasure.Subjects != "" {
+		if err := json.Unmarshal([]byte(erasure.Subjects), &subj); err != nil {
+			return fmt.Errorf("malformed erasure subjects: %w", err)
+		}
+	}
+	if subj.TenantID == 0 {
+		subj.TenantID = tenant.DefaultID
+	}
+	ctx = tenant.NewContext(ctx, tenant.Tenant{ID: subj.TenantID})
+
+	for i := erasure.Step; i < len(e.steps); i++ {
+		if err := e.steps[i].run(ctx, erasure, subj); err != nil {
+			return fmt.Errorf("step %s: %w", e.steps[i].name, err)
+		}
+		if err := e.svcCtx.Repository.Erasure.SaveStep(ctx, erasure.UserID, i+1); err != nil {
+			return fmt.Errorf("failed to save erasure progress: %w", err)
+		}
+		erasure.Step = i + 1
+	}
+
+	now := time.Now()
+	if err := e.svcCtx.Repository.Erasure.Complete(ctx, erasure.UserID, now); err != nil {
+		return fmt.Errorf("failed to complete erasure: %w", err)
+	}
+	erasure.Status = userConstant.ErasureStatusCompleted
+	erasure.Subjects = ""
+	erasure.Error = ""
+	erasure.CompletedAt = &now
+	return nil
+}
+
+// anonymize 匿名化用户记录，同时禁用并软删除
+func (e *Eraser) anonymize(ctx context.Context, erasure *userEntity.UserErasure, _ subjects) error {
+	return e.svcCtx.Repository.User.Anonymize(ctx, erasure.UserID, Placeholder(erasure.UserID))
+}
+
+// revokeSessions 吊销全部登录会话及其刷新令牌
+func (e *Eraser) revokeSessions(ctx context.Context, erasure *userEntity.UserErasure, _ subjects) error {
+	userID, err := uuidString(erasure.UserID)
+	if err != nil {
+		return err
+	}
+	_, err = e.svcCtx.Repository.Session.DeleteAllByUser(ctx, userID)
+	return err
+}
+
+// eraseRelated 删除密码历史、两步验证数据、用户名变更历史与相关邀请，
+// 并将用户以当前或变更前的用户名作为操作人的状态变更、用户名变更、角色分配与邀请记录替换为匿名用户名
+func (e *Eraser) eraseRelated(ctx context.Context, erasure *userEntity.UserErasure, subj subjects) error {
+	return e.svcCtx.Repository.User.EraseRelated(ctx, erasure.UserID, subj.Username, subj.Email, Placeholder(erasure.UserID))
+}
+
+// deleteExports 删除个人数据导出归档与导出任务
+func (e *Eraser) deleteExports(ctx context.Context, erasure *userEntity.UserErasure, _ subjects) error {
+	exports, err := e.svcCtx.Repository.DataExport.ListByUser(ctx, erasure.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to list data exports: %w", err)
+	}
+	for _, export := range exports {
+		if export.BlobKey != "" {
+			if err := e.svcCtx.BlobStore.Delete(ctx, export.BlobKey); err != nil {
+				return err
+			}
+		}
+		if err := e.svcCtx.Repository.DataExport.Delete(ctx, export.ID); err != nil {
+			return fmt.Errorf("failed to delete data export(%s): %w", export.GetIDAsString(), err)
+		}
+	}
+	return nil
+}
+
+// clearCache 删除用户资料缓存，以及以用户 ID、用户名、邮箱为键的验证码、限流计数与失败锁定数据
+func (e *Eraser) clearCache(ctx context.Context, erasure *userEntity.UserErasure, subj subjects) error {
+	userID, err := uuidString(erasure.UserID)
+	if err != nil {
+		return err
+	}
+	// 邮箱验证以用户 ID 为接收方（附加数据中保存待验证的邮箱）
+	if err := e.svcCtx.Repository.VerifyCode.Purge(ctx, authRepo.PurposeEmailVerify, userID); err != nil {
+		return fmt.Errorf("failed to purge email verification codes: %w", err)
+	}
+	if subj.Email != "" {
+		if err := e.svcCtx.Repository.VerifyCode.Purge(ctx, authRepo.PurposePasswordReset, subj.Email); err != nil {
+			return fmt.Errorf("failed to purge password reset codes: %w", err)
+		}
+	}
+	if subj.Username != "" {
+		if err := e.svcCtx.Repository.CachedUser.DeleteByUsername(ctx, subj.Username); err != nil {
+			return fmt.Errorf("failed to delete user cache: %w", err)
+		}
+		if err := e.svcCtx.Repository.Lockout.Clear(ctx, authRepo.LockoutSubjectUser, subj.Username); err != nil {
+			return fmt.Errorf("failed to clear lockout: %w", err)
+		}
+	}
+	return nil
+}
+
+// publishEvent 发布擦除事件，通知下游删除各自保存的数据；发布失败时返回错误，由后台任务重试
+// 重试可能导致事件重复发布，消费方需要保证幂等
+func (e *Eraser) publishEvent(ctx context.Context, erasure *userEntity.UserErasure, _ subjects) error {
+	userID, err := uuidString(erasure.UserID)
+	if err != nil {
+		return err
+	}
+	return e.svcCtx.Publisher.Publish(ctx, &event.UserEvent{
+		EventType: event.TypeUserErased,
+		UserID:    userID,
+		Data: map[string]interface{}{
+			"erased_at": time.Now().Unix(),
+		},
+	})
+}
+
+// staleBefore 开始处理时间早于该时间仍未完成的擦除视为处理它的实例已退出
+func (e *Eraser) staleBefore() time.Time {
+	return time.Now().Add(-e.jobTimeout())
+}
+
+func (e *Eraser) jobTimeout() time.Duration {
+	return time.Duration(e.svcCtx.Config.Erasure.JobTimeout) * time.Second
+}
+
+// uuidString 将二进制用户 ID 转换为 UUID 字符串
+func uuidString(id []byte) (string, error) {
+	userID, err := uuid.FromBytes(id)
+	if err != nil {
+		return "", fmt.Errorf("malformed user id: %w", err)
+	}
+	return userID.String(), nil
+}
@@ -0,0 +1,196 @@
+// Package lockout 认证失败锁定（暴力破解防护），供登录、修改密码等需要校验密码的流程复用
+//
+// 按用户名（在请求所属的租户内）与客户端 IP 两个维度分别计数（见 [authRepo.LockoutRepository]）：
+//   - 时间窗口内失败次数达到上限后暂时锁定，锁定期间直接拒绝，不再校验密码
+//   - 锁定时长随连续锁定次数指数增长，直到配置的上限
+//   - 同一用户名连续被锁定达到配置的次数后，账户状态置为 [userConstant.StatusLocked]，需要管理员解锁
+//
+// 锁定与解锁都会发布用户事件（见 [event.Publisher]），供安全审计等外部系统处理
+package lockout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userConstant "hello-gozero/internal/constant/user"
+	"hello-gozero/internal/event"
+	authRepo "hello-gozero/internal/repository/auth"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/svc"
+)
+
+// ErrLocked 认证失败次数过多，暂时锁定
+var ErrLocked = errors.New("too many failed attempts, try again later")
+
+// LockedError 暂时锁定错误，携带剩余锁定时长，errors.Is(err, ErrLocked) 为 true
+type LockedError struct {
+	// 剩余锁定时长
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", ErrLocked.Error(), e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockedError) Is(target error) bool {
+	return target == ErrLocked
+}
+
+// RetryAfterSeconds 剩余锁定时长（秒，向上取整），用于 Retry-After 响应头
+func (e *LockedError) RetryAfterSeconds() int64 {
+	return int64(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// Guard 认证失败锁定
+type Guard struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewGuard 创建认证失败锁定
+func NewGuard(svcCtx *svc.ServiceContext) *Guard {
+	return &Guard{svcCtx: svcCtx}
+}
+
+// Check 检查用户名与客户端 IP 是否处于锁定状态，锁定时返回 [*LockedError]
+// 应在查询用户与校验密码之前调用；ip 为空时只检查用户名
+func (g *Guard) Check(ctx context.Context, username, ip string) error {
+	repo := g.svcCtx.Repository.Lockout
+
+	lockedFor, err := repo.LockedFor(ctx, authRepo.LockoutSubjectUser, username)
+	if err != nil {
+		return fmt.Errorf("failed to check user lockout: %w", err)
+	}
+	if ip != "" {
+		ipLockedFor, err := repo.LockedFor(ctx, authRepo.LockoutSubjectIP, ip)
+		if err != nil {
+			return fmt.Errorf("failed to check ip lockout: %w", err)
+		}
+		lockedFor = max(lockedFor, ipLockedFor)
+	}
+	if lockedFor > 0 {
+		return &LockedError{RetryAfter: lockedFor}
+	}
+	return nil
+}
+
+// RecordFailure 记录一次认证失败
+// 用户名不存在时同样计数，避免通过锁定行为的差异枚举用户名；计数失败只记录日志，不影响本次请求的结果
+func (g *Guard) RecordFailure(ctx context.Context, username, ip string) {
+	logger := logx.WithContext(ctx)
+	conf := g.svcCtx.Config.Auth.Lockout
+
+	result, err := g.svcCtx.Repository.Lockout.RecordFailure(ctx, authRepo.LockoutSubjectUser, username, g.policy(conf.UserMaxFailures))
+	if err != nil {
+		logger.Errorf("failed to record user(%s) auth failure: %v", username, err)
+	} else if result.LockedFor > 0 {
+		logger.Infof("user(%s) locked for %s after %d failures (level %d)", username, result.LockedFor, result.Failures, result.Level)
+		g.publishLockout(ctx, authRepo.LockoutSubjectUser, username, ip, result)
+		if conf.LockAccountAfter > 0 && result.Level >= conf.LockAccountAfter {
+			g.lockAccount(ctx, username, ip, result.Level)
+		}
+	}
+
+	if ip == "" {
+		return
+	}
+	result, err = g.svcCtx.Repository.Lockout.RecordFailure(ctx, authRepo.LockoutSubjectIP, ip, g.policy(conf.IPMaxFailures))
+	if err != nil {
+		logger.Errorf("failed to record ip(%s) auth failure: %v", ip, err)
+	} else if result.LockedFor > 0 {
+		logger.Infof("ip(%s) locked for %s after %d failures (level %d)", ip, result.LockedFor, result.Failures, result.Level)
+		g.publishLockout(ctx, authRepo.LockoutSubjectIP, ip, ip, result)
+	}
+}
+
+// RecordSuccess 认证成功后清零用户名的失败计数
+// IP 的失败计数不清零，避免攻击者用自己的账户登录成功来重置撞库计数
+func (g *Guard) RecordSuccess(ctx context.Context, username string) {
+	if err := g.svcCtx.Repository.Lockout.ResetFailures(ctx, authRepo.LockoutSubjectUser, username); err != nil {
+		logx.WithContext(ctx).Errorf("failed to reset user(%s) auth failures: %v", username, err)
+	}
+}
+
+// Unlock 清除用户名的失败计数、锁定等级与暂时锁定
+func (g *Guard) Unlock(ctx context.Context, username string) error {
+	if err := g.svcCtx.Repository.Lockout.Clear(ctx, authRepo.LockoutSubjectUser, username); err != nil {
+		return fmt.Errorf("failed to clear user(%s) lockout: %w", username, err)
+	}
+	return nil
+}
+
+// lockAccount 将账户状态置为锁定
+func (g *Guard) lockAccount(ctx context.Context, username, ip string, level int64) {
+	logger := logx.WithContext(ctx)
+
+	existUser, err := g.svcCtx.Repository.User.GetByUsername(ctx, username)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Errorf("failed to get user(%s) for account lock: %v", username, err)
+		}
+		return
+	}
+	// 已禁用或已锁定的账户不需要处理
+	if existUser.Status != userConstant.StatusActive {
+		return
+	}
+
+	err = accountstatus.NewManager(g.svcCtx).Transition(ctx, existUser, accountstatus.Change{
+		To:     userConstant.StatusLocked,
+		Reason: fmt.Sprintf("temporarily locked out %d times", level),
+		Actor:  accountstatus.ActorSystem,
+	})
+	if err != nil {
+		logger.Errorf("failed to lock user(%s): %v", username, err)
+		return
+	}
+
+	g.publish(ctx, &event.UserEvent{
+		EventType: event.TypeUserLocked,
+		UserID:    existUser.GetIDAsString(),
+		Data: map[string]interface{}{
+			"username": username,
+			"level":    level,
+			"ip":       ip,
+		},
+	})
+}
+
+// publishLockout 发布暂时锁定事件
+func (g *Guard) publishLockout(ctx context.Context, subject authRepo.LockoutSubject, key, ip string, result *authRepo.LockoutResult) {
+	g.publish(ctx, &event.UserEvent{
+		EventType: event.TypeLoginLockout,
+		Data: map[string]interface{}{
+			"subject":        string(subject),
+			"key":            key,
+			"level":          result.Level,
+			"failures":       result.Failures,
+			"locked_seconds": int64(result.LockedFor.Seconds()),
+			"ip":             ip,
+		},
+	})
+}
+
+// publish 发布事件，失败只记录日志
+func (g *Guard) publish(ctx context.Context, e *event.UserEvent) {
+	if err := g.svcCtx.Publisher.Publish(ctx, e); err != nil {
+		logx.WithContext(ctx).Errorf("failed to publish %s event: %v", e.EventType, err)
+	}
+}
+
+// policy 根据配置生成锁定策略
+func (g *Guard) policy(maxFailures int64) authRepo.LockoutPolicy {
+	conf := g.svcCtx.Config.Auth.Lockout
+	return authRepo.LockoutPolicy{
+		MaxFailures: maxFailures,
+		Window:      time.Duration(conf.FailureWindow) * time.Second,
+		BaseLockout: time.Duration(conf.BaseLockout) * time.Second,
+		MaxLockout:  time.Duration(conf.MaxLockout) * time.Second,
+		LevelTTL:    time.Duration(conf.LevelResetAfter) * time.Second,
+	}
+}
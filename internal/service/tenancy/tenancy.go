@@ -0,0 +1,39 @@
+// Package tenancy 租户解析，按请求中的租户标识查询租户并校验状态
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	userConstant "hello-gozero/internal/constant/user"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+)
+
+// Resolver 租户解析
+type Resolver struct {
+	svcCtx *svc.ServiceContext
+}
+
+// NewResolver 创建租户解析
+func NewResolver(svcCtx *svc.ServiceContext) *Resolver {
+	return &Resolver{svcCtx: svcCtx}
+}
+
+// ResolveTenant Implements [middleware.TenantResolver]
+func (r *Resolver) ResolveTenant(ctx context.Context, code string) (tenant.Tenant, error) {
+	t, err := r.svcCtx.Repository.Tenant.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tenant.Tenant{}, tenant.ErrNotFound
+		}
+		return tenant.Tenant{}, fmt.Errorf("failed to get tenant(%s): %w", code, err)
+	}
+	if t.Status != userConstant.TenantStatusActive {
+		return tenant.Tenant{}, tenant.ErrDisabled
+	}
+	return tenant.Tenant{ID: t.ID, Code: t.Code}, nil
+}
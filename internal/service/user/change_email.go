@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/service/credential"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+)
+
+type ChangeEmailService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewChangeEmailService 更换邮箱
+func NewChangeEmailService(ctx context.Context, svcCtx *svc.ServiceContext) *ChangeEmailService {
+	return &ChangeEmailService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *ChangeEmailService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// ChangeEmail 申请更换邮箱，向新邮箱发送验证码
+// 新邮箱通过 [VerifyEmailService.VerifyEmail] 验证之后才会生效，在此之前账户仍然使用原邮箱
+func (s *ChangeEmailService) ChangeEmail(req *userDto.ChangeEmailReq) (*userDto.ChangeEmailResp, error) {
+	if req.Username == "" {
+		return nil, ErrMissingUsername
+	}
+
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by name(%s): %w", req.Username, err)
+	}
+
+	// 密码校验失败计入认证失败次数
+	guard := lockout.NewGuard(s.svcCtx)
+	if err := guard.Check(s.ctx, existUser.Username, req.ClientIP); err != nil {
+		return nil, err
+	}
+	if !credential.NewVerifier(s.svcCtx).Verify(s.ctx, existUser, req.Password) {
+		guard.RecordFailure(s.ctx, existUser.Username, req.ClientIP)
+		return nil, ErrPasswordMismatch
+	}
+	if err := s.requestChange(existUser, req.Email); err != nil {
+		return nil, err
+	}
+	return &userDto.ChangeEmailResp{
+		Message: "verification code sent to the new email",
+	}, nil
+}
+
+// requestChange 检查新邮箱并向其发送验证码，不校验当前密码
+// 本人更换邮箱由 [ChangeEmailService.ChangeEmail] 先校验密码；管理员为其他用户更换邮箱时直接调用（见 [UpdateUserService.UpdateUser]）
+func (s *ChangeEmailService) requestChange(existUser *userEntity.User, email string) error {
+	if normalizeEmail(existUser.Email) == normalizeEmail(email) {
+		return ErrEmailUnchanged
+	}
+
+	// 提前检查新邮箱是否已被占用，验证时还会再次检查
+	other, err := s.svcCtx.Repository.User.GetByEmail(s.ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if other != nil {
+		return ErrEmailExists
+	}
+
+	return sendEmailVerification(s.ctx, s.svcCtx, newEmailVerifyCode(s.svcCtx), existUser, email)
+}
@@ -67,25 +67,39 @@ func (l *DeleteUserService) DeleteUser(req *userDto.DeleteUserReq) (resp *userDt
 	}
 	l.Logger.Debugf("database delete success for user(%s)", req.Username)
 
+	// 账户已删除，吊销被删除用户的所有会话（调用方可能是拥有 user:delete:any 权限的管理员，不能使用调用方的用户 ID）
+	if deleted, err := l.svcCtx.Repository.User.GetDeletedByUsername(l.ctx, req.Username); err != nil {
+		l.Logger.Errorf("failed to get deleted user(%s) for session revocation: %v", req.Username, err)
+	} else if _, err := l.svcCtx.Repository.Session.DeleteAllByUser(l.ctx, deleted.GetIDAsString()); err != nil {
+		l.Logger.Errorf("failed to revoke sessions for user(%s): %v", req.Username, err)
+	}
+
 	// 延迟双删：异步延迟后再次删除缓存
-	// 使用 goroutine 异步执行，不阻塞主流程
+	deleteUserCacheLater(l.ctx, l.Logger, l.svcCtx, req.Username)
+
+	return &userDto.DeleteUserResp{}, nil
+}
+
+// deleteUserCacheLater 延迟双删的第二次删除：异步延迟后再次删除用户缓存，清除数据库写入期间被并发读请求写回的旧数据
+// 使用 goroutine 异步执行，不阻塞主流程；删除失败只记录日志
+// ctx 为请求上下文，只用于获取租户等上下文值，请求结束后不会取消第二次删除
+func deleteUserCacheLater(ctx context.Context, logger logx.Logger, svcCtx *svc.ServiceContext, username string) {
+	ctx = context.WithoutCancel(ctx)
 	threading.GoSafe(func() {
 		// 延迟一段时间（通常 100-500ms）
 		// 这个时间应该大于一次数据库写操作的时间
 		time.Sleep(cacheDeleteDelay)
 
 		// 第二次删除缓存，带超时控制，避免Goroutine 泄漏（超时确保 goroutine 能正常退出）
-		ctx, cancel := context.WithTimeout(context.Background(), secondDeleteTimeoutSec*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, secondDeleteTimeoutSec*time.Second)
 		defer cancel()
 
-		err := l.svcCtx.Repository.CachedUser.DeleteByUsername(ctx, req.Username)
+		err := svcCtx.Repository.CachedUser.DeleteByUsername(ctx, username)
 		if err != nil {
 			// 记录错误但不影响主流程（主流程已经返回成功）
-			l.Logger.Errorf("second cache delete failed for user(%s): %v", req.Username, err)
+			logger.Errorf("second cache delete failed for user(%s): %v", username, err)
 		} else {
-			l.Logger.Debugf("second cache delete success for user(%s)", req.Username)
+			logger.Debugf("second cache delete success for user(%s)", username)
 		}
 	})
-
-	return &userDto.DeleteUserResp{}, nil
 }
@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/erasure"
+	"hello-gozero/internal/svc"
+)
+
+type EraseUserService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	eraser *erasure.Eraser
+}
+
+// NewEraseUserService 擦除用户个人数据（需要 user:erase:any 权限）
+func NewEraseUserService(ctx context.Context, svcCtx *svc.ServiceContext) *EraseUserService {
+	return &EraseUserService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		eraser: erasure.NewEraser(svcCtx),
+	}
+}
+
+func (s *EraseUserService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// EraseUser 擦除指定用户（活跃用户优先，其次是最近一次被删除的用户）的个人数据，擦除后用户无法恢复
+// 擦除同步执行，中途失败时返回等待重试的进度，由后台任务继续；重复请求不会重复创建擦除记录
+// 返回的 bool 表示擦除是否已经完成
+func (s *EraseUserService) EraseUser(req *userDto.EraseUserReq) (*userDto.ErasureResp, bool, error) {
+	if req.Username == "" {
+		return nil, false, ErrMissingUsername
+	}
+
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, req.Username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		existUser, err = s.svcCtx.Repository.User.GetDeletedByUsername(s.ctx, req.Username)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrUserNotFound
+		}
+		return nil, false, fmt.Errorf("failed to get user by username(%s): %w", req.Username, err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", existUser.GetIDAsString()))
+
+	var actor string
+	if principal := middleware.GetPrincipal(s.ctx); principal != nil {
+		actor = principal.Username
+	}
+	record, err := s.eraser.Request(s.ctx, existUser, actor)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.toErasureDto(record), record.Status == userConstant.ErasureStatusCompleted, nil
+}
+
+// GetErasure 查询用户的个人数据擦除进度
+func (s *EraseUserService) GetErasure(req *userDto.GetErasureReq) (*userDto.ErasureResp, error) {
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, ErrErasureNotFound
+	}
+	record, err := s.svcCtx.Repository.Erasure.Get(s.ctx, userID[:])
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrErasureNotFound
+		}
+		return nil, fmt.Errorf("failed to get erasure(%s): %w", req.UserID, err)
+	}
+	return s.toErasureDto(record), nil
+}
+
+// toErasureDto 转换为擦除进度响应
+func (s *EraseUserService) toErasureDto(record *userEntity.UserErasure) *userDto.ErasureResp {
+	resp := &userDto.ErasureResp{
+		Status:     erasureStatusName(record.Status),
+		Step:       record.Step,
+		TotalSteps: s.eraser.TotalSteps(),
+		Attempts:   record.Attempts,
+		CreatedAt:  record.CreatedAt.Format(time.RFC3339),
+	}
+	if userID, err := uuid.FromBytes(record.UserID); err == nil {
+		resp.UserID = userID.String()
+	}
+	if record.Status == userConstant.ErasureStatusPending {
+		resp.Error = record.Error
+	}
+	if record.CompletedAt != nil {
+		resp.CompletedAt = record.CompletedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// erasureStatusName 擦除状态名称
+func erasureStatusName(status int8) string {
+	switch status {
+	case userConstant.ErasureStatusPending:
+		return "pending"
+	case userConstant.ErasureStatusRunning:
+		return "running"
+	case userConstant.ErasureStatusCompleted:
+		return "completed"
+	default:
+		return fmt.Sprintf("unknown(%d)", status)
+	}
+}
@@ -12,12 +12,42 @@ var (
 
 	// 用户名已存在
 	ErrUsernameExists = errors.New("username already exists")
+
+	// 用户名是其他用户变更前的用户名且仍在保留期内，或是配置 Auth.Admins 中的管理员用户名
+	ErrUsernameReserved = errors.New("username is reserved")
+
+	// 新用户名与当前用户名相同
+	ErrUsernameUnchanged = errors.New("new username is the same as the current username")
+
+	// 距上次变更用户名的时间过短（配置 Username.ChangeInterval）
+	ErrUsernameChangeTooSoon = errors.New("username was changed too recently")
 )
 
 var (
 	// 邮箱已存在
 	ErrEmailExists = errors.New("email already exists")
 
+	// 缺少邮箱参数
+	ErrMissingEmail = errors.New("missing email")
+
+	// 邮箱验证码无效（不存在、已使用、已过期或不匹配）
+	ErrInvalidVerifyCode = errors.New("invalid or expired verification code")
+
+	// 申请邮箱验证码过于频繁
+	ErrTooManyVerifyRequests = errors.New("too many email verification requests")
+
+	// 邮箱验证码校验失败次数过多
+	ErrTooManyVerifyAttempts = errors.New("too many email verification attempts")
+
+	// 没有待验证的邮箱（未填写邮箱或邮箱已验证）
+	ErrNothingToVerify = errors.New("no email pending verification")
+
+	// 新邮箱与当前邮箱相同
+	ErrEmailUnchanged = errors.New("new email is the same as the current email")
+
+	// 密码错误（敏感操作需要验证当前密码）
+	ErrPasswordMismatch = errors.New("password does not match")
+
 	// 手机号已存在
 	ErrPhoneExists = errors.New("phone already exists")
 )
@@ -35,3 +65,79 @@ var (
 	// 新旧密码相同
 	ErrNewPasswordSameAsOld = errors.New("new password cannot be the same as the old password")
 )
+
+var (
+	// 重置密码验证码无效（不存在、已使用、已过期或不匹配）
+	ErrInvalidResetCode = errors.New("invalid or expired reset code")
+
+	// 申请重置密码过于频繁
+	ErrTooManyResetRequests = errors.New("too many password reset requests")
+
+	// 重置密码验证码校验失败次数过多
+	ErrTooManyResetAttempts = errors.New("too many password reset attempts")
+)
+
+var (
+	// 用户列表查询参数无效（如时间格式错误、状态值不存在）
+	ErrInvalidListQuery = errors.New("invalid user list query")
+
+	// 用户列表游标无效（格式错误或与当前排序方式不匹配）
+	ErrInvalidCursor = errors.New("invalid or mismatched cursor")
+)
+
+var (
+	// 资源已被修改（If-Match 与当前版本不匹配）
+	ErrPreconditionFailed = errors.New("user has been modified, please reload and retry")
+
+	// 已有邮箱不能清空，邮箱用于找回密码等流程
+	ErrEmailRequired = errors.New("email cannot be removed")
+
+	// 部分更新的合并补丁无效（字段不支持或类型错误）
+	ErrInvalidPatch = errors.New("invalid merge patch")
+)
+
+var (
+	// 用户状态值无效或暂停到期时间格式错误
+	ErrInvalidStatus = errors.New("invalid user status")
+)
+
+var (
+	// 个人数据导出任务不存在
+	ErrExportNotFound = errors.New("data export not found")
+
+	// 个人数据导出尚未完成或归档已过期
+	ErrExportNotReady = errors.New("data export is not available for download")
+)
+
+var (
+	// 个人数据擦除记录不存在
+	ErrErasureNotFound = errors.New("erasure not found")
+)
+
+var (
+	// 角色不存在
+	ErrRoleNotFound = errors.New("role not found")
+
+	// 不能修改自己的角色
+	ErrCannotChangeOwnRoles = errors.New("cannot change your own roles")
+
+	// 不能分配包含调用方自身没有的权限的角色
+	ErrRoleNotGrantable = errors.New("cannot grant a role with permissions you do not have")
+)
+
+var (
+	// 注册已关闭（配置 Auth.Registration 为 closed）
+	ErrRegistrationClosed = errors.New("registration is closed")
+
+	// 仅限邀请注册（配置 Auth.Registration 为 invite）
+	ErrInvitationRequired = errors.New("registration requires an invitation")
+
+	// 邀请不存在、已过期、已接受或已撤销，或邀请令牌无效
+	ErrInvalidInvitation = errors.New("invalid or expired invitation")
+
+	// 邀请 ID 无效或邀请不存在（撤销时）
+	ErrInvitationNotFound = errors.New("invitation not found")
+
+	// 邀请的租户与请求所属的租户不一致
+	ErrTenantMismatch = errors.New("tenant does not match the request tenant")
+)
@@ -0,0 +1,194 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	"hello-gozero/internal/blobstore"
+	userConstant "hello-gozero/internal/constant/user"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/service/dataexport"
+	"hello-gozero/internal/svc"
+)
+
+type DataExportService struct {
+	Logger   logx.Logger
+	ctx      context.Context
+	svcCtx   *svc.ServiceContext
+	exporter *dataexport.Exporter
+}
+
+// NewDataExportService 个人数据导出
+func NewDataExportService(ctx context.Context, svcCtx *svc.ServiceContext) *DataExportService {
+	return &DataExportService{
+		Logger:   logx.WithContext(ctx),
+		ctx:      ctx,
+		svcCtx:   svcCtx,
+		exporter: dataexport.NewExporter(svcCtx),
+	}
+}
+
+func (s *DataExportService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// RequestExport 申请导出个人数据，归档由后台任务异步生成
+// 已有等待处理或处理中的任务时直接返回该任务，不重复创建；返回的 bool 表示是否新建了任务
+func (s *DataExportService) RequestExport(req *userDto.RequestDataExportReq) (*userDto.DataExportResp, bool, error) {
+	existUser, err := s.getUser(req.Username)
+	if err != nil {
+		return nil, false, err
+	}
+
+	active, err := s.svcCtx.Repository.DataExport.GetActiveByUser(s.ctx, existUser.ID)
+	if err == nil {
+		return s.toDataExportDto(active), false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, fmt.Errorf("failed to get active data export: %w", err)
+	}
+
+	export := &userEntity.DataExport{
+		UserID: existUser.ID,
+		Status: userConstant.ExportStatusPending,
+	}
+	if err := s.svcCtx.Repository.DataExport.Create(s.ctx, export); err != nil {
+		return nil, false, fmt.Errorf("failed to create data export: %w", err)
+	}
+	export.CreatedAt = time.Now()
+	s.Logger.WithContext(s.ctx).Infof("data export(%s) requested for user(%s)", export.GetIDAsString(), existUser.Username)
+
+	return s.toDataExportDto(export), true, nil
+}
+
+// GetExport 查询导出任务，完成后返回新生成的限时下载地址
+func (s *DataExportService) GetExport(req *userDto.GetDataExportReq) (*userDto.DataExportResp, error) {
+	existUser, err := s.getUser(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	export, err := s.getExport(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 只能查询自己的导出任务
+	if !bytes.Equal(export.UserID, existUser.ID) {
+		return nil, ErrExportNotFound
+	}
+	return s.toDataExportDto(export), nil
+}
+
+// Download 校验下载地址后打开导出归档，调用方负责关闭
+func (s *DataExportService) Download(req *userDto.DownloadDataExportReq) (io.ReadCloser, *userEntity.DataExport, error) {
+	if err := s.exporter.VerifyDownload(req.ID, req.Expires, req.Signature); err != nil {
+		return nil, nil, err
+	}
+	export, err := s.getExport(req.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if userID, err := uuid.FromBytes(export.UserID); err == nil {
+		s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", userID.String()))
+	}
+
+	if export.Status != userConstant.ExportStatusCompleted || export.BlobKey == "" ||
+		(export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt)) {
+		return nil, nil, ErrExportNotReady
+	}
+	r, err := s.svcCtx.BlobStore.Open(s.ctx, export.BlobKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, nil, ErrExportNotReady
+		}
+		return nil, nil, err
+	}
+	return r, export, nil
+}
+
+// getUser 根据用户名获取用户
+func (s *DataExportService) getUser(username string) (*userEntity.User, error) {
+	if username == "" {
+		return nil, ErrMissingUsername
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by username(%s): %w", username, err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", existUser.GetIDAsString()))
+	return existUser, nil
+}
+
+// getExport 根据任务 ID 获取导出任务
+func (s *DataExportService) getExport(id string) (*userEntity.DataExport, error) {
+	exportID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrExportNotFound
+	}
+	export, err := s.svcCtx.Repository.DataExport.GetByID(s.ctx, exportID[:])
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get data export(%s): %w", id, err)
+	}
+	return export, nil
+}
+
+// toDataExportDto 转换为导出任务响应，已完成的任务附带限时下载地址
+func (s *DataExportService) toDataExportDto(export *userEntity.DataExport) *userDto.DataExportResp {
+	resp := &userDto.DataExportResp{
+		ID:        export.GetIDAsString(),
+		Status:    exportStatusName(export.Status),
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+	}
+	if export.Status != userConstant.ExportStatusCompleted {
+		return resp
+	}
+
+	if export.CompletedAt != nil {
+		resp.CompletedAt = export.CompletedAt.Format(time.RFC3339)
+	}
+	resp.Size = export.Size
+	if export.ExpiresAt != nil {
+		resp.ExpiresAt = export.ExpiresAt.Format(time.RFC3339)
+		if time.Now().After(*export.ExpiresAt) {
+			// 归档已过期但尚未被清理
+			resp.Status = exportStatusName(userConstant.ExportStatusExpired)
+			return resp
+		}
+	}
+	downloadURL, expiresAt := s.exporter.DownloadURL(export)
+	resp.DownloadURL = downloadURL
+	resp.DownloadURLExpiresAt = expiresAt.Format(time.RFC3339)
+	return resp
+}
+
+// exportStatusName 导出任务状态名称
+func exportStatusName(status int8) string {
+	switch status {
+	case userConstant.ExportStatusPending:
+		return "pending"
+	case userConstant.ExportStatusRunning:
+		return "running"
+	case userConstant.ExportStatusCompleted:
+		return "completed"
+	case userConstant.ExportStatusFailed:
+		return "failed"
+	case userConstant.ExportStatusExpired:
+		return "expired"
+	default:
+		return fmt.Sprintf("unknown(%d)", status)
+	}
+}
@@ -5,10 +5,13 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
 
 	userDto "hello-gozero/internal/dto/user"
 	userEntity "hello-gozero/internal/entity/user"
@@ -41,6 +44,10 @@ func (l *GetUserService) GetUser(req *userDto.GetUserReq) (resp *userDto.GetUser
 
 	cachedEntity, err := l.svcCtx.Repository.CachedUser.GetByUsername(l.ctx, req.Username)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 用户不存在，可能是已变更的原用户名
+			return l.resolveRenamed(req.Username)
+		}
 		return nil, fmt.Errorf("failed to get user: %v", err)
 	}
 	l.ctx = logx.ContextWithFields(l.ctx, logx.Field("source", cachedEntity.DataSource))
@@ -50,25 +57,54 @@ func (l *GetUserService) GetUser(req *userDto.GetUserReq) (resp *userDto.GetUser
 		return nil, ErrUserNotFound
 	}
 
-	return l.userEntityToResp(cachedEntity.User), nil
+	return &userDto.GetUserResp{
+		User: toUserDto(cachedEntity.User),
+		ETag: userETag(cachedEntity.User),
+	}, nil
+}
+
+// resolveRenamed 按保留期内的原用户名查找用户，找到时返回用户的当前用户名（[userDto.GetUserResp.MovedTo]），否则返回 [ErrUserNotFound]
+// 按用户 ID 查找当前用户名，多次变更后原用户名仍指向最新的用户名
+func (l *GetUserService) resolveRenamed(username string) (*userDto.GetUserResp, error) {
+	reservation, err := l.svcCtx.Repository.User.GetUsernameReservation(l.ctx, username, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get username reservation: %w", err)
+	}
+	userID, err := uuid.FromBytes(reservation.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed user id in username history(%d): %w", reservation.ID, err)
+	}
+	existUser, err := l.svcCtx.Repository.User.GetByID(l.ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 用户已被删除
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by id(%s): %w", userID, err)
+	}
+	l.Logger.WithContext(l.ctx).Debugf("GetUser: username '%s' has been renamed to '%s'", username, existUser.Username)
+	return &userDto.GetUserResp{MovedTo: existUser.Username}, nil
 }
 
-// userEntityToResp 将用户实体转换为响应 DTO
-func (l *GetUserService) userEntityToResp(user *userEntity.User) *userDto.GetUserResp {
+// toUserDto 将用户实体转换为返回给客户端的用户信息
+func toUserDto(user *userEntity.User) userDto.User {
 	var lastLogin string
 	if user.LastLoginTime != nil {
 		lastLogin = user.LastLoginTime.Format(time.RFC3339)
 	}
 
-	return &userDto.GetUserResp{
-		User: userDto.User{
-			Username:         user.Username,
-			Email:            user.Email,
-			PhoneCountryCode: user.PhoneCountryCode,
-			PhoneNumber:      user.PhoneNumber,
-			Nickname:         user.Nickname,
-			Status:           int(user.Status),
-			LastLoginTime:    lastLogin,
-		},
+	return userDto.User{
+		Username:         user.Username,
+		Email:            user.Email,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		PhoneCountryCode: user.PhoneCountryCode,
+		PhoneNumber:      user.PhoneNumber,
+		Nickname:         user.Nickname,
+		Status:           int(user.Status),
+		LastLoginTime:    lastLogin,
+		CreatedAt:        user.CreatedAt.Format(time.RFC3339),
 	}
 }
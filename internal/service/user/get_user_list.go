@@ -5,10 +5,17 @@ package user
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
 
 	"github.com/zeromicro/go-zero/core/logx"
 
 	userDto "hello-gozero/internal/dto/user"
+	userRepo "hello-gozero/internal/repository/user"
+	"hello-gozero/internal/service/accountstatus"
 	"hello-gozero/internal/svc"
 )
 
@@ -29,8 +36,145 @@ func NewGetUserListService(ctx context.Context, svcCtx *svc.ServiceContext) *Get
 func (l *GetUserListService) GetCtx() context.Context {
 	return l.ctx
 }
+
+// GetUserList 按条件获取用户列表，支持偏移分页与游标分页（见 [userDto.GetUserListReq]）
 func (l *GetUserListService) GetUserList(req *userDto.GetUserListReq) (resp *userDto.GetUserListResp, err error) {
-	// todo: add your logic here and delete this line
+	query, err := toListQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cursorMode := req.Pagination == "cursor" || req.Cursor != ""
+	if cursorMode {
+		if req.Cursor != "" {
+			after, err := decodeListCursor(req.Cursor, query.SortBy, query.Desc)
+			if err != nil {
+				return nil, err
+			}
+			query.After = after
+		}
+	} else {
+		query.Offset = (req.Page - 1) * req.PageSize
+		query.CountTotal = true
+	}
+
+	// 多取一条用于判断是否还有下一页
+	query.Limit = req.PageSize + 1
+	users, total, err := l.svcCtx.Repository.User.List(l.ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	resp = &userDto.GetUserListResp{
+		List:     make([]userDto.User, 0, len(users)),
+		PageSize: req.PageSize,
+		HasMore:  len(users) > req.PageSize,
+	}
+	if resp.HasMore {
+		users = users[:req.PageSize]
+	}
+	for _, user := range users {
+		resp.List = append(resp.List, toUserDto(user))
+	}
+
+	if !cursorMode {
+		resp.Total = &total
+		resp.Page = req.Page
+		return resp, nil
+	}
+	if resp.HasMore {
+		last, err := userRepo.NewListCursor(users[len(users)-1], query.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		resp.NextCursor = encodeListCursor(last, query.SortBy, query.Desc)
+	}
+	return resp, nil
+}
+
+// toListQuery 将请求参数转换为查询条件（不含分页）
+func toListQuery(req *userDto.GetUserListReq) (userRepo.ListQuery, error) {
+	query := userRepo.ListQuery{
+		UsernamePrefix: req.Username,
+		EmailDomain:    req.EmailDomain,
+		SortBy:         req.Sort,
+		Desc:           req.Order == "desc",
+	}
 
-	return
+	if req.Status != nil {
+		if *req.Status < math.MinInt8 || *req.Status > math.MaxInt8 || !accountstatus.Valid(int8(*req.Status)) {
+			return query, fmt.Errorf("%w: unknown status %d", ErrInvalidListQuery, *req.Status)
+		}
+		status := int8(*req.Status)
+		query.Status = &status
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		dest  **time.Time
+	}{
+		{"created_after", req.CreatedAfter, &query.CreatedFrom},
+		{"created_before", req.CreatedBefore, &query.CreatedTo},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return query, fmt.Errorf("%w: %s must be an RFC 3339 time", ErrInvalidListQuery, bound.name)
+		}
+		*bound.dest = &t
+	}
+	if query.CreatedFrom != nil && query.CreatedTo != nil && !query.CreatedFrom.Before(*query.CreatedTo) {
+		return query, fmt.Errorf("%w: created_after must be earlier than created_before", ErrInvalidListQuery)
+	}
+	return query, nil
+}
+
+// listCursor 游标的编码内容，游标对客户端不透明（base64url 编码的 JSON）
+// 排序方式一并编码，用于拒绝与当前排序方式不匹配的游标
+type listCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`  // 排序字段的值，时间使用 RFC 3339（纳秒精度）
+	ID    []byte `json:"id"` // 用户 ID
+}
+
+// encodeListCursor 编码游标
+func encodeListCursor(cursor userRepo.ListCursor, sortBy string, desc bool) string {
+	c := listCursor{Sort: sortBy, Desc: desc, ID: cursor.ID}
+	switch v := cursor.Value.(type) {
+	case time.Time:
+		c.Value = v.Format(time.RFC3339Nano)
+	case string:
+		c.Value = v
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeListCursor 解码游标，并校验游标与当前排序方式一致
+func decodeListCursor(s, sortBy string, desc bool) (*userRepo.ListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil || len(c.ID) != 16 {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sortBy || c.Desc != desc {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &userRepo.ListCursor{ID: c.ID, Value: c.Value}
+	if sortBy != userRepo.ListSortUsername {
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		cursor.Value = t
+	}
+	return cursor, nil
 }
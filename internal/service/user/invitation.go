@@ -0,0 +1,293 @@
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/notify"
+	"hello-gozero/internal/service/authz"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+	"hello-gozero/pkg/i18n"
+)
+
+type UserInvitationService struct {
+	Logger     logx.Logger
+	ctx        context.Context
+	svcCtx     *svc.ServiceContext
+	authorizer *authz.Authorizer
+}
+
+// NewUserInvitationService 用户邀请
+func NewUserInvitationService(ctx context.Context, svcCtx *svc.ServiceContext) *UserInvitationService {
+	return &UserInvitationService{
+		Logger:     logx.WithContext(ctx),
+		ctx:        ctx,
+		svcCtx:     svcCtx,
+		authorizer: authz.NewAuthorizer(svcCtx),
+	}
+}
+
+func (s *UserInvitationService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// CreateInvitation 邀请用户注册，向被邀请人的邮箱发送带签名令牌的邀请链接
+//
+// 同一邮箱之前尚未接受的邀请会被撤销；邮箱已被租户内的用户使用时返回 [ErrEmailExists]；
+// 防止越权：邀请分配的角色包含调用方自身没有的权限时拒绝，返回 [ErrRoleNotGrantable]
+func (s *UserInvitationService) CreateInvitation(req *userDto.CreateInvitationReq) (*userDto.Invitation, error) {
+	if s.svcCtx.Config.Auth.Registration == userConstant.RegistrationClosed {
+		return nil, ErrRegistrationClosed
+	}
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, errors.New("missing principal")
+	}
+	if t, _ := tenant.FromContext(s.ctx); req.Tenant != "" && !strings.EqualFold(req.Tenant, t.Code) {
+		return nil, ErrTenantMismatch
+	}
+
+	if req.Role != "" {
+		roles, err := s.svcCtx.Repository.Role.GetRolesByNames(s.ctx, []string{req.Role})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role(%s): %w", req.Role, err)
+		}
+		if len(roles) == 0 {
+			return nil, ErrRoleNotFound
+		}
+		if err := ensureGrantable(s.ctx, s.svcCtx, s.authorizer, principal, []uint64{roles[0].ID}); err != nil {
+			return nil, err
+		}
+	}
+
+	existUser, err := s.svcCtx.Repository.User.GetByEmail(s.ctx, req.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if existUser != nil {
+		return nil, ErrEmailExists
+	}
+
+	expire := time.Duration(s.svcCtx.Config.Invitation.Expire) * time.Second
+	invitation := &userEntity.Invitation{
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: principal.Username,
+		Status:    userConstant.InvitationStatusPending,
+		// 令牌中的过期时间精确到秒，数据库中同样截断到秒，保证两者一致
+		ExpiresAt: time.Now().Add(expire).Truncate(time.Second),
+	}
+	if err := s.svcCtx.Repository.Invitation.Create(s.ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("invitation_id", invitation.GetIDAsString()))
+	s.Logger.WithContext(s.ctx).Infof("invitation for %s created by %s", invitation.Email, principal.Username)
+
+	// 发送失败不影响邀请结果，可以重新邀请同一邮箱
+	err = s.svcCtx.Notifier.Notify(s.ctx, &notify.Message{
+		Channel:  notify.ChannelEmail,
+		To:       invitation.Email,
+		Template: notify.TemplateInvitation,
+		Locale:   i18n.GetLocale(s.ctx),
+		Data: map[string]any{
+			"Inviter":     principal.Username,
+			"Link":        s.acceptLink(invitation),
+			"ExpireHours": int(expire.Hours()),
+		},
+	})
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to send invitation to %s: %v", invitation.Email, err)
+	}
+
+	err = s.svcCtx.Publisher.Publish(s.ctx, &event.UserEvent{
+		EventType: event.TypeUserInvited,
+		Data: map[string]interface{}{
+			"email":    invitation.Email,
+			"role":     invitation.Role,
+			"operator": principal.Username,
+		},
+	})
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to publish %s event: %v", event.TypeUserInvited, err)
+	}
+
+	return toInvitationDto(invitation), nil
+}
+
+// ListInvitations 获取租户内未过期且等待接受的邀请
+func (s *UserInvitationService) ListInvitations() (*userDto.ListInvitationsResp, error) {
+	invitations, err := s.svcCtx.Repository.Invitation.ListPending(s.ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	resp := &userDto.ListInvitationsResp{Invitations: make([]userDto.Invitation, 0, len(invitations))}
+	for _, invitation := range invitations {
+		resp.Invitations = append(resp.Invitations, *toInvitationDto(invitation))
+	}
+	return resp, nil
+}
+
+// RevokeInvitation 撤销等待接受的邀请，撤销后邀请链接立即失效
+func (s *UserInvitationService) RevokeInvitation(req *userDto.RevokeInvitationReq) (*userDto.RevokeInvitationResp, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return nil, ErrInvitationNotFound
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("invitation_id", id.String()))
+
+	revoked, err := s.svcCtx.Repository.Invitation.Revoke(s.ctx, id[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	if !revoked {
+		return nil, ErrInvitationNotFound
+	}
+	if principal := middleware.GetPrincipal(s.ctx); principal != nil {
+		s.Logger.WithContext(s.ctx).Infof("invitation revoked by %s", principal.Username)
+	}
+	return &userDto.RevokeInvitationResp{}, nil
+}
+
+// AcceptInvitation 接受邀请并注册，邮箱取自邀请且视为已验证，邀请指定了角色时为新用户分配该角色
+//
+// 注册流程与开放注册相同（见 [RegisterUserService]），配置 Auth.Registration 为 closed 时拒绝；
+// 邀请在注册前被领取，同一邀请只能被接受一次，注册失败时恢复为等待接受
+func (s *UserInvitationService) AcceptInvitation(req *userDto.AcceptInvitationReq) (*userDto.AcceptInvitationResp, error) {
+	if s.svcCtx.Config.Auth.Registration == userConstant.RegistrationClosed {
+		return nil, ErrRegistrationClosed
+	}
+	id, err := s.parseToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("invitation_id", id.String()))
+
+	invitation, err := s.svcCtx.Repository.Invitation.Claim(s.ctx, id[:], time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim invitation: %w", err)
+	}
+	if invitation == nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	registrar := NewRegisterUserService(s.ctx, s.svcCtx)
+	user, err := registrar.register(req.RegisterReq(invitation.Email), true)
+	if err != nil {
+		// 请求取消时仍需要恢复邀请
+		if releaseErr := s.svcCtx.Repository.Invitation.Release(context.WithoutCancel(s.ctx), invitation.ID); releaseErr != nil {
+			s.Logger.WithContext(s.ctx).Errorf("failed to release invitation: %v", releaseErr)
+		}
+		return nil, err
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", user.GetIDAsString()))
+
+	if err := s.svcCtx.Repository.Invitation.SetAcceptedUser(s.ctx, invitation.ID, user.ID); err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to record accepted user of invitation: %v", err)
+	}
+
+	resp := &userDto.AcceptInvitationResp{Username: user.Username, Email: user.Email}
+	if invitation.Role != "" {
+		// 用户已经创建成功，分配角色失败只记录日志，可以由管理员重新分配
+		if err := s.assignRole(invitation, user); err != nil {
+			s.Logger.WithContext(s.ctx).Errorf("failed to assign role(%s) to invited user(%s): %v", invitation.Role, user.Username, err)
+		} else {
+			resp.Role = invitation.Role
+		}
+	}
+	s.Logger.WithContext(s.ctx).Infof("invitation accepted by user(%s)", user.Username)
+
+	return resp, nil
+}
+
+// assignRole 为接受邀请的用户分配邀请中的角色，分配人记为邀请人
+func (s *UserInvitationService) assignRole(invitation *userEntity.Invitation, user *userEntity.User) error {
+	roles, err := s.svcCtx.Repository.Role.GetRolesByNames(s.ctx, []string{invitation.Role})
+	if err != nil {
+		return fmt.Errorf("failed to get role: %w", err)
+	}
+	if len(roles) == 0 {
+		return ErrRoleNotFound
+	}
+	return s.svcCtx.Repository.Role.SetUserRoles(s.ctx, user.ID, []uint64{roles[0].ID}, invitation.InvitedBy)
+}
+
+// acceptLink 生成邀请邮件中的链接，未配置 Invitation.AcceptURL 时只返回令牌
+func (s *UserInvitationService) acceptLink(invitation *userEntity.Invitation) string {
+	token := s.token(invitation.GetIDAsString(), invitation.ExpiresAt.Unix())
+	acceptURL := s.svcCtx.Config.Invitation.AcceptURL
+	if acceptURL == "" {
+		return token
+	}
+	sep := "?"
+	if strings.Contains(acceptURL, "?") {
+		sep = "&"
+	}
+	return acceptURL + sep + url.Values{"token": {token}}.Encode()
+}
+
+// token 生成邀请令牌，格式为 "邀请 ID.过期时间.签名"
+func (s *UserInvitationService) token(id string, expires int64) string {
+	exp := strconv.FormatInt(expires, 10)
+	return id + "." + exp + "." + s.sign(id, exp)
+}
+
+// parseToken 校验邀请令牌的签名与有效期，返回邀请 ID；令牌无效或已过期时返回 [ErrInvalidInvitation]
+func (s *UserInvitationService) parseToken(token string) (uuid.UUID, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, ErrInvalidInvitation
+	}
+	if !hmac.Equal([]byte(s.sign(parts[0], parts[1])), []byte(parts[2])) {
+		return uuid.Nil, ErrInvalidInvitation
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return uuid.Nil, ErrInvalidInvitation
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, ErrInvalidInvitation
+	}
+	return id, nil
+}
+
+// sign 计算邀请令牌签名：HMAC-SHA256(邀请 ID + 过期时间)
+func (s *UserInvitationService) sign(id, expires string) string {
+	key := s.svcCtx.Config.Invitation.SigningKey
+	if key == "" {
+		key = s.svcCtx.Config.Auth.AccessSecret
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte("invitation\n" + id + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// toInvitationDto 转换为邀请 DTO
+func toInvitationDto(invitation *userEntity.Invitation) *userDto.Invitation {
+	return &userDto.Invitation{
+		ID:        invitation.GetIDAsString(),
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		InvitedBy: invitation.InvitedBy,
+		ExpiresAt: invitation.ExpiresAt.Format(time.RFC3339),
+		CreatedAt: invitation.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
+	"hello-gozero/internal/utils/password"
+)
+
+type GetPasswordPolicyService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewGetPasswordPolicyService 获取密码策略
+func NewGetPasswordPolicyService(ctx context.Context, svcCtx *svc.ServiceContext) *GetPasswordPolicyService {
+	return &GetPasswordPolicyService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *GetPasswordPolicyService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// GetPasswordPolicy 获取适用于指定租户（默认为请求所属的租户）与角色的密码策略（租户 > 角色 > 默认）
+func (s *GetPasswordPolicyService) GetPasswordPolicy(req *userDto.GetPasswordPolicyReq) (*userDto.GetPasswordPolicyResp, error) {
+	subject := password.PolicySubject{Tenant: req.Tenant}
+	if t, ok := tenant.FromContext(s.ctx); ok && subject.Tenant == "" {
+		subject.Tenant = t.Code
+	}
+	if req.Role != "" {
+		subject.Roles = []string{req.Role}
+	}
+	policy := s.svcCtx.Security.PasswordPolicy.Resolve(subject)
+
+	return &userDto.GetPasswordPolicyResp{
+		MinLength:     policy.MinLength,
+		MaxLength:     policy.MaxLength,
+		RequireDigit:  policy.RequireDigit,
+		RequireUpper:  policy.RequireUpper,
+		RequireLower:  policy.RequireLower,
+		RequireSymbol: policy.RequireSymbol,
+		Symbols:       password.Symbols,
+		BanSimple:     policy.BanSimple,
+		BanBreached:   policy.BanBreached,
+		MinStrength:   policy.MinStrength,
+		HistorySize:   policy.HistorySize,
+		MaxAgeDays:    policy.MaxAge,
+	}, nil
+}
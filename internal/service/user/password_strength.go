@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/utils/password"
+	"hello-gozero/pkg/i18n"
+)
+
+type EstimatePasswordStrengthService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewEstimatePasswordStrengthService 评估密码强度
+func NewEstimatePasswordStrengthService(ctx context.Context, svcCtx *svc.ServiceContext) *EstimatePasswordStrengthService {
+	return &EstimatePasswordStrengthService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *EstimatePasswordStrengthService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// EstimatePasswordStrength 评估密码强度，反馈的提示文案按调用方语言渲染
+// 只做评估，不校验密码策略，也不保存或记录密码
+func (s *EstimatePasswordStrengthService) EstimatePasswordStrength(req *userDto.EstimatePasswordStrengthReq) (*userDto.EstimatePasswordStrengthResp, error) {
+	strength := password.EstimateStrength(req.Password, req.UserInputs...)
+	locale := i18n.GetLocale(s.ctx)
+
+	resp := &userDto.EstimatePasswordStrengthResp{
+		Score:        strength.Score,
+		Guesses:      strength.Guesses,
+		GuessesLog10: strength.GuessesLog10,
+		Feedback: userDto.PasswordStrengthFeedback{
+			Suggestions: make([]userDto.PasswordFeedbackItem, 0, len(strength.Suggestions)),
+		},
+	}
+	if strength.Warning != "" {
+		resp.Feedback.Warning = &userDto.PasswordFeedbackItem{
+			Code:    strength.Warning,
+			Message: password.FeedbackMessage(locale, strength.Warning),
+		}
+	}
+	for _, code := range strength.Suggestions {
+		resp.Feedback.Suggestions = append(resp.Feedback.Suggestions, userDto.PasswordFeedbackItem{
+			Code:    code,
+			Message: password.FeedbackMessage(locale, code),
+		})
+	}
+	return resp, nil
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 
 	"hello-gozero/infra/cache"
@@ -18,7 +17,10 @@ import (
 	userDto "hello-gozero/internal/dto/user"
 	userEntity "hello-gozero/internal/entity/user"
 	userRepo "hello-gozero/internal/repository/user"
+	"hello-gozero/internal/service/authz"
+	"hello-gozero/internal/service/credential"
 	"hello-gozero/internal/svc"
+	"hello-gozero/internal/tenant"
 )
 
 type RegisterUserService struct {
@@ -38,23 +40,62 @@ func NewRegisterUserService(ctx context.Context, svcCtx *svc.ServiceContext) *Re
 func (s *RegisterUserService) GetCtx() context.Context {
 	return s.ctx
 }
+
+// RegisterUser 开放注册，配置 Auth.Registration 不为 open 时拒绝，需要通过邀请注册（见 [UserInvitationService.AcceptInvitation]）
 func (s *RegisterUserService) RegisterUser(req *userDto.RegisterUserReq) (resp *userDto.RegisterUserResp, err error) {
+	switch s.svcCtx.Config.Auth.Registration {
+	case userConstant.RegistrationInvite:
+		return nil, ErrInvitationRequired
+	case userConstant.RegistrationClosed:
+		return nil, ErrRegistrationClosed
+	}
+
+	if _, err := s.register(req, false); err != nil {
+		return nil, err
+	}
+
+	// 返回结果
+	return &userDto.RegisterUserResp{}, nil
+}
+
+// register 创建用户，emailVerified 为 true 表示邮箱已经过验证（如通过发送到该邮箱的邀请注册），不再发送邮箱验证码
+func (s *RegisterUserService) register(req *userDto.RegisterUserReq, emailVerified bool) (*userEntity.User, error) {
+	// 配置的管理员用户名不能通过注册获得
+	if authz.NewAuthorizer(s.svcCtx).IsConfiguredAdmin(s.ctx, req.Username) {
+		return nil, ErrUsernameReserved
+	}
+
+	// 密码策略检查
+	verifier := credential.NewVerifier(s.svcCtx)
+	if err := verifier.CheckPolicy(s.ctx, req.Username, req.Password, req.Email, req.Nickname, req.PhoneNumber); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWeakPassword, err)
+	}
+
 	// 加密密码（在事务外处理，避免事务过长）
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := verifier.Hash(req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %v", err)
+		return nil, err
 	}
 
 	// 创建用户实体
+	now := time.Now()
 	user := &userEntity.User{
 		// ID 由 [userEntity.User.BeforeCreate] hook 自动生成，不应该显式设置
-		Username:         req.Username,
-		Password:         string(hashedPassword),
-		Email:            req.Email,
-		PhoneCountryCode: req.PhoneCountryCode,
-		PhoneNumber:      req.PhoneNumber,
-		Nickname:         req.Nickname,
-		Status:           userConstant.StatusActive, // 默认正常状态
+		Username:          req.Username,
+		Password:          hashedPassword,
+		Email:             req.Email,
+		PhoneCountryCode:  req.PhoneCountryCode,
+		PhoneNumber:       req.PhoneNumber,
+		Nickname:          req.Nickname,
+		Status:            userConstant.StatusActive, // 默认正常状态
+		PasswordChangedAt: &now,
+	}
+	// 需要验证邮箱时，填写了邮箱的账户在验证通过前处于待验证状态
+	switch {
+	case emailVerified && req.Email != "":
+		user.EmailVerifiedAt = &now
+	case s.svcCtx.Config.Auth.RequireEmailVerification && req.Email != "":
+		user.Status = userConstant.StatusPendingVerification
 	}
 
 	// ============================================================
@@ -65,7 +106,7 @@ func (s *RegisterUserService) RegisterUser(req *userDto.RegisterUserReq) (resp *
 	//   2. 在应用层面序列化并发请求，减少数据库压力
 	//   3. 数据库唯一索引作为最后防线，保证数据完整性
 	// 锁的粒度：基于用户名（可以根据需求调整为邮箱/手机号）
-	lockKey := fmt.Sprintf("lock:user:register:%s", req.Username)
+	lockKey := userLockKey(s.ctx, "register", req.Username)
 	lockValue := uuid.New().String() // 锁的唯一标识
 	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
 
@@ -81,6 +122,10 @@ func (s *RegisterUserService) RegisterUser(req *userDto.RegisterUserReq) (resp *
 			if exists {
 				return ErrUsernameExists
 			}
+			// 其他用户变更前的用户名在保留期内不能注册
+			if err := checkUsernameReservation(s.ctx, txRepo, req.Username, nil); err != nil {
+				return err
+			}
 
 			// 检查邮箱是否已存在（如果提供）
 			if req.Email != "" {
@@ -132,7 +177,21 @@ func (s *RegisterUserService) RegisterUser(req *userDto.RegisterUserReq) (resp *
 	if err != nil {
 		return nil, err
 	}
+	verifier.RecordPasswordChange(s.ctx, user)
 
-	// 返回结果
-	return &userDto.RegisterUserResp{}, nil
+	// 发送邮箱验证码，邮箱验证通过之前不能用于找回密码；发送失败不影响注册结果，用户可以稍后重新发送
+	if req.Email != "" && !emailVerified {
+		if err := sendEmailVerification(s.ctx, s.svcCtx, newEmailVerifyCode(s.svcCtx), user, req.Email); err != nil {
+			s.Logger.WithContext(s.ctx).Errorf("failed to send email verification for user(%s): %v", req.Username, err)
+		}
+	}
+
+	return user, nil
+}
+
+// userLockKey 获取用户相关分布式锁的键，格式为 lock:user:<kind>:<租户 ID>:<name>
+// 用户名与邮箱只在租户内唯一，不同租户中的同名用户不应互相阻塞
+func userLockKey(ctx context.Context, kind, name string) string {
+	t, _ := tenant.FromContext(ctx)
+	return fmt.Sprintf("lock:user:%s:%d:%s", kind, t.ID, name)
 }
@@ -0,0 +1,195 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	"hello-gozero/infra/cache"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/middleware"
+	userRepo "hello-gozero/internal/repository/user"
+	"hello-gozero/internal/service/authz"
+	"hello-gozero/internal/svc"
+)
+
+type RenameUserService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewRenameUserService 变更用户名
+func NewRenameUserService(ctx context.Context, svcCtx *svc.ServiceContext) *RenameUserService {
+	return &RenameUserService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *RenameUserService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// RenameUser 变更用户名
+//   - 原用户名记录到变更历史中，保留期内（配置 Username.Reservation）只能由原用户改回，按原用户名获取用户时重定向到新用户名
+//   - 本人变更受最短间隔限制（配置 Username.ChangeInterval），管理员变更其他用户的用户名不受限制
+//   - 本人的接口按用户 ID 判断归属，变更后立即按新用户名访问，原有的访问令牌仍然有效；令牌中的用户名在刷新令牌后才会更新
+func (s *RenameUserService) RenameUser(req *userDto.RenameUserReq) (*userDto.RenameUserResp, error) {
+	if req.Username == "" {
+		return nil, ErrMissingUsername
+	}
+	if req.NewUsername == req.Username {
+		return nil, ErrUsernameUnchanged
+	}
+	// 配置的管理员用户名不能通过变更用户名获得
+	if authz.NewAuthorizer(s.svcCtx).IsConfiguredAdmin(s.ctx, req.NewUsername) {
+		return nil, ErrUsernameReserved
+	}
+
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by name(%s): %w", req.Username, err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", existUser.GetIDAsString()))
+
+	operator, self := existUser.Username, true
+	if principal := middleware.GetPrincipal(s.ctx); principal != nil {
+		operator = principal.Username
+		self = principal.UserID == existUser.GetIDAsString()
+	}
+	now := time.Now()
+	if self {
+		if err := s.checkInterval(existUser, now); err != nil {
+			return nil, err
+		}
+	}
+
+	change := &userEntity.UsernameHistory{
+		UserID:        existUser.ID,
+		OldUsername:   existUser.Username,
+		NewUsername:   req.NewUsername,
+		ReservedUntil: now.Add(time.Duration(s.svcCtx.Config.Username.Reservation) * time.Second).Truncate(time.Second),
+		Actor:         operator,
+	}
+
+	// 与注册使用同一把锁，避免变更的同时注册同名用户；数据库唯一索引作为最后防线
+	lockKey := userLockKey(s.ctx, "register", req.NewUsername)
+	lockValue := uuid.New().String() // 锁的唯一标识
+	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
+
+	err = cache.WithLock(s.ctx, s.svcCtx.Infra.Redis.Client, lockKey, lockValue, lockTTL, func() error {
+		return s.renameWithinLock(existUser, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	existUser.Username = change.NewUsername
+
+	// 延迟双删：原用户名与新用户名的缓存（新用户名可能缓存了空值标记）都需要再次删除
+	deleteUserCacheLater(s.ctx, s.Logger, s.svcCtx, change.OldUsername)
+	deleteUserCacheLater(s.ctx, s.Logger, s.svcCtx, change.NewUsername)
+
+	s.Logger.WithContext(s.ctx).Infof("username changed from %s to %s by %s", change.OldUsername, change.NewUsername, operator)
+	err = s.svcCtx.Publisher.Publish(s.ctx, &event.UserEvent{
+		EventType: event.TypeUsernameChanged,
+		UserID:    existUser.GetIDAsString(),
+		Data: map[string]interface{}{
+			"old_username": change.OldUsername,
+			"new_username": change.NewUsername,
+			"operator":     operator,
+		},
+	})
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to publish %s event: %v", event.TypeUsernameChanged, err)
+	}
+
+	return &userDto.RenameUserResp{
+		User:          toUserDto(existUser),
+		ReservedUntil: change.ReservedUntil.Format(time.RFC3339),
+		ETag:          userETag(existUser),
+	}, nil
+}
+
+// renameWithinLock 在分布式锁保护下检查新用户名并变更
+func (s *RenameUserService) renameWithinLock(existUser *userEntity.User, change *userEntity.UsernameHistory) error {
+	// 第一次删除缓存，失败时不继续更新数据库（见 [DeleteUserService.DeleteUser]）
+	for _, username := range []string{change.OldUsername, change.NewUsername} {
+		if err := s.svcCtx.Repository.CachedUser.DeleteByUsername(s.ctx, username); err != nil {
+			return fmt.Errorf("first cache delete failed for user(%s): %w", username, err)
+		}
+	}
+
+	return s.svcCtx.Repository.User.Transaction(s.ctx, func(txRepo userRepo.UserRepository) error {
+		exists, err := txRepo.ExistsByUsername(s.ctx, change.NewUsername)
+		if err != nil {
+			return fmt.Errorf("failed to check username existence: %w", err)
+		}
+		if exists {
+			return ErrUsernameExists
+		}
+		// 本人变更前的用户名可以改回，其他用户变更前的用户名在保留期内不能使用
+		if err := checkUsernameReservation(s.ctx, txRepo, change.NewUsername, existUser.ID); err != nil {
+			return err
+		}
+
+		renamed, err := txRepo.Rename(s.ctx, change)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+				return ErrUsernameExists
+			}
+			return fmt.Errorf("failed to rename user(%s): %w", change.OldUsername, err)
+		}
+		if !renamed {
+			// 读取之后用户名已被并发变更或用户已被删除
+			return ErrPreconditionFailed
+		}
+		return nil
+	})
+}
+
+// checkInterval 检查距本人上次变更用户名的时间是否达到最短间隔
+func (s *RenameUserService) checkInterval(existUser *userEntity.User, now time.Time) error {
+	interval := time.Duration(s.svcCtx.Config.Username.ChangeInterval) * time.Second
+	if interval <= 0 {
+		return nil
+	}
+	history, err := s.svcCtx.Repository.User.ListUsernameHistory(s.ctx, existUser.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list username history: %w", err)
+	}
+	if len(history) > 0 && now.Before(history[len(history)-1].CreatedAt.Add(interval)) {
+		return ErrUsernameChangeTooSoon
+	}
+	return nil
+}
+
+// checkUsernameReservation 检查用户名是否为其他用户变更前且仍在保留期内的用户名，是则返回 [ErrUsernameReserved]
+// userID 为使用该用户名的用户，为空表示新用户
+func checkUsernameReservation(ctx context.Context, repo userRepo.UserRepository, username string, userID []byte) error {
+	reservation, err := repo.GetUsernameReservation(ctx, username, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to check username reservation: %w", err)
+	}
+	if userID != nil && bytes.Equal(reservation.UserID, userID) {
+		return nil
+	}
+	return ErrUsernameReserved
+}
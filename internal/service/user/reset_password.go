@@ -2,23 +2,208 @@ package user
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
 
+	"hello-gozero/infra/cache"
+	userConstant "hello-gozero/internal/constant/user"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/notify"
+	authRepo "hello-gozero/internal/repository/auth"
+	"hello-gozero/internal/service/credential"
 	"hello-gozero/internal/svc"
+	"hello-gozero/pkg/i18n"
 )
 
+// resetRequestedMessage 申请重置密码的统一响应消息
+const resetRequestedMessage = "if the email is registered, a reset code has been sent"
+
 type ResetPasswordService struct {
 	Logger logx.Logger
 	ctx    context.Context
 	svcCtx *svc.ServiceContext
+	codes  *oneTimeCode
 }
 
-// NewResetPasswordService 获取单个用户
+// NewResetPasswordService 重置密码（忘记密码）
 func NewResetPasswordService(ctx context.Context, svcCtx *svc.ServiceContext) *ResetPasswordService {
 	return &ResetPasswordService{
 		Logger: logx.WithContext(ctx),
 		ctx:    ctx,
 		svcCtx: svcCtx,
+		codes: &oneTimeCode{
+			svcCtx:             svcCtx,
+			purpose:            authRepo.PurposePasswordReset,
+			conf:               svcCtx.Config.Auth.PasswordReset,
+			errInvalid:         ErrInvalidResetCode,
+			errTooManyRequests: ErrTooManyResetRequests,
+			errTooManyAttempts: ErrTooManyResetAttempts,
+		},
+	}
+}
+
+func (s *ResetPasswordService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// RequestReset 申请重置密码，向邮箱发送一次性重置验证码
+//
+// 为避免通过该接口枚举已注册的邮箱，邮箱未注册、未验证或账户不可用时同样返回成功，只是不发送验证码
+func (s *ResetPasswordService) RequestReset(req *userDto.RequestPasswordResetReq) (*userDto.RequestPasswordResetResp, error) {
+	email := normalizeEmail(req.Email)
+	if email == "" {
+		return nil, ErrMissingEmail
+	}
+	// 限制单个邮箱申请验证码的频率，防止邮件轰炸
+	if err := s.codes.throttle(s.ctx, email); err != nil {
+		return nil, err
+	}
+
+	resp := &userDto.RequestPasswordResetResp{Message: resetRequestedMessage}
+
+	existUser, err := s.svcCtx.Repository.User.GetByEmail(s.ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.Logger.WithContext(s.ctx).Infof("password reset requested for unknown email")
+			return resp, nil
+		}
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+	if existUser.Status != userConstant.StatusActive {
+		s.Logger.WithContext(s.ctx).Infof("password reset requested for inactive user(%s)", existUser.Username)
+		return resp, nil
+	}
+	// 未验证的邮箱不能证明账户归属，不能用于找回密码
+	if existUser.EmailVerifiedAt == nil {
+		s.Logger.WithContext(s.ctx).Infof("password reset requested for unverified email of user(%s)", existUser.Username)
+		return resp, nil
+	}
+
+	code, err := s.codes.issue(s.ctx, email, existUser.GetIDAsString(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.svcCtx.Notifier.Notify(s.ctx, &notify.Message{
+		Channel:  notify.ChannelEmail,
+		To:       existUser.Email,
+		Template: notify.TemplatePasswordResetCode,
+		Locale:   i18n.GetLocale(s.ctx),
+		Data: map[string]any{
+			"Code":          code,
+			"ExpireMinutes": s.codes.expireMinutes(),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reset code: %w", err)
+	}
+
+	return resp, nil
+}
+
+// VerifyResetCode 校验重置验证码是否有效，校验成功不会消费验证码
+// 用于客户端在用户输入新密码之前提前确认验证码
+func (s *ResetPasswordService) VerifyResetCode(req *userDto.VerifyResetPasswordTokenReq) (*userDto.VerifyResetPasswordTokenResp, error) {
+	if _, err := s.codes.check(s.ctx, normalizeEmail(req.Email), req.ResetCode); err != nil {
+		return nil, err
+	}
+	return &userDto.VerifyResetPasswordTokenResp{
+		Message: "reset code is valid",
+	}, nil
+}
+
+// ResetPassword 使用重置验证码设置新密码
+//
+// 重置成功后：
+//  1. 验证码立即作废（一次性）
+//  2. 吊销该用户的所有会话，使持有旧密码的设备全部下线
+func (s *ResetPasswordService) ResetPassword(req *userDto.ResetPasswordReq) (*userDto.ResetPasswordResp, error) {
+	email := normalizeEmail(req.Email)
+	code, err := s.codes.check(s.ctx, email, req.ResetCode)
+	if err != nil {
+		return nil, err
 	}
+
+	userID, err := uuid.Parse(code.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed user id %q in reset code: %w", code.UserID, err)
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByID(s.ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidResetCode
+		}
+		return nil, fmt.Errorf("failed to get user by id(%s): %w", code.UserID, err)
+	}
+	// 验证码下发后用户可能已更换邮箱或被禁用
+	if normalizeEmail(existUser.Email) != email || existUser.EmailVerifiedAt == nil || existUser.Status != userConstant.StatusActive {
+		return nil, ErrInvalidResetCode
+	}
+
+	// 密码策略检查
+	if err := credential.NewVerifier(s.svcCtx).CheckPolicyForUser(s.ctx, existUser, req.NewPassword); err != nil {
+		return nil, wrapPolicyError(err)
+	}
+
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", code.UserID))
+
+	// 与修改密码共用同一把锁，避免并发修改同一用户的密码
+	lockKey := userLockKey(s.ctx, "password", existUser.Username)
+	lockValue := uuid.New().String() // 锁的唯一标识
+	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
+
+	err = cache.WithLock(s.ctx, s.svcCtx.Infra.Redis.Client, lockKey, lockValue, lockTTL, func() error {
+		return s.resetPasswordWithinLock(email, code, existUser, req.NewPassword)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 吊销所有会话；密码已经更新成功，失败只记录日志
+	if _, err := s.svcCtx.Repository.Session.DeleteAllByUser(s.ctx, code.UserID); err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to revoke sessions for user(%s): %v", existUser.Username, err)
+	}
+	if err := s.svcCtx.Repository.CachedUser.DeleteByUsername(s.ctx, existUser.Username); err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to delete user cache for user(%s): %v", existUser.Username, err)
+	}
+
+	return &userDto.ResetPasswordResp{
+		Message: "password reset successfully",
+	}, nil
+}
+
+// resetPasswordWithinLock 在分布式锁保护下消费验证码并更新密码
+func (s *ResetPasswordService) resetPasswordWithinLock(email string, code *authRepo.VerifyCode, existUser *userEntity.User, newPassword string) error {
+	// 先消费验证码，并发使用同一个验证码时只有一个请求能够成功
+	if err := s.codes.consume(s.ctx, email, code); err != nil {
+		return err
+	}
+
+	// 对新密码进行哈希
+	verifier := credential.NewVerifier(s.svcCtx)
+	hashedPassword, err := verifier.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	existUser.Password = hashedPassword
+	existUser.PasswordChangedAt = &now
+	if err := s.svcCtx.Repository.User.Update(s.ctx, existUser); err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
+	}
+	verifier.RecordPasswordChange(s.ctx, existUser)
+	return nil
+}
+
+// normalizeEmail 规范化邮箱，用作缓存键与摘要的一部分，避免大小写不同绕过限流
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
@@ -0,0 +1,152 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	"hello-gozero/infra/cache"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/middleware"
+	userRepo "hello-gozero/internal/repository/user"
+	"hello-gozero/internal/svc"
+)
+
+type RestoreUserService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewRestoreUserService 恢复已删除用户（管理员）
+func NewRestoreUserService(ctx context.Context, svcCtx *svc.ServiceContext) *RestoreUserService {
+	return &RestoreUserService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *RestoreUserService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// RestoreUser 恢复指定用户名最近一次被软删除的用户，恢复后保留删除前的状态
+// 删除期间用户名、邮箱或手机号已被重新注册时拒绝恢复，返回 [ErrUsernameExists]、[ErrEmailExists] 或 [ErrPhoneExists]
+func (s *RestoreUserService) RestoreUser(req *userDto.RestoreUserReq) (*userDto.RestoreUserResp, error) {
+	if req.Username == "" {
+		return nil, ErrMissingUsername
+	}
+
+	deletedUser, err := s.svcCtx.Repository.User.GetDeletedByUsername(s.ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted user by username(%s): %w", req.Username, err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", deletedUser.GetIDAsString()))
+
+	// 已擦除或正在擦除个人数据的用户不能恢复
+	if _, err := s.svcCtx.Repository.Erasure.Get(s.ctx, deletedUser.ID); err == nil {
+		return nil, ErrUserNotFound
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get erasure: %w", err)
+	}
+
+	// 与注册使用同一把锁，避免恢复的同时注册同名用户；邮箱、手机号的冲突由数据库唯一索引兜底
+	lockKey := userLockKey(s.ctx, "register", deletedUser.Username)
+	lockValue := uuid.New().String() // 锁的唯一标识
+	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
+
+	err = cache.WithLock(s.ctx, s.svcCtx.Infra.Redis.Client, lockKey, lockValue, lockTTL, func() error {
+		return s.svcCtx.Repository.User.Transaction(s.ctx, func(txRepo userRepo.UserRepository) error {
+			if err := checkRestoreConflict(s.ctx, txRepo, deletedUser); err != nil {
+				return err
+			}
+
+			restored, err := txRepo.Restore(s.ctx, deletedUser.ID)
+			if err != nil {
+				var mysqlErr *mysql.MySQLError
+				if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+					// 通过再次查询确定是哪个字段冲突（不依赖索引名称）
+					if err := checkRestoreConflict(s.ctx, txRepo, deletedUser); err != nil {
+						return err
+					}
+					return errors.New("user already exists")
+				}
+				return fmt.Errorf("failed to restore user: %w", err)
+			}
+			if !restored {
+				// 并发恢复或已被彻底删除
+				return ErrUserNotFound
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	deletedUser.DeletedAt = gorm.DeletedAt{}
+
+	// 删除期间可能缓存了“用户不存在”的空值
+	if err := s.svcCtx.Repository.CachedUser.DeleteByUsername(s.ctx, deletedUser.Username); err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to delete user cache for user(%s): %v", deletedUser.Username, err)
+	}
+
+	var operator string
+	if principal := middleware.GetPrincipal(s.ctx); principal != nil {
+		operator = principal.Username
+	}
+	err = s.svcCtx.Publisher.Publish(s.ctx, &event.UserEvent{
+		EventType: event.TypeUserRestored,
+		UserID:    deletedUser.GetIDAsString(),
+		Data: map[string]interface{}{
+			"username": deletedUser.Username,
+			"operator": operator,
+		},
+	})
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to publish %s event: %v", event.TypeUserRestored, err)
+	}
+
+	return &userDto.RestoreUserResp{User: toUserDto(deletedUser)}, nil
+}
+
+// checkRestoreConflict 检查被删除用户的用户名、邮箱、手机号是否已被其他活跃用户占用
+func checkRestoreConflict(ctx context.Context, repo userRepo.UserRepository, deletedUser *userEntity.User) error {
+	exists, err := repo.ExistsByUsername(ctx, deletedUser.Username)
+	if err != nil {
+		return fmt.Errorf("failed to check username existence: %w", err)
+	}
+	if exists {
+		return ErrUsernameExists
+	}
+
+	if deletedUser.Email != "" {
+		existingUser, err := repo.GetByEmail(ctx, deletedUser.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to check email existence: %w", err)
+		}
+		if existingUser != nil {
+			return ErrEmailExists
+		}
+	}
+
+	exists, err = repo.ExistsByPhone(ctx, deletedUser.PhoneCountryCode, deletedUser.PhoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to check phone existence: %w", err)
+	}
+	if exists {
+		return ErrPhoneExists
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/authz"
+	"hello-gozero/internal/svc"
+)
+
+type UserRoleService struct {
+	Logger     logx.Logger
+	ctx        context.Context
+	svcCtx     *svc.ServiceContext
+	authorizer *authz.Authorizer
+}
+
+// NewUserRoleService 角色与权限
+func NewUserRoleService(ctx context.Context, svcCtx *svc.ServiceContext) *UserRoleService {
+	return &UserRoleService{
+		Logger:     logx.WithContext(ctx),
+		ctx:        ctx,
+		svcCtx:     svcCtx,
+		authorizer: authz.NewAuthorizer(svcCtx),
+	}
+}
+
+func (s *UserRoleService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// ListRoles 获取全部角色及其权限
+func (s *UserRoleService) ListRoles() (*userDto.ListRolesResp, error) {
+	roles, err := s.svcCtx.Repository.Role.ListRoles(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	roleIDs := make([]uint64, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	permissions, err := s.svcCtx.Repository.Role.ListRolePermissions(s.ctx, roleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	resp := &userDto.ListRolesResp{Roles: make([]userDto.Role, 0, len(roles))}
+	for _, role := range roles {
+		codes := permissions[role.ID]
+		if codes == nil {
+			codes = []string{}
+		}
+		resp.Roles = append(resp.Roles, userDto.Role{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: codes,
+		})
+	}
+	return resp, nil
+}
+
+// GetUserRoles 获取用户的角色（包括配置 Auth.Admins 带来的管理员角色）
+func (s *UserRoleService) GetUserRoles(req *userDto.GetUserRolesReq) (*userDto.UserRolesResp, error) {
+	existUser, err := s.getUser(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.authorizer.Roles(s.ctx, existUser.ID, existUser.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &userDto.UserRolesResp{Username: existUser.Username, Roles: roles}, nil
+}
+
+// GetUserPermissions 获取用户通过角色拥有的全部权限
+func (s *UserRoleService) GetUserPermissions(req *userDto.GetUserRolesReq) (*userDto.UserPermissionsResp, error) {
+	existUser, err := s.getUser(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := s.authorizer.Permissions(s.ctx, existUser.ID, existUser.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &userDto.UserPermissionsResp{Username: existUser.Username, Permissions: permissions}, nil
+}
+
+// UpdateUserRoles 用请求中的角色替换用户的全部角色
+//
+// 防止越权：
+//   - 不能修改自己的角色，返回 [ErrCannotChangeOwnRoles]
+//   - 新分配的角色包含调用方自身没有的权限时拒绝，返回 [ErrRoleNotGrantable]
+//
+// 配置 Auth.Admins 带来的管理员角色不受影响
+func (s *UserRoleService) UpdateUserRoles(req *userDto.UpdateUserRolesReq) (*userDto.UserRolesResp, error) {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, errors.New("missing principal")
+	}
+	existUser, err := s.getUser(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	// 按用户 ID 判断本人，访问令牌中的用户名在变更用户名之后可能已经过时
+	if existUser.GetIDAsString() == principal.UserID {
+		return nil, ErrCannotChangeOwnRoles
+	}
+
+	names := slices.Compact(slices.Sorted(slices.Values(req.Roles)))
+	roles, err := s.svcCtx.Repository.Role.GetRolesByNames(s.ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+	if len(roles) != len(names) {
+		return nil, ErrRoleNotFound
+	}
+
+	if err := s.checkGrantable(principal, existUser, roles); err != nil {
+		return nil, err
+	}
+
+	roleIDs := make([]uint64, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	if err := s.svcCtx.Repository.Role.SetUserRoles(s.ctx, existUser.ID, roleIDs, principal.Username); err != nil {
+		return nil, fmt.Errorf("failed to set roles for user(%s): %w", existUser.Username, err)
+	}
+	s.Logger.WithContext(s.ctx).Infof("roles of user(%s) set to %v by %s", existUser.Username, names, principal.Username)
+
+	effective, err := s.authorizer.Roles(s.ctx, existUser.ID, existUser.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.svcCtx.Publisher.Publish(s.ctx, &event.UserEvent{
+		EventType: event.TypeUserRolesChanged,
+		UserID:    existUser.GetIDAsString(),
+		Data: map[string]interface{}{
+			"username": existUser.Username,
+			"roles":    effective,
+			"operator": principal.Username,
+		},
+	})
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to publish %s event: %v", event.TypeUserRolesChanged, err)
+	}
+
+	return &userDto.UserRolesResp{Username: existUser.Username, Roles: effective}, nil
+}
+
+// checkGrantable 检查新分配给用户的角色（用户当前没有的）是否只包含调用方自身拥有的权限
+func (s *UserRoleService) checkGrantable(principal *middleware.Principal, existUser *userEntity.User, roles []*userEntity.Role) error {
+	current, err := s.svcCtx.Repository.Role.ListUserRoles(s.ctx, existUser.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list roles for user(%s): %w", existUser.Username, err)
+	}
+	var added []uint64
+	for _, role := range roles {
+		if !slices.ContainsFunc(current, func(r *userEntity.Role) bool { return r.ID == role.ID }) {
+			added = append(added, role.ID)
+		}
+	}
+	return ensureGrantable(s.ctx, s.svcCtx, s.authorizer, principal, added)
+}
+
+// ensureGrantable 检查角色是否只包含调用方自身拥有的权限，否则返回 [ErrRoleNotGrantable]
+func ensureGrantable(ctx context.Context, svcCtx *svc.ServiceContext, authorizer *authz.Authorizer, principal *middleware.Principal, roleIDs []uint64) error {
+	if len(roleIDs) == 0 {
+		return nil
+	}
+
+	permissions, err := svcCtx.Repository.Role.ListRolePermissions(ctx, roleIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list role permissions: %w", err)
+	}
+	for _, codes := range permissions {
+		for _, code := range codes {
+			allowed, err := authorizer.HasPermission(ctx, principal, code)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return ErrRoleNotGrantable
+			}
+		}
+	}
+	return nil
+}
+
+// getUser 根据用户名获取用户
+func (s *UserRoleService) getUser(username string) (*userEntity.User, error) {
+	if username == "" {
+		return nil, ErrMissingUsername
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by username(%s): %w", username, err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", existUser.GetIDAsString()))
+	return existUser, nil
+}
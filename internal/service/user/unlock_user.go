@@ -0,0 +1,87 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userDto "hello-gozero/internal/dto/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+)
+
+type UnlockUserService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewUnlockUserService 解锁用户（管理员）
+func NewUnlockUserService(ctx context.Context, svcCtx *svc.ServiceContext) *UnlockUserService {
+	return &UnlockUserService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *UnlockUserService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// UnlockUser 清除用户的认证失败计数与暂时锁定，账户处于锁定状态时恢复为正常状态
+// 已禁用的账户不受影响
+func (s *UnlockUserService) UnlockUser(req *userDto.UnlockUserReq) (*userDto.UnlockUserResp, error) {
+	if req.Username == "" {
+		return nil, ErrMissingUsername
+	}
+
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by username(%s): %w", req.Username, err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", existUser.GetIDAsString()))
+
+	if err := lockout.NewGuard(s.svcCtx).Unlock(s.ctx, existUser.Username); err != nil {
+		return nil, err
+	}
+
+	var operator string
+	if principal := middleware.GetPrincipal(s.ctx); principal != nil {
+		operator = principal.Username
+	}
+
+	if existUser.Status == userConstant.StatusLocked {
+		err := accountstatus.NewManager(s.svcCtx).Transition(s.ctx, existUser, accountstatus.Change{
+			To:     userConstant.StatusActive,
+			Reason: "unlocked by administrator",
+			Actor:  operator,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to unlock user(%s): %w", existUser.Username, err)
+		}
+	}
+	err = s.svcCtx.Publisher.Publish(s.ctx, &event.UserEvent{
+		EventType: event.TypeUserUnlocked,
+		UserID:    existUser.GetIDAsString(),
+		Data: map[string]interface{}{
+			"username": existUser.Username,
+			"operator": operator,
+		},
+	})
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to publish %s event: %v", event.TypeUserUnlocked, err)
+	}
+
+	return &userDto.UnlockUserResp{Message: "user unlocked"}, nil
+}
@@ -2,17 +2,21 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
-	"golang.org/x/crypto/bcrypt"
 
 	"hello-gozero/infra/cache"
 	"hello-gozero/internal/dto/user"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/service/credential"
+	"hello-gozero/internal/service/lockout"
 	"hello-gozero/internal/svc"
-	passwordUtil "hello-gozero/internal/utils/password"
+	"hello-gozero/internal/utils/password"
 )
 
 type UpdatePasswordService struct {
@@ -35,11 +39,17 @@ func (s *UpdatePasswordService) GetCtx() context.Context {
 }
 
 // UpdatePassword 更新用户密码
+// 旧密码校验失败计入认证失败次数，连续失败次数过多时暂时锁定（见 [lockout.Guard]）
 func (s *UpdatePasswordService) UpdatePassword(req *user.UpdatePasswordReq) (*user.UpdatePasswordResp, error) {
 	if req.Username == "" {
 		return nil, ErrMissingUsername
 	}
 
+	guard := lockout.NewGuard(s.svcCtx)
+	if err := guard.Check(s.ctx, req.Username, req.ClientIP); err != nil {
+		return nil, err
+	}
+
 	// ============================================================
 	// 使用 Redis 分布式锁避免并发修改密码冲突
 	// ============================================================
@@ -48,7 +58,7 @@ func (s *UpdatePasswordService) UpdatePassword(req *user.UpdatePasswordReq) (*us
 	//   2. 在应用层面序列化并发请求，保证修改的原子性
 	//   3. 避免读取-修改-写入的竞态条件
 	// 锁的粒度：基于用户名
-	lockKey := fmt.Sprintf("lock:user:password:%s", req.Username)
+	lockKey := userLockKey(s.ctx, "password", req.Username)
 	lockValue := uuid.New().String() // 锁的唯一标识
 	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
 
@@ -57,8 +67,15 @@ func (s *UpdatePasswordService) UpdatePassword(req *user.UpdatePasswordReq) (*us
 		return s.updatePasswordWithinLock(req)
 	})
 	if err != nil {
+		if errors.Is(err, ErrOldPasswordMismatch) {
+			guard.RecordFailure(s.ctx, req.Username, req.ClientIP)
+		}
 		return nil, err
 	}
+	guard.RecordSuccess(s.ctx, req.Username)
+
+	// 密码修改成功后吊销除当前会话以外的所有会话，使可能泄露的旧密码登录的设备立即下线
+	s.revokeOtherSessions()
 
 	return &user.UpdatePasswordResp{
 		Message: "password updated successfully",
@@ -76,34 +93,66 @@ func (s *UpdatePasswordService) updatePasswordWithinLock(req *user.UpdatePasswor
 		return ErrUserNotFound
 	}
 
-	// 对比旧密码（使用bcrypt比较哈希值）
-	err = bcrypt.CompareHashAndPassword([]byte(existUser.Password), []byte(req.OldPassword))
-	if err != nil {
+	// 对比旧密码
+	verifier := credential.NewVerifier(s.svcCtx)
+	if !verifier.Verify(s.ctx, existUser, req.OldPassword) {
 		return ErrOldPasswordMismatch
 	}
 
+	// 旧密码正确后再检查账户状态，禁用、锁定或暂停的账户不能修改密码
+	if err := accountstatus.NewManager(s.svcCtx).Check(s.ctx, existUser); err != nil {
+		return err
+	}
+
 	// 检查新密码是否与旧密码相同
 	if req.OldPassword == req.NewPassword {
 		return ErrNewPasswordSameAsOld
 	}
 
-	// 密码检查
-	checker := passwordUtil.NewDefaultPasswordChecker()
-	if err := checker.Check(req.NewPassword); err != nil {
-		return fmt.Errorf("new password does not meet complexity requirements: %w", err)
+	// 密码策略检查
+	if err := verifier.CheckPolicyForUser(s.ctx, existUser, req.NewPassword); err != nil {
+		return wrapPolicyError(err)
 	}
 
 	// 对新密码进行哈希
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	hashedPassword, err := verifier.Hash(req.NewPassword)
 	if err != nil {
-		return fmt.Errorf("failed to hash new password: %w", err)
+		return err
 	}
 
 	// 更新新的密码
-	existUser.Password = string(hashedPassword)
+	now := time.Now()
+	existUser.Password = hashedPassword
+	existUser.PasswordChangedAt = &now
 	if err := s.svcCtx.Repository.User.Update(s.ctx, existUser); err != nil {
 		return fmt.Errorf("failed to update user password: %w", err)
 	}
+	verifier.RecordPasswordChange(s.ctx, existUser)
 
 	return nil
 }
+
+// revokeOtherSessions 吊销调用方除当前会话以外的所有会话
+// 密码已经更新成功，吊销失败只记录日志，不影响本次请求的结果
+func (s *UpdatePasswordService) revokeOtherSessions() {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return
+	}
+
+	revoked, err := s.svcCtx.Repository.Session.DeleteAllByUser(s.ctx, principal.UserID, principal.SessionID)
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to revoke sessions for user(%s): %v", principal.Username, err)
+		return
+	}
+	s.Logger.WithContext(s.ctx).Infof("revoked %d other sessions for user(%s) after password change", revoked, principal.Username)
+}
+
+// wrapPolicyError 新密码不符合密码策略时包装为 [ErrWeakPassword]，其他错误（如查询密码历史失败）原样返回
+func wrapPolicyError(err error) error {
+	var policyErr *password.PolicyError
+	if errors.As(err, &policyErr) {
+		return fmt.Errorf("%w: %w", ErrWeakPassword, err)
+	}
+	return err
+}
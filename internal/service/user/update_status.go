@@ -0,0 +1,125 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	userConstant "hello-gozero/internal/constant/user"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/service/lockout"
+	"hello-gozero/internal/svc"
+)
+
+type UpdateUserStatusService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewUpdateUserStatusService 更新用户状态（管理员）
+func NewUpdateUserStatusService(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateUserStatusService {
+	return &UpdateUserStatusService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *UpdateUserStatusService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// UpdateStatus 按状态机将用户迁移到指定状态，迁移到暂停状态时需要指定到期时间
+func (s *UpdateUserStatusService) UpdateStatus(req *userDto.UpdateUserStatusReq) (*userDto.UpdateUserStatusResp, error) {
+	if req.Status < math.MinInt8 || req.Status > math.MaxInt8 || !accountstatus.Valid(int8(req.Status)) {
+		return nil, fmt.Errorf("%w: unknown status %d", ErrInvalidStatus, req.Status)
+	}
+
+	change := accountstatus.Change{
+		To:     int8(req.Status),
+		Reason: req.Reason,
+	}
+	if req.SuspendedUntil != "" {
+		until, err := time.Parse(time.RFC3339, req.SuspendedUntil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid suspended_until %q", ErrInvalidStatus, req.SuspendedUntil)
+		}
+		change.SuspendedUntil = &until
+	}
+	return s.changeStatus(req.Username, change)
+}
+
+// Activate 激活用户，等同于迁移到正常状态
+func (s *UpdateUserStatusService) Activate(req *userDto.ChangeUserStatusReq) (*userDto.UpdateUserStatusResp, error) {
+	return s.changeStatus(req.Username, accountstatus.Change{
+		To:     userConstant.StatusActive,
+		Reason: req.Reason,
+	})
+}
+
+// Deactivate 停用用户，等同于迁移到禁用状态
+func (s *UpdateUserStatusService) Deactivate(req *userDto.ChangeUserStatusReq) (*userDto.UpdateUserStatusResp, error) {
+	return s.changeStatus(req.Username, accountstatus.Change{
+		To:     userConstant.StatusDisabled,
+		Reason: req.Reason,
+	})
+}
+
+// changeStatus 记录操作人并执行状态迁移
+// 从锁定状态恢复时同时清除认证失败计数，与解锁用户的效果一致
+func (s *UpdateUserStatusService) changeStatus(username string, change accountstatus.Change) (*userDto.UpdateUserStatusResp, error) {
+	if username == "" {
+		return nil, ErrMissingUsername
+	}
+	if utf8.RuneCountInString(change.Reason) > 255 {
+		return nil, fmt.Errorf("%w: reason is too long", ErrInvalidStatus)
+	}
+
+	existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by username(%s): %w", username, err)
+	}
+	s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", existUser.GetIDAsString()))
+
+	if principal := middleware.GetPrincipal(s.ctx); principal != nil {
+		change.Actor = principal.Username
+	}
+
+	wasLocked := existUser.Status == userConstant.StatusLocked
+	if err := accountstatus.NewManager(s.svcCtx).Transition(s.ctx, existUser, change); err != nil {
+		return nil, err
+	}
+	if wasLocked && existUser.Status == userConstant.StatusActive {
+		if err := lockout.NewGuard(s.svcCtx).Unlock(s.ctx, existUser.Username); err != nil {
+			s.Logger.WithContext(s.ctx).Errorf("failed to clear lockout for user(%s): %v", existUser.Username, err)
+		}
+	}
+
+	return toUserStatusDto(existUser), nil
+}
+
+// toUserStatusDto 转换为用户状态响应
+func toUserStatusDto(user *userEntity.User) *userDto.UpdateUserStatusResp {
+	resp := &userDto.UpdateUserStatusResp{
+		Username:   user.Username,
+		Status:     int(user.Status),
+		StatusName: accountstatus.Name(user.Status),
+	}
+	if user.SuspendedUntil != nil {
+		resp.SuspendedUntil = user.SuspendedUntil.Format(time.RFC3339)
+	}
+	return resp
+}
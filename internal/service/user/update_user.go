@@ -0,0 +1,299 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	"hello-gozero/infra/cache"
+	"hello-gozero/internal/constant/rbac"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/service/authz"
+	"hello-gozero/internal/svc"
+)
+
+type UpdateUserService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewUpdateUserService 更新用户信息
+func NewUpdateUserService(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateUserService {
+	return &UpdateUserService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (s *UpdateUserService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// UpdateUser 完整更新用户资料（昵称、邮箱、手机号）
+//
+//   - 邮箱：本人更换与 [ChangeEmailService.ChangeEmail] 相同，需要当前密码；拥有 user:update:any 权限的调用方为其他用户更换时不需要目标用户的密码；
+//     两种情况都向新邮箱发送验证码，新邮箱验证通过后才会生效
+//   - 昵称与手机号：立即生效，手机号与注册时一样不能与其他用户重复
+//   - 携带 If-Match 时只在资源版本一致时更新（乐观并发控制），否则返回 [ErrPreconditionFailed]
+func (s *UpdateUserService) UpdateUser(req *userDto.UpdateUserReq) (*userDto.UpdateUserResp, error) {
+	if req.Username == "" {
+		return nil, ErrMissingUsername
+	}
+	return s.updateWithLock(req.Username, req.IfMatch, req.ClientIP, func(*userEntity.User) (userDto.UserProfile, string, error) {
+		return req.Profile(), req.Password, nil
+	})
+}
+
+// PatchUser 按 JSON Merge Patch（RFC 7396）部分更新用户资料，合并后的资料按 [UpdateUserService.UpdateUser] 相同的规则更新
+func (s *UpdateUserService) PatchUser(req *userDto.PatchUserReq) (*userDto.UpdateUserResp, error) {
+	if req.Username == "" {
+		return nil, ErrMissingUsername
+	}
+	return s.updateWithLock(req.Username, req.IfMatch, req.ClientIP, func(existUser *userEntity.User) (userDto.UserProfile, string, error) {
+		return applyMergePatch(existUser, req.Patch)
+	})
+}
+
+// updateWithLock 在分布式锁保护下读取用户、校验资源版本并更新，build 根据当前用户构建更新后的资料与当前密码
+func (s *UpdateUserService) updateWithLock(
+	username, ifMatch, clientIP string,
+	build func(existUser *userEntity.User) (userDto.UserProfile, string, error),
+) (*userDto.UpdateUserResp, error) {
+	// 同一用户的资料更新串行执行，保证「校验版本 - 更新」的原子性
+	lockKey := userLockKey(s.ctx, "profile", username)
+	lockValue := uuid.New().String() // 锁的唯一标识
+	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
+
+	var resp *userDto.UpdateUserResp
+	err := cache.WithLock(s.ctx, s.svcCtx.Infra.Redis.Client, lockKey, lockValue, lockTTL, func() error {
+		// 从数据库读取，避免使用缓存中的旧数据校验版本
+		existUser, err := s.svcCtx.Repository.User.GetByUsername(s.ctx, username)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user by name(%s): %w", username, err)
+		}
+		s.ctx = logx.ContextWithFields(s.ctx, logx.Field("user_id", existUser.GetIDAsString()))
+
+		if !etagMatches(ifMatch, userETag(existUser)) {
+			return ErrPreconditionFailed
+		}
+
+		profile, password, err := build(existUser)
+		if err != nil {
+			return err
+		}
+		resp, err = s.update(existUser, profile, password, clientIP)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// update 校验并更新用户资料
+func (s *UpdateUserService) update(existUser *userEntity.User, profile userDto.UserProfile, password, clientIP string) (*userDto.UpdateUserResp, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	emailChanged := normalizeEmail(profile.Email) != normalizeEmail(existUser.Email)
+	if emailChanged && profile.Email == "" {
+		return nil, ErrEmailRequired
+	}
+
+	var changed []string
+	if profile.Nickname != existUser.Nickname {
+		changed = append(changed, "nickname")
+	}
+	phoneChanged := profile.PhoneCountryCode != existUser.PhoneCountryCode || profile.PhoneNumber != existUser.PhoneNumber
+	if phoneChanged {
+		changed = append(changed, "phone")
+		// 检查手机号是否已被其他用户占用，数据库唯一索引作为最后防线
+		if profile.PhoneNumber != "" {
+			other, err := s.svcCtx.Repository.User.GetByPhone(s.ctx, profile.PhoneCountryCode, profile.PhoneNumber)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to check phone existence: %w", err)
+			}
+			if other != nil && !bytes.Equal(other.ID, existUser.ID) {
+				return nil, ErrPhoneExists
+			}
+		}
+	}
+
+	// 先申请更换邮箱：密码错误、邮箱已被占用等情况下整个请求失败，不会只更新部分资料
+	if emailChanged {
+		requirePassword, err := emailChangeRequiresPassword(s.ctx, authz.NewAuthorizer(s.svcCtx), middleware.GetPrincipal(s.ctx), existUser)
+		if err != nil {
+			return nil, err
+		}
+		changeEmail := NewChangeEmailService(s.ctx, s.svcCtx)
+		if requirePassword {
+			_, err = changeEmail.ChangeEmail(&userDto.ChangeEmailReq{
+				Username: existUser.Username,
+				Email:    profile.Email,
+				Password: password,
+				ClientIP: clientIP,
+			})
+		} else {
+			err = changeEmail.requestChange(existUser, profile.Email)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(changed) > 0 {
+		if err := s.saveProfile(existUser, profile); err != nil {
+			return nil, err
+		}
+		s.publishUpdated(existUser, changed)
+	}
+
+	return &userDto.UpdateUserResp{
+		User:                     toUserDto(existUser),
+		EmailPendingVerification: emailChanged,
+		ETag:                     userETag(existUser),
+	}, nil
+}
+
+// emailChangeRequiresPassword 判断更换邮箱时是否需要校验目标用户的当前密码
+// 本人更换需要当前密码；为其他用户更换时调用方不知道目标用户的密码，拥有 user:update:any 权限时不校验，否则仍然要求密码
+func emailChangeRequiresPassword(ctx context.Context, checker middleware.PermissionChecker, principal *middleware.Principal, existUser *userEntity.User) (bool, error) {
+	if principal == nil || principal.UserID == existUser.GetIDAsString() {
+		return true, nil
+	}
+	allowed, err := checker.HasPermission(ctx, principal, rbac.PermUserUpdateAny)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission %s: %w", rbac.PermUserUpdateAny, err)
+	}
+	return !allowed, nil
+}
+
+// saveProfile 使用延迟双删策略更新昵称与手机号，成功后同步更新 existUser
+func (s *UpdateUserService) saveProfile(existUser *userEntity.User, profile userDto.UserProfile) error {
+	// 第一次删除缓存，失败时不继续更新数据库（见 [DeleteUserService.DeleteUser]）
+	if err := s.svcCtx.Repository.CachedUser.DeleteByUsername(s.ctx, existUser.Username); err != nil {
+		return fmt.Errorf("first cache delete failed for user(%s): %w", existUser.Username, err)
+	}
+
+	err := s.svcCtx.Repository.User.UpdateProfile(s.ctx, existUser.ID, profile.Nickname, profile.PhoneCountryCode, profile.PhoneNumber)
+	if err != nil {
+		// 并发占用同一手机号时由数据库唯一索引兜底
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return ErrPhoneExists
+		}
+		return fmt.Errorf("failed to update profile for user(%s): %w", existUser.Username, err)
+	}
+
+	// 延迟双删：异步延迟后再次删除缓存
+	deleteUserCacheLater(s.ctx, s.Logger, s.svcCtx, existUser.Username)
+
+	existUser.Nickname = profile.Nickname
+	existUser.PhoneCountryCode = profile.PhoneCountryCode
+	existUser.PhoneNumber = profile.PhoneNumber
+	return nil
+}
+
+// publishUpdated 发布用户信息更新事件，只包含变更的字段名，不包含字段值；发布失败只记录日志
+func (s *UpdateUserService) publishUpdated(existUser *userEntity.User, changed []string) {
+	err := s.svcCtx.Publisher.Publish(s.ctx, &event.UserEvent{
+		EventType: event.TypeUserUpdated,
+		UserID:    existUser.GetIDAsString(),
+		Data: map[string]interface{}{
+			"username": existUser.Username,
+			"fields":   changed,
+		},
+	})
+	if err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to publish %s event: %v", event.TypeUserUpdated, err)
+	}
+}
+
+// applyMergePatch 将合并补丁应用到用户当前的资料上，返回合并后的资料与补丁中的当前密码
+// 字段不存在表示不修改，null 表示清空；不支持嵌套对象与未知字段
+func applyMergePatch(existUser *userEntity.User, patch map[string]json.RawMessage) (userDto.UserProfile, string, error) {
+	profile := userDto.UserProfile{
+		Nickname:         existUser.Nickname,
+		Email:            existUser.Email,
+		PhoneCountryCode: existUser.PhoneCountryCode,
+		PhoneNumber:      existUser.PhoneNumber,
+	}
+	var password string
+	fields := map[string]*string{
+		"nickname":           &profile.Nickname,
+		"email":              &profile.Email,
+		"phone_country_code": &profile.PhoneCountryCode,
+		"phone_number":       &profile.PhoneNumber,
+		"password":           &password,
+	}
+
+	for key, raw := range patch {
+		dest, ok := fields[key]
+		if !ok {
+			return profile, "", fmt.Errorf("%w: unsupported field %q", ErrInvalidPatch, key)
+		}
+		var value *string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return profile, "", fmt.Errorf("%w: field %q must be a string or null", ErrInvalidPatch, key)
+		}
+		*dest = ""
+		if value != nil {
+			*dest = *value
+		}
+	}
+	return profile, password, nil
+}
+
+// userETag 计算用户资料的资源版本（强 ETag），资料内容变化时版本随之变化
+// 不使用 updated_at：数据库只保存到秒，同一秒内的多次修改无法区分
+func userETag(user *userEntity.User) string {
+	h := sha256.New()
+	for _, field := range []string{
+		string(user.ID),
+		user.Username,
+		user.Nickname,
+		user.Email,
+		user.PhoneCountryCode,
+		user.PhoneNumber,
+		fmt.Sprint(user.Status),
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// etagMatches 判断 If-Match 请求头是否与当前资源版本匹配
+// 为空表示不校验；支持 "*" 与逗号分隔的多个版本，按弱比较忽略 W/ 前缀
+func etagMatches(ifMatch, etag string) bool {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
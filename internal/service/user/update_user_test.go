@@ -0,0 +1,72 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"hello-gozero/internal/constant/rbac"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/middleware"
+)
+
+// fakePermissions 内存权限表，用于替代角色仓库
+type fakePermissions struct {
+	granted map[string][]string // 用户名 -> 权限
+	err     error
+}
+
+func (f *fakePermissions) HasPermission(_ context.Context, principal *middleware.Principal, permission string) (bool, error) {
+	for _, granted := range f.granted[principal.Username] {
+		if granted == permission {
+			return true, f.err
+		}
+	}
+	return false, f.err
+}
+
+func TestEmailChangeRequiresPassword(t *testing.T) {
+	aliceID := uuid.New()
+	alice := &userEntity.User{ID: aliceID[:], Username: "alice"}
+	permissions := &fakePermissions{granted: map[string][]string{
+		"admin": {rbac.PermUserUpdateAny},
+		"alice": {rbac.PermUserUpdateAny},
+	}}
+
+	cases := []struct {
+		name      string
+		principal *middleware.Principal
+		want      bool
+	}{
+		{"self update", &middleware.Principal{UserID: aliceID.String(), Username: "alice"}, true},
+		{"admin updates other user", &middleware.Principal{UserID: uuid.NewString(), Username: "admin"}, false},
+		{"other user without permission", &middleware.Principal{UserID: uuid.NewString(), Username: "bob"}, true},
+		{"unauthenticated", nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := emailChangeRequiresPassword(context.Background(), permissions, tc.principal, alice)
+			if err != nil {
+				t.Fatalf("emailChangeRequiresPassword() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("requires password = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEmailChangeRequiresPasswordCheckerError(t *testing.T) {
+	aliceID := uuid.New()
+	alice := &userEntity.User{ID: aliceID[:], Username: "alice"}
+	errBackend := errors.New("backend down")
+	permissions := &fakePermissions{err: errBackend}
+
+	_, err := emailChangeRequiresPassword(context.Background(), permissions, &middleware.Principal{UserID: uuid.NewString(), Username: "admin"}, alice)
+	if !errors.Is(err, errBackend) {
+		t.Fatalf("error = %v, want %v", err, errBackend)
+	}
+}
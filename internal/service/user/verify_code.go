@@ -0,0 +1,139 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"hello-gozero/internal/config"
+	authRepo "hello-gozero/internal/repository/auth"
+	"hello-gozero/internal/svc"
+	"hello-gozero/internal/utils/token"
+)
+
+// 验证码位数
+const verifyCodeDigits = 6
+
+// oneTimeCode 一次性验证码的下发与校验，供忘记密码、邮箱验证等流程复用
+//
+// 暴力破解防护：
+//   - 单个接收方在时间窗口内申请验证码的次数有上限，防止消息轰炸
+//   - 单个验证码失败次数达到上限后立即作废，需要重新申请
+//   - 单个接收方在时间窗口内的失败次数达到上限后，暂时无法继续校验
+type oneTimeCode struct {
+	svcCtx  *svc.ServiceContext
+	purpose authRepo.VerifyCodePurpose
+	conf    config.VerifyCodeConfig
+
+	// 各流程对外暴露的错误
+	errInvalid         error // 验证码无效
+	errTooManyRequests error // 申请过于频繁
+	errTooManyAttempts error // 校验失败次数过多
+}
+
+// throttle 累计接收方申请验证码的次数，超过上限时返回错误
+// 应在判断接收方是否存在之前调用，避免通过限流行为的差异枚举接收方
+func (c *oneTimeCode) throttle(ctx context.Context, target string) error {
+	requests, err := c.svcCtx.Repository.VerifyCode.IncrCounter(ctx, c.purpose, authRepo.CounterRequests, target, c.window())
+	if err != nil {
+		return fmt.Errorf("failed to count %s requests: %w", c.purpose, err)
+	}
+	if requests > c.conf.EmailMaxRequests {
+		return c.errTooManyRequests
+	}
+	return nil
+}
+
+// issue 为接收方生成并保存新的验证码，返回验证码明文（只用于发送，不会被保存）
+// 重新申请时覆盖旧验证码，同一接收方同一时间只有一个有效的验证码
+func (c *oneTimeCode) issue(ctx context.Context, target, userID, payload string) (string, error) {
+	code, err := token.NewNumericCode(verifyCodeDigits)
+	if err != nil {
+		return "", err
+	}
+	err = c.svcCtx.Repository.VerifyCode.Save(ctx, c.purpose, target, &authRepo.VerifyCode{
+		UserID:   userID,
+		CodeHash: token.HashVerifyCode(target, code),
+		Payload:  payload,
+	}, c.expire())
+	if err != nil {
+		return "", fmt.Errorf("failed to save %s code: %w", c.purpose, err)
+	}
+	return code, nil
+}
+
+// check 校验验证码，返回验证码记录；校验成功不会消费验证码
+func (c *oneTimeCode) check(ctx context.Context, target, code string) (*authRepo.VerifyCode, error) {
+	if target == "" || code == "" {
+		return nil, c.errInvalid
+	}
+	repo := c.svcCtx.Repository.VerifyCode
+	logger := logx.WithContext(ctx)
+
+	failures, err := repo.GetCounter(ctx, c.purpose, authRepo.CounterFailures, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s failure counter: %w", c.purpose, err)
+	}
+	if failures >= c.conf.EmailMaxAttempts {
+		return nil, c.errTooManyAttempts
+	}
+
+	record, err := repo.Get(ctx, c.purpose, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s code: %w", c.purpose, err)
+	}
+	if record != nil && token.CompareVerifyCode(target, code, record.CodeHash) {
+		return record, nil
+	}
+
+	// 校验失败，累计失败次数
+	if _, err := repo.IncrCounter(ctx, c.purpose, authRepo.CounterFailures, target, c.window()); err != nil {
+		logger.Errorf("failed to count %s failures: %v", c.purpose, err)
+	}
+	if record != nil {
+		attempts, err := repo.IncrAttempts(ctx, c.purpose, target)
+		if err != nil {
+			logger.Errorf("failed to count %s code attempts: %v", c.purpose, err)
+		} else if attempts >= c.conf.CodeMaxAttempts {
+			// 失败次数达到上限，验证码作废
+			if _, err := repo.Delete(ctx, c.purpose, target, record.CodeHash); err != nil {
+				logger.Errorf("failed to delete %s code: %v", c.purpose, err)
+			}
+		}
+	}
+	return nil, c.errInvalid
+}
+
+// consume 消费 [oneTimeCode.check] 校验通过的验证码，并发使用同一个验证码时只有一个调用方能够成功
+// 只删除摘要与 record 一致的验证码，校验之后重新下发的验证码不受影响（当前请求视为验证码无效）；
+// 消费成功后同时清理失败计数
+func (c *oneTimeCode) consume(ctx context.Context, target string, record *authRepo.VerifyCode) error {
+	repo := c.svcCtx.Repository.VerifyCode
+
+	consumed, err := repo.Delete(ctx, c.purpose, target, record.CodeHash)
+	if err != nil {
+		return fmt.Errorf("failed to consume %s code: %w", c.purpose, err)
+	}
+	if !consumed {
+		return c.errInvalid
+	}
+	if err := repo.DeleteCounter(ctx, c.purpose, authRepo.CounterFailures, target); err != nil {
+		logx.WithContext(ctx).Errorf("failed to reset %s failure counter: %v", c.purpose, err)
+	}
+	return nil
+}
+
+// expireMinutes 验证码有效期（分钟），用于通知模板
+func (c *oneTimeCode) expireMinutes() int64 {
+	return c.conf.CodeExpire / 60
+}
+
+func (c *oneTimeCode) expire() time.Duration {
+	return time.Duration(c.conf.CodeExpire) * time.Second
+}
+
+func (c *oneTimeCode) window() time.Duration {
+	return time.Duration(c.conf.RateLimitWindow) * time.Second
+}
@@ -0,0 +1,232 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+
+	"hello-gozero/infra/cache"
+	userConstant "hello-gozero/internal/constant/user"
+	userDto "hello-gozero/internal/dto/user"
+	userEntity "hello-gozero/internal/entity/user"
+	"hello-gozero/internal/middleware"
+	"hello-gozero/internal/notify"
+	authRepo "hello-gozero/internal/repository/auth"
+	"hello-gozero/internal/service/accountstatus"
+	"hello-gozero/internal/svc"
+	"hello-gozero/pkg/i18n"
+)
+
+type VerifyEmailService struct {
+	Logger logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	codes  *oneTimeCode
+}
+
+// NewVerifyEmailService 邮箱验证
+func NewVerifyEmailService(ctx context.Context, svcCtx *svc.ServiceContext) *VerifyEmailService {
+	return &VerifyEmailService{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		codes:  newEmailVerifyCode(svcCtx),
+	}
+}
+
+func (s *VerifyEmailService) GetCtx() context.Context {
+	return s.ctx
+}
+
+// newEmailVerifyCode 邮箱验证码
+// 以用户 ID 作为接收方标识，每个用户同一时间只有一个待验证的邮箱，待验证的邮箱保存在验证码的附加数据中
+func newEmailVerifyCode(svcCtx *svc.ServiceContext) *oneTimeCode {
+	return &oneTimeCode{
+		svcCtx:             svcCtx,
+		purpose:            authRepo.PurposeEmailVerify,
+		conf:               svcCtx.Config.Auth.EmailVerify,
+		errInvalid:         ErrInvalidVerifyCode,
+		errTooManyRequests: ErrTooManyVerifyRequests,
+		errTooManyAttempts: ErrTooManyVerifyAttempts,
+	}
+}
+
+// sendEmailVerification 向待验证的邮箱发送验证码，供注册、重新发送与更换邮箱复用
+func sendEmailVerification(ctx context.Context, svcCtx *svc.ServiceContext, codes *oneTimeCode, user *userEntity.User, email string) error {
+	userID := user.GetIDAsString()
+	if err := codes.throttle(ctx, userID); err != nil {
+		return err
+	}
+	code, err := codes.issue(ctx, userID, userID, email)
+	if err != nil {
+		return err
+	}
+
+	err = svcCtx.Notifier.Notify(ctx, &notify.Message{
+		Channel:  notify.ChannelEmail,
+		To:       email,
+		Template: notify.TemplateEmailVerifyCode,
+		Locale:   i18n.GetLocale(ctx),
+		Data: map[string]any{
+			"Username":      user.Username,
+			"Code":          code,
+			"ExpireMinutes": codes.expireMinutes(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email verification code: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail 使用验证码验证邮箱
+//
+// 验证通过后：
+//   - 注册时填写的邮箱：标记为已验证，待验证状态的账户进入正常状态
+//   - 申请更换的新邮箱：替换原邮箱并标记为已验证，同时向原邮箱发送安全提醒
+func (s *VerifyEmailService) VerifyEmail(req *userDto.VerifyEmailReq) (*userDto.VerifyEmailResp, error) {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, ErrUserNotFound
+	}
+
+	record, err := s.codes.check(s.ctx, principal.UserID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	email := record.Payload
+
+	userID, err := uuid.Parse(principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed user id %q: %w", principal.UserID, err)
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByID(s.ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by id(%s): %w", principal.UserID, err)
+	}
+	oldEmail := existUser.Email
+
+	// 邮箱维度的锁，避免同一个邮箱被并发验证到多个账户（与注册之间的冲突由数据库唯一索引兜底）
+	lockKey := userLockKey(s.ctx, "email", normalizeEmail(email))
+	lockValue := uuid.New().String() // 锁的唯一标识
+	lockTTL := 10 * time.Second      // 锁的过期时间（防止死锁）
+
+	err = cache.WithLock(s.ctx, s.svcCtx.Infra.Redis.Client, lockKey, lockValue, lockTTL, func() error {
+		return s.verifyEmailWithinLock(existUser, record, email)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 待验证的账户验证邮箱后进入正常状态
+	if existUser.Status == userConstant.StatusPendingVerification {
+		err := accountstatus.NewManager(s.svcCtx).Transition(s.ctx, existUser, accountstatus.Change{
+			To:     userConstant.StatusActive,
+			Reason: "email verified",
+			Actor:  existUser.Username,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to activate user(%s): %w", existUser.Username, err)
+		}
+	}
+
+	if err := s.svcCtx.Repository.CachedUser.DeleteByUsername(s.ctx, existUser.Username); err != nil {
+		s.Logger.WithContext(s.ctx).Errorf("failed to delete user cache for user(%s): %v", existUser.Username, err)
+	}
+
+	// 更换邮箱时通知原邮箱，账户被盗用时原邮箱的所有者可以及时发现
+	if oldEmail != "" && normalizeEmail(oldEmail) != normalizeEmail(email) {
+		err := s.svcCtx.Notifier.Notify(s.ctx, &notify.Message{
+			Channel:  notify.ChannelEmail,
+			To:       oldEmail,
+			Template: notify.TemplateSecurityAlert,
+			Locale:   i18n.GetLocale(s.ctx),
+			Data: map[string]any{
+				"Username": existUser.Username,
+				"Event":    "email changed to " + email,
+			},
+		})
+		if err != nil {
+			s.Logger.WithContext(s.ctx).Errorf("failed to send email change alert: %v", err)
+		}
+	}
+
+	return &userDto.VerifyEmailResp{Email: email}, nil
+}
+
+// verifyEmailWithinLock 在分布式锁保护下消费验证码并更新邮箱
+func (s *VerifyEmailService) verifyEmailWithinLock(existUser *userEntity.User, record *authRepo.VerifyCode, email string) error {
+	// 新邮箱可能在申请更换之后被其他用户占用
+	other, err := s.svcCtx.Repository.User.GetByEmail(s.ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if other != nil && other.GetIDAsString() != existUser.GetIDAsString() {
+		return ErrEmailExists
+	}
+
+	if err := s.codes.consume(s.ctx, existUser.GetIDAsString(), record); err != nil {
+		return err
+	}
+
+	err = s.svcCtx.Repository.User.UpdateVerifiedEmail(s.ctx, existUser.ID, email, time.Now())
+	if err != nil {
+		// 数据库唯一索引作为最后防线
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return ErrEmailExists
+		}
+		return fmt.Errorf("failed to update verified email: %w", err)
+	}
+	return nil
+}
+
+// ResendVerification 重新发送邮箱验证码
+// 有待验证的新邮箱时发送到新邮箱，否则发送到当前未验证的邮箱
+func (s *VerifyEmailService) ResendVerification(_ *userDto.ResendEmailVerificationReq) (*userDto.ResendEmailVerificationResp, error) {
+	principal := middleware.GetPrincipal(s.ctx)
+	if principal == nil {
+		return nil, ErrUserNotFound
+	}
+
+	userID, err := uuid.Parse(principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed user id %q: %w", principal.UserID, err)
+	}
+	existUser, err := s.svcCtx.Repository.User.GetByID(s.ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by id(%s): %w", principal.UserID, err)
+	}
+
+	pending, err := s.svcCtx.Repository.VerifyCode.Get(s.ctx, authRepo.PurposeEmailVerify, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending email: %w", err)
+	}
+	var email string
+	if pending != nil && pending.Payload != "" {
+		email = pending.Payload
+	} else if existUser.Email != "" && existUser.EmailVerifiedAt == nil {
+		email = existUser.Email
+	} else {
+		return nil, ErrNothingToVerify
+	}
+
+	if err := sendEmailVerification(s.ctx, s.svcCtx, s.codes, existUser, email); err != nil {
+		return nil, err
+	}
+	return &userDto.ResendEmailVerificationResp{
+		Message: "verification code sent",
+	}, nil
+}
@@ -4,6 +4,7 @@ package svc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -12,8 +13,15 @@ import (
 	"hello-gozero/infra/cache"
 	"hello-gozero/infra/database"
 	"hello-gozero/infra/queue"
+	"hello-gozero/internal/blobstore"
 	"hello-gozero/internal/config"
+	"hello-gozero/internal/event"
+	"hello-gozero/internal/notify"
+	authRepo "hello-gozero/internal/repository/auth"
 	userRepo "hello-gozero/internal/repository/user"
+	"hello-gozero/internal/utils/password"
+	"hello-gozero/internal/utils/token"
+	"hello-gozero/internal/utils/totp"
 )
 
 type ServiceContext struct {
@@ -27,6 +35,18 @@ type ServiceContext struct {
 
 	// Repository
 	Repository Repository
+
+	// Security 安全相关组件
+	Security Security
+
+	// Notifier 站外通知（邮件、短信等），异步投递，需要作为后台任务运行
+	Notifier *notify.Dispatcher
+
+	// Publisher 用户事件发布（Kafka），供安全审计等外部系统订阅
+	Publisher event.Publisher
+
+	// BlobStore 对象存储，保存个人数据导出归档等文件
+	BlobStore blobstore.Store
 }
 
 // Repository 结构体，包含所有仓库接口
@@ -35,6 +55,44 @@ type Repository struct {
 	User userRepo.UserRepository
 	// 用户仓库（带缓存的装饰器，用于特殊场景，如：防重复提交、限流）
 	CachedUser userRepo.CachedUserRepository
+
+	// 刷新令牌仓库
+	RefreshToken authRepo.RefreshTokenRepository
+	// 登录会话仓库
+	Session authRepo.SessionRepository
+	// 一次性验证码仓库
+	VerifyCode authRepo.VerifyCodeRepository
+
+	// 两步验证仓库
+	MFA userRepo.MFARepository
+	// 密码历史仓库
+	PasswordHistory userRepo.PasswordHistoryRepository
+	// 个人数据导出任务仓库
+	DataExport userRepo.DataExportRepository
+	// 个人数据擦除记录仓库
+	Erasure userRepo.ErasureRepository
+	// 角色与权限仓库
+	Role userRepo.RoleRepository
+	// 租户仓库
+	Tenant userRepo.TenantRepository
+	// 用户邀请仓库
+	Invitation userRepo.InvitationRepository
+	// 两步验证登录挑战仓库
+	MFAChallenge authRepo.MFAChallengeRepository
+	// 认证失败锁定仓库
+	Lockout authRepo.LockoutRepository
+}
+
+// Security 结构体，包含安全相关组件
+type Security struct {
+	// 访问令牌管理器
+	Token token.Manager
+	// TOTP 密钥加密工具
+	TOTPCipher totp.SecretCipher
+	// 密码哈希工具
+	Password password.PasswordCryptoTool
+	// 密码策略
+	PasswordPolicy *password.Policies
 }
 
 // Infra 结构体，包含所有基础设施连接
@@ -80,9 +138,65 @@ func NewServiceContext(c config.Config) (*ServiceContext, error) {
 		return nil, fmt.Errorf("failed to init kafka reader: %w", err)
 	}
 
+	// 初始化安全组件
+	tokenManager, err := token.NewJWTManager(c.Auth.AccessSecret, c.Auth.Issuer, time.Duration(c.Auth.AccessExpire)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init token manager: %w", err)
+	}
+	totpCipher, err := totp.NewSecretCipher(c.Auth.MFA.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init totp cipher: %w", err)
+	}
+	peppers := make([]password.Pepper, 0, len(c.Auth.Password.Peppers))
+	for _, pepper := range c.Auth.Password.Peppers {
+		peppers = append(peppers, password.Pepper{Version: pepper.Version, Key: pepper.Key})
+	}
+	passwordHasher, err := newPasswordHasher(c.Auth.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init password hasher: %w", err)
+	}
+	passwordTool, err := password.NewVersionedPasswordCryptoTool(passwordHasher, c.Auth.Password.PepperVersion, peppers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init password crypto tool: %w", err)
+	}
+	passwordPolicy, err := password.NewPolicies(c.PasswordPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init password policy: %w", err)
+	}
+	if err := c.Auth.Lockout.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to init lockout: %w", err)
+	}
+
+	// 初始化通知分发器
+	notifier, err := notify.NewDispatcher(c.Notify, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init notifier: %w", err)
+	}
+
+	// 初始化对象存储
+	blobStore, err := blobstore.New(c.Export.BlobStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init blob store: %w", err)
+	}
+
 	// 初始化仓库
 	user := userRepo.NewUserRepository(mysqlConn)
 	cachedUser := userRepo.NewCachedUserRepository(redisInfra, user)
+	refreshToken := authRepo.NewRefreshTokenRepository(redisInfra)
+	session := authRepo.NewSessionRepository(redisInfra)
+	verifyCode := authRepo.NewVerifyCodeRepository(redisInfra)
+	mfa := userRepo.NewMFARepository(mysqlConn)
+	passwordHistory := userRepo.NewPasswordHistoryRepository(mysqlConn)
+	dataExport := userRepo.NewDataExportRepository(mysqlConn)
+	erasure := userRepo.NewErasureRepository(mysqlConn)
+	role := userRepo.NewRoleRepository(mysqlConn)
+	tenant, err := userRepo.NewTenantRepository(mysqlConn, time.Duration(c.Tenant.CacheExpire)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init tenant repository: %w", err)
+	}
+	invitation := userRepo.NewInvitationRepository(mysqlConn)
+	mfaChallenge := authRepo.NewMFAChallengeRepository(redisInfra)
+	lockout := authRepo.NewLockoutRepository(redisInfra)
 
 	return &ServiceContext{
 		Config: c,
@@ -94,12 +208,51 @@ func NewServiceContext(c config.Config) (*ServiceContext, error) {
 			KafkaReader: kafkaReader,
 		},
 		Repository: Repository{
-			User:       user,
-			CachedUser: cachedUser,
+			User:            user,
+			CachedUser:      cachedUser,
+			RefreshToken:    refreshToken,
+			Session:         session,
+			VerifyCode:      verifyCode,
+			MFA:             mfa,
+			PasswordHistory: passwordHistory,
+			DataExport:      dataExport,
+			Erasure:         erasure,
+			Role:            role,
+			Tenant:          tenant,
+			Invitation:      invitation,
+			MFAChallenge:    mfaChallenge,
+			Lockout:         lockout,
 		},
+		Security: Security{
+			Token:          tokenManager,
+			TOTPCipher:     totpCipher,
+			Password:       passwordTool,
+			PasswordPolicy: passwordPolicy,
+		},
+		Notifier:  notifier,
+		Publisher: event.NewKafkaPublisher(kafkaWriter),
+		BlobStore: blobStore,
 	}, nil
 }
 
+// newPasswordHasher 根据配置创建生成新密码哈希使用的算法
+func newPasswordHasher(c config.PasswordConfig) (password.Hasher, error) {
+	switch c.Algorithm {
+	case password.AlgorithmBcrypt:
+		return password.NewBcryptHasher(c.BcryptCost), nil
+	case password.AlgorithmArgon2id:
+		return password.NewArgon2idHasher(password.Argon2Params{
+			Memory:      c.Argon2.Memory,
+			Time:        c.Argon2.Time,
+			Parallelism: c.Argon2.Parallelism,
+			SaltLength:  c.Argon2.SaltLength,
+			KeyLength:   c.Argon2.KeyLength,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported password algorithm %q", c.Algorithm)
+	}
+}
+
 // Close 关闭所有资源连接
 func (sc *ServiceContext) Close() error {
 	if err := database.CloseMysql(sc.Infra.MysqlConn); err != nil {
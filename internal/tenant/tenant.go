@@ -0,0 +1,57 @@
+// Package tenant 多租户：请求所属租户在上下文中的传递
+//
+// 租户由 [middleware.TenantMiddleware] 从请求头或子域名解析后存入请求上下文，
+// 用户仓库按上下文中的租户限定全部查询，上下文中没有租户时查询直接失败（[ErrMissing]），避免遗漏租户条件导致跨租户访问；
+// 后台任务没有请求租户，需要跨租户处理时通过 [WithAllTenants] 显式声明
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// DefaultID 默认租户 ID，引入多租户之前的用户都属于默认租户
+const DefaultID uint64 = 1
+
+var (
+	ErrMissing  = errors.New("tenant missing in context") // 上下文中没有租户
+	ErrNotFound = errors.New("tenant not found")          // 租户不存在
+	ErrDisabled = errors.New("tenant disabled")           // 租户已停用
+)
+
+// Tenant 请求所属的租户
+type Tenant struct {
+	// 租户 ID
+	ID uint64
+
+	// 租户标识，即请求头或子域名中的值，同时用于选择按租户配置的密码策略
+	Code string
+}
+
+// 定义自定义的上下文 key 类型（非导出，避免外部冲突）
+type (
+	tenantContextKey     struct{}
+	allTenantsContextKey struct{}
+)
+
+// NewContext 返回携带租户的上下文
+func NewContext(ctx context.Context, t Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, t)
+}
+
+// FromContext 从上下文中检索租户，不存在时返回 false
+func FromContext(ctx context.Context) (Tenant, bool) {
+	t, ok := ctx.Value(tenantContextKey{}).(Tenant)
+	return t, ok
+}
+
+// WithAllTenants 返回可以跨租户访问的上下文，仅用于按全局唯一的用户 ID 处理数据的后台任务（如彻底清理已删除用户）
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsContextKey{}, true)
+}
+
+// IsAllTenants 判断上下文是否可以跨租户访问
+func IsAllTenants(ctx context.Context) bool {
+	all, _ := ctx.Value(allTenantsContextKey{}).(bool)
+	return all
+}
@@ -1,3 +1 @@
 package errno
-
-
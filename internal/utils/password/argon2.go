@@ -0,0 +1,147 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2idID Argon2id 在 PHC 格式哈希中的标识
+const argon2idID = "argon2id"
+
+// ErrInvalidArgon2Params Argon2id 参数非法
+var ErrInvalidArgon2Params = errors.New("invalid argon2id parameters")
+
+// Argon2Params Argon2id 参数
+// 单次哈希占用 Memory KiB 内存，并发登录时内存占用按请求数叠加，调整前请运行基准测试（BenchmarkArgon2id）
+type Argon2Params struct {
+	Memory      uint32 // 内存开销，单位 KiB
+	Time        uint32 // 迭代次数
+	Parallelism uint8  // 并行度（线程数）
+	SaltLength  uint32 // 随机盐值长度，单位字节
+	KeyLength   uint32 // 输出哈希长度，单位字节
+}
+
+// DefaultArgon2Params 默认 Argon2id 参数（OWASP 推荐的最低配置：19 MiB 内存、2 次迭代、1 个线程）
+var DefaultArgon2Params = Argon2Params{
+	Memory:      19 * 1024,
+	Time:        2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// argon2idHasher Argon2id 哈希算法
+type argon2idHasher struct {
+	params Argon2Params
+}
+
+// NewArgon2idHasher 创建 Argon2id 哈希算法
+func NewArgon2idHasher(params Argon2Params) (Hasher, error) {
+	if params.Time < 1 || params.Parallelism < 1 {
+		return nil, fmt.Errorf("%w: time and parallelism must be at least 1", ErrInvalidArgon2Params)
+	}
+	if params.Memory < 8*uint32(params.Parallelism) {
+		return nil, fmt.Errorf("%w: memory must be at least 8 KiB per thread", ErrInvalidArgon2Params)
+	}
+	if params.SaltLength < 8 || params.KeyLength < 16 {
+		return nil, fmt.Errorf("%w: salt must be at least 8 bytes and key at least 16 bytes", ErrInvalidArgon2Params)
+	}
+	return &argon2idHasher{params: params}, nil
+}
+
+// IDs Implements [Hasher.IDs]
+func (h *argon2idHasher) IDs() []string {
+	return []string{argon2idID}
+}
+
+// Hash Implements [Hasher.Hash]
+// 输出 PHC 格式：`$argon2id$v=19$m=<memory>,t=<time>,p=<parallelism>$<salt>$<hash>`，盐值与哈希为无填充的 base64 编码
+func (h *argon2idHasher) Hash(password []byte) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	p := h.params
+	key := argon2.IDKey(password, salt, p.Time, p.Memory, p.Parallelism, p.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idID, argon2.Version, p.Memory, p.Time, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify Implements [Hasher.Verify]
+func (h *argon2idHasher) Verify(password []byte, encoded string) bool {
+	decoded, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false
+	}
+	p := decoded.params
+	key := argon2.IDKey(password, decoded.salt, p.Time, p.Memory, p.Parallelism, p.KeyLength)
+	return subtle.ConstantTimeCompare(key, decoded.key) == 1
+}
+
+// NeedsRehash Implements [Hasher.NeedsRehash]
+func (h *argon2idHasher) NeedsRehash(encoded string) bool {
+	decoded, err := decodeArgon2id(encoded)
+	if err != nil {
+		// 无法解析的哈希无法通过验证，也就不会被重新生成
+		return false
+	}
+	p := decoded.params
+	return decoded.version != argon2.Version ||
+		p.Memory != h.params.Memory ||
+		p.Time != h.params.Time ||
+		p.Parallelism != h.params.Parallelism ||
+		p.KeyLength != h.params.KeyLength
+}
+
+// argon2idHash 解析后的 Argon2id 哈希
+type argon2idHash struct {
+	version int
+	params  Argon2Params
+	salt    []byte
+	key     []byte
+}
+
+// decodeArgon2id 解析 PHC 格式的 Argon2id 哈希
+func decodeArgon2id(encoded string) (*argon2idHash, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != argon2idID {
+		return nil, ErrInvalidArgon2Params
+	}
+
+	var decoded argon2idHash
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &decoded.version); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgon2Params, err)
+	}
+	p := &decoded.params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Parallelism); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgon2Params, err)
+	}
+	if p.Time < 1 || p.Parallelism < 1 {
+		return nil, ErrInvalidArgon2Params
+	}
+
+	var err error
+	if decoded.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgon2Params, err)
+	}
+	if decoded.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgon2Params, err)
+	}
+	if len(decoded.key) == 0 {
+		return nil, ErrInvalidArgon2Params
+	}
+	p.SaltLength = uint32(len(decoded.salt))
+	p.KeyLength = uint32(len(decoded.key))
+	return &decoded, nil
+}
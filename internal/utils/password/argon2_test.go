@@ -0,0 +1,195 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2Params 测试使用的低开销参数
+var testArgon2Params = Argon2Params{Memory: 64, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func newTestArgon2idHasher(t testing.TB, params Argon2Params) Hasher {
+	t.Helper()
+	hasher, err := NewArgon2idHasher(params)
+	if err != nil {
+		t.Fatalf("NewArgon2idHasher() error = %v", err)
+	}
+	return hasher
+}
+
+func TestNewArgon2idHasher_Validation(t *testing.T) {
+	cases := []struct {
+		name   string
+		params Argon2Params
+	}{
+		{"zero time", Argon2Params{Memory: 64, Time: 0, Parallelism: 1, SaltLength: 16, KeyLength: 32}},
+		{"zero parallelism", Argon2Params{Memory: 64, Time: 1, Parallelism: 0, SaltLength: 16, KeyLength: 32}},
+		{"memory too small", Argon2Params{Memory: 15, Time: 1, Parallelism: 2, SaltLength: 16, KeyLength: 32}},
+		{"short salt", Argon2Params{Memory: 64, Time: 1, Parallelism: 1, SaltLength: 4, KeyLength: 32}},
+		{"short key", Argon2Params{Memory: 64, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 8}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewArgon2idHasher(tc.params); !errors.Is(err, ErrInvalidArgon2Params) {
+				t.Fatalf("error = %v, want %v", err, ErrInvalidArgon2Params)
+			}
+		})
+	}
+}
+
+func TestArgon2idHasher(t *testing.T) {
+	hasher := newTestArgon2idHasher(t, testArgon2Params)
+
+	hash, err := hasher.Hash([]byte("Secret#123"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if want := "$argon2id$v=19$m=64,t=1,p=1$"; !strings.HasPrefix(hash, want) {
+		t.Fatalf("hash %q does not start with %q", hash, want)
+	}
+	if !hasher.Verify([]byte("Secret#123"), hash) {
+		t.Fatal("Verify() = false for correct password")
+	}
+	if hasher.Verify([]byte("Secret#124"), hash) {
+		t.Fatal("Verify() = true for wrong password")
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Fatal("NeedsRehash() = true for a fresh hash")
+	}
+
+	other, _ := hasher.Hash([]byte("Secret#123"))
+	if other == hash {
+		t.Fatal("Hash() produced identical hashes, salt is not random")
+	}
+
+	// 参数调整后旧哈希仍可验证，但需要重新生成
+	stronger := newTestArgon2idHasher(t, Argon2Params{Memory: 128, Time: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	if !stronger.Verify([]byte("Secret#123"), hash) {
+		t.Fatal("Verify() = false after parameter change")
+	}
+	if !stronger.NeedsRehash(hash) {
+		t.Fatal("NeedsRehash() = false for hash with outdated parameters")
+	}
+}
+
+func TestArgon2idHasher_Malformed(t *testing.T) {
+	hasher := newTestArgon2idHasher(t, testArgon2Params)
+
+	for _, hash := range []string{
+		"",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHQ$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5",
+	} {
+		if hasher.Verify([]byte("Secret#123"), hash) {
+			t.Fatalf("Verify(%q) = true", hash)
+		}
+		if hasher.NeedsRehash(hash) {
+			t.Fatalf("NeedsRehash(%q) = true", hash)
+		}
+	}
+}
+
+func TestPasswordCryptoTool_AlgorithmMigration(t *testing.T) {
+	pepper := Pepper{Version: 1, Key: testPepperV1}
+
+	bcryptHash, err := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 1, pepper).GenerateHash("Secret#123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Secret#123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tool := newTestCryptoTool(t, newTestArgon2idHasher(t, testArgon2Params), 1, pepper)
+
+	argon2Hash, err := tool.GenerateHash("Secret#123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(argon2Hash, pepperedHashPrefix+"1$argon2id$") {
+		t.Fatalf("hash %q is not an argon2id hash", argon2Hash)
+	}
+
+	cases := []struct {
+		name        string
+		hash        string
+		needsRehash bool
+	}{
+		{"argon2id", argon2Hash, false},
+		{"peppered bcrypt", bcryptHash, true},
+		{"legacy bcrypt", string(legacy), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tool.VerifyHash("Secret#123", tc.hash) {
+				t.Fatal("VerifyHash() = false for correct password")
+			}
+			if tool.VerifyHash("Secret#124", tc.hash) {
+				t.Fatal("VerifyHash() = true for wrong password")
+			}
+			if got := tool.NeedsRehash(tc.hash); got != tc.needsRehash {
+				t.Fatalf("NeedsRehash() = %v, want %v", got, tc.needsRehash)
+			}
+		})
+	}
+}
+
+// BenchmarkArgon2id 不同参数下单次生成 Argon2id 哈希的耗时与内存，用于为部署环境调优参数
+// 运行：go test -run '^$' -bench Argon2id -benchmem ./internal/utils/password/
+// 建议单次哈希耗时控制在几十到几百毫秒，同时关注 Memory × 并发登录数 不超过 Pod 内存限制
+func BenchmarkArgon2id(b *testing.B) {
+	for _, params := range []Argon2Params{
+		DefaultArgon2Params,
+		{Memory: 46 * 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		{Memory: 64 * 1024, Time: 2, Parallelism: 2, SaltLength: 16, KeyLength: 32},
+		{Memory: 64 * 1024, Time: 3, Parallelism: 4, SaltLength: 16, KeyLength: 32},
+	} {
+		name := fmt.Sprintf("m=%dKiB,t=%d,p=%d", params.Memory, params.Time, params.Parallelism)
+		b.Run(name, func(b *testing.B) {
+			hasher := newTestArgon2idHasher(b, params)
+			for b.Loop() {
+				if _, err := hasher.Hash([]byte("Secret#123")); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkBcrypt 不同成本下单次生成 bcrypt 哈希的耗时，用于与 Argon2id 对比
+func BenchmarkBcrypt(b *testing.B) {
+	for _, cost := range []int{10, 12} {
+		b.Run(fmt.Sprintf("cost=%d", cost), func(b *testing.B) {
+			hasher := NewBcryptHasher(cost)
+			for b.Loop() {
+				if _, err := hasher.Hash([]byte("Secret#123")); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPasswordCryptoTool_VerifyHash 含 pepper 的完整验证流程耗时（登录时的主要开销）
+func BenchmarkPasswordCryptoTool_VerifyHash(b *testing.B) {
+	tool, err := NewVersionedPasswordCryptoTool(newTestArgon2idHasher(b, DefaultArgon2Params), 1, []Pepper{{Version: 1, Key: testPepperV1}})
+	if err != nil {
+		b.Fatal(err)
+	}
+	hash, err := tool.GenerateHash("Secret#123")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		tool.VerifyHash("Secret#123", hash)
+	}
+}
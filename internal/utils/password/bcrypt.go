@@ -0,0 +1,46 @@
+package password
+
+import "golang.org/x/crypto/bcrypt"
+
+// bcryptHasher bcrypt 哈希算法
+type bcryptHasher struct {
+	cost int // 哈希成本
+}
+
+// NewBcryptHasher 创建 bcrypt 哈希算法
+// 参数：cost - 哈希成本（4-31），超出范围时使用默认值 10
+func NewBcryptHasher(cost int) Hasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &bcryptHasher{cost: cost}
+}
+
+// IDs Implements [Hasher.IDs]
+func (h *bcryptHasher) IDs() []string {
+	return []string{"2a", "2b", "2y"}
+}
+
+// Hash Implements [Hasher.Hash]
+func (h *bcryptHasher) Hash(password []byte) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword(password, h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify Implements [Hasher.Verify]
+func (h *bcryptHasher) Verify(password []byte, encoded string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(encoded), password) == nil
+}
+
+// NeedsRehash Implements [Hasher.NeedsRehash]
+func (h *bcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		// 无法解析的哈希无法通过验证，也就不会被重新生成
+		return false
+	}
+	return cost != h.cost
+}
@@ -0,0 +1,55 @@
+// Package password 布隆过滤器
+package password
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// bloomFilter 布隆过滤器，用于以较小的内存判断 SHA-1 摘要是否在集合中
+// 不存在漏判；误判率由创建时的预期元素数量与目标误判率决定，每个元素约占 1.44*log2(1/p) 位
+//
+// 输入已经是均匀分布的 SHA-1 摘要，直接取摘要的两段作为双重哈希的种子，不再额外计算哈希
+type bloomFilter struct {
+	bits []uint64
+	m    uint64 // 位数
+	k    uint64 // 哈希函数个数
+}
+
+// newBloomFilter 创建布隆过滤器，n 为预期元素数量，p 为目标误判率
+func newBloomFilter(n int, p float64) *bloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max((m+63)/64*64, 64)
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = min(max(k, 1), 32)
+	return &bloomFilter{bits: make([]uint64, m/64), m: m, k: k}
+}
+
+// add 添加 SHA-1 摘要
+func (f *bloomFilter) add(digest [20]byte) {
+	h1, h2 := f.seeds(digest)
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// test 判断 SHA-1 摘要是否可能在集合中
+func (f *bloomFilter) test(digest [20]byte) bool {
+	h1, h2 := f.seeds(digest)
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// seeds 从摘要中取双重哈希的两个种子，第二个种子取奇数，保证探测序列不退化
+func (f *bloomFilter) seeds(digest [20]byte) (uint64, uint64) {
+	return binary.BigEndian.Uint64(digest[0:8]), binary.BigEndian.Uint64(digest[8:16]) | 1
+}
@@ -0,0 +1,327 @@
+// Package password 泄露密码与常见密码检查
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// builtinCommonPasswords 内置的常见密码列表（小写），未配置任何密码库时同样生效
+//
+//go:embed common_passwords.txt
+var builtinCommonPasswords string
+
+// 默认值，与 [BreachCorpusConfig] 的配置默认值保持一致
+const (
+	defaultCommonLimit       = 10000
+	defaultFalsePositiveRate = 0.001
+)
+
+// BreachCorpusConfig 本地泄露密码库配置，检查过程不发起任何网络请求
+type BreachCorpusConfig struct {
+	// 泄露密码库路径，HIBP（Have I Been Pwned）范围格式，支持两种布局：
+	//   - 目录：按 SHA-1 前 5 位分桶，每个文件名为前缀（如 "21BD1" 或 "21BD1.txt"），每行 "后 35 位:泄露次数"
+	//   - 单个文件：所有分桶按前缀合并，每行 "完整 SHA-1:泄露次数"
+	// 为空表示不使用泄露密码库
+	Path string `json:"Path,optional"`
+
+	// 常见密码列表路径，每行一个明文密码，按流行程度降序排列；为空表示只使用内置列表
+	CommonPath string `json:"CommonPath,optional"`
+
+	// 只加载常见密码列表的前 N 个
+	CommonLimit int `json:"CommonLimit,default=10000"`
+
+	// 泄露次数达到该值才拒绝，调大可以减少内存占用
+	MinCount int64 `json:"MinCount,default=1"`
+
+	// 布隆过滤器的目标误判率，误判会拒绝少量并未泄露的密码
+	FalsePositiveRate float64 `json:"FalsePositiveRate,default=0.001"`
+
+	// 检查文件是否更新的间隔（秒），文件更新后在后台重新加载并原子替换，0 表示不检查
+	// 目录布局只比较目录本身的修改时间，更新时应整体替换目录（如写入新目录后重命名）
+	ReloadInterval int `json:"ReloadInterval,default=60"`
+}
+
+// BreachCorpus 本地泄露密码库
+//
+// 第一次检查时才加载（懒加载），加载后只保留布隆过滤器，不保留原始摘要；
+// 加载失败只记录日志，此时只使用已成功加载的部分（至少包含内置常见密码列表），不影响注册等流程
+type BreachCorpus struct {
+	conf           BreachCorpusConfig
+	reloadInterval time.Duration
+
+	loadOnce  sync.Once
+	index     atomic.Pointer[breachIndex]
+	lastCheck atomic.Int64 // 上次检查文件是否更新的时间（UnixNano）
+	reloading atomic.Bool
+}
+
+// breachIndex 加载完成的密码库索引，加载后只读，更新时整体替换
+type breachIndex struct {
+	breached    *bloomFilter // 泄露密码的 SHA-1 摘要，为空表示未配置或加载失败
+	common      *bloomFilter // 常见密码（小写）的 SHA-1 摘要
+	fingerprint string       // 加载时的文件指纹，用于判断文件是否更新
+}
+
+// NewBreachCorpus 创建本地泄露密码库，此时不加载任何文件
+func NewBreachCorpus(conf BreachCorpusConfig) *BreachCorpus {
+	if conf.CommonLimit <= 0 {
+		conf.CommonLimit = defaultCommonLimit
+	}
+	if conf.FalsePositiveRate <= 0 || conf.FalsePositiveRate >= 1 {
+		conf.FalsePositiveRate = defaultFalsePositiveRate
+	}
+	return &BreachCorpus{
+		conf:           conf,
+		reloadInterval: time.Duration(conf.ReloadInterval) * time.Second,
+	}
+}
+
+// defaultBreachCorpus 只包含内置常见密码列表的密码库
+var defaultBreachCorpus = sync.OnceValue(func() *BreachCorpus {
+	return NewBreachCorpus(BreachCorpusConfig{})
+})
+
+// Contains 判断密码是否在泄露密码库中，或者（忽略大小写）是常见密码
+func (c *BreachCorpus) Contains(password string) bool {
+	c.loadOnce.Do(func() {
+		c.index.Store(c.load())
+		c.lastCheck.Store(time.Now().UnixNano())
+	})
+	c.reloadIfChanged()
+
+	idx := c.index.Load()
+	if idx.breached != nil && idx.breached.test(sha1.Sum([]byte(password))) {
+		return true
+	}
+	return idx.common.test(sha1.Sum([]byte(strings.ToLower(password))))
+}
+
+// reloadIfChanged 距离上次检查超过间隔时比较文件指纹，文件更新后在后台重新加载，加载期间继续使用旧索引
+func (c *BreachCorpus) reloadIfChanged() {
+	if c.reloadInterval <= 0 || (c.conf.Path == "" && c.conf.CommonPath == "") {
+		return
+	}
+	now := time.Now().UnixNano()
+	last := c.lastCheck.Load()
+	if now-last < int64(c.reloadInterval) || !c.lastCheck.CompareAndSwap(last, now) {
+		return
+	}
+	if c.fingerprint() == c.index.Load().fingerprint {
+		return
+	}
+	if !c.reloading.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer c.reloading.Store(false)
+		c.index.Store(c.load())
+		logx.Infof("breach corpus reloaded")
+	}()
+}
+
+// load 加载密码库，返回新的索引
+func (c *BreachCorpus) load() *breachIndex {
+	// 先取指纹再读文件：读取期间文件被更新时，下次检查会再次加载
+	idx := &breachIndex{fingerprint: c.fingerprint()}
+
+	if c.conf.Path != "" {
+		breached, err := c.loadBreached()
+		if err != nil {
+			logx.Errorf("failed to load breach corpus(%s): %v", c.conf.Path, err)
+		} else {
+			idx.breached = breached
+		}
+	}
+
+	common, err := c.loadCommon()
+	if err != nil {
+		logx.Errorf("failed to load common password list(%s): %v", c.conf.CommonPath, err)
+	}
+	idx.common = common
+	return idx
+}
+
+// loadBreached 加载泄露密码库：第一遍统计条目数量以确定布隆过滤器大小，第二遍写入
+func (c *BreachCorpus) loadBreached() (*bloomFilter, error) {
+	n := 0
+	if err := c.scanBreached(func([20]byte) { n++ }); err != nil {
+		return nil, err
+	}
+	filter := newBloomFilter(n, c.conf.FalsePositiveRate)
+	if err := c.scanBreached(filter.add); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// scanBreached 遍历泄露密码库中泄露次数达到 MinCount 的摘要
+func (c *BreachCorpus) scanBreached(fn func([20]byte)) error {
+	info, err := os.Stat(c.conf.Path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return scanRangeFile(c.conf.Path, "", c.conf.MinCount, fn)
+	}
+
+	entries, err := os.ReadDir(c.conf.Path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		prefix := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if entry.IsDir() || len(prefix) != 5 {
+			continue
+		}
+		if err := scanRangeFile(filepath.Join(c.conf.Path, entry.Name()), strings.ToUpper(prefix), c.conf.MinCount, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanRangeFile 解析 HIBP 范围格式的文件，prefix 为空时每行是完整摘要，否则每行是去掉前缀的后 35 位
+func scanRangeFile(path, prefix string, minCount int64, fn func([20]byte)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		hash, count, _ := strings.Cut(line, ":")
+		if count != "" {
+			n, err := strconv.ParseInt(count, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s:%d: invalid count %q", path, lineNo, count)
+			}
+			// HIBP 的填充条目泄露次数为 0
+			if n < minCount || n == 0 {
+				continue
+			}
+		}
+
+		var digest [20]byte
+		if n, err := hex.Decode(digest[:], []byte(prefix+hash)); err != nil || n != len(digest) {
+			return fmt.Errorf("%s:%d: invalid sha-1 hash %q", path, lineNo, prefix+hash)
+		}
+		fn(digest)
+	}
+	return scanner.Err()
+}
+
+// loadCommon 加载内置常见密码列表与配置的常见密码列表（前 CommonLimit 个），统一转小写
+// 配置的列表加载失败时仍返回只包含内置列表的过滤器
+func (c *BreachCorpus) loadCommon() (*bloomFilter, error) {
+	builtin := splitLines(builtinCommonPasswords)
+
+	var (
+		custom []string
+		err    error
+	)
+	if c.conf.CommonPath != "" {
+		custom, err = readLines(c.conf.CommonPath, c.conf.CommonLimit)
+	}
+
+	filter := newBloomFilter(len(builtin)+len(custom), c.conf.FalsePositiveRate)
+	for _, list := range [][]string{builtin, custom} {
+		for _, password := range list {
+			filter.add(sha1.Sum([]byte(strings.ToLower(password))))
+		}
+	}
+	return filter, err
+}
+
+// fingerprint 计算密码库文件指纹（路径、大小与修改时间），文件不存在时对应部分为空
+func (c *BreachCorpus) fingerprint() string {
+	var b strings.Builder
+	for _, path := range []string{c.conf.Path, c.conf.CommonPath} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+		} else {
+			fmt.Fprintf(&b, "%s:-;", path)
+		}
+	}
+	return b.String()
+}
+
+// readLines 读取文件的前 limit 个非空行
+func readLines(path string, limit int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	reader := bufio.NewReader(f)
+	for len(lines) < limit {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			lines = append(lines, line)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return lines, err
+		}
+	}
+	return lines, nil
+}
+
+// splitLines 拆分非空行
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// -------- BreachedPasswordRule 泄露密码校验规则 --------
+
+// BreachedPasswordRule 泄露密码与常见密码校验规则
+type BreachedPasswordRule struct {
+	corpus *BreachCorpus
+}
+
+// NewBreachedPasswordRule 创建泄露密码校验规则，corpus 为空时使用只包含内置常见密码列表的密码库
+func NewBreachedPasswordRule(corpus *BreachCorpus) Rule {
+	if corpus == nil {
+		corpus = defaultBreachCorpus()
+	}
+	return &BreachedPasswordRule{corpus: corpus}
+}
+
+func (r *BreachedPasswordRule) Check(password string) []Violation {
+	if r.corpus.Contains(password) {
+		return []Violation{{Code: CodeBreached}}
+	}
+	return nil
+}
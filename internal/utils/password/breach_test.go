@@ -0,0 +1,185 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sha1Hex 计算大写十六进制 SHA-1，与 HIBP 的格式一致
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBreachCorpus_BuiltinCommonPasswords(t *testing.T) {
+	corpus := NewBreachCorpus(BreachCorpusConfig{})
+
+	for _, pwd := range []string{"Password1", "password1", "PASSWORD1", "P@ssw0rd", "Welcome1"} {
+		if !corpus.Contains(pwd) {
+			t.Errorf("expected %q to be rejected as common", pwd)
+		}
+	}
+	for _, pwd := range []string{"Xy7Kp2mQ", "correct-horse-battery"} {
+		if corpus.Contains(pwd) {
+			t.Errorf("expected %q to be accepted", pwd)
+		}
+	}
+}
+
+func TestBreachCorpus_SingleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	writeFile(t, path, fmt.Sprintf("%s:42\r\n%s:1\r\n%s:0\r\n", sha1Hex("Tr0ub4dor&3"), sha1Hex("RareOne9"), sha1Hex("Padding7")))
+
+	corpus := NewBreachCorpus(BreachCorpusConfig{Path: path, MinCount: 2})
+	if !corpus.Contains("Tr0ub4dor&3") {
+		t.Error("expected breached password to be rejected")
+	}
+	if corpus.Contains("RareOne9") {
+		t.Error("expected password below MinCount to be accepted")
+	}
+	if corpus.Contains("Padding7") {
+		t.Error("expected padding entry to be ignored")
+	}
+	// 泄露密码区分大小写
+	if corpus.Contains("tr0ub4dor&3") {
+		t.Error("expected breached lookup to be case sensitive")
+	}
+}
+
+func TestBreachCorpus_RangeDirectory(t *testing.T) {
+	dir := t.TempDir()
+	hash := sha1Hex("Tr0ub4dor&3")
+	writeFile(t, filepath.Join(dir, hash[:5]+".txt"), hash[5:]+":7\n")
+	writeFile(t, filepath.Join(dir, "README"), "not a range file")
+
+	corpus := NewBreachCorpus(BreachCorpusConfig{Path: dir})
+	if !corpus.Contains("Tr0ub4dor&3") {
+		t.Error("expected breached password to be rejected")
+	}
+	if corpus.Contains("Xy7Kp2mQ") {
+		t.Error("expected unknown password to be accepted")
+	}
+}
+
+func TestBreachCorpus_CommonListLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "common.txt")
+	writeFile(t, path, "Hunter2hunter\nDragonfly88\nZebra5zebra\n")
+
+	corpus := NewBreachCorpus(BreachCorpusConfig{CommonPath: path, CommonLimit: 2})
+	if !corpus.Contains("hunter2HUNTER") {
+		t.Error("expected common password to be rejected case-insensitively")
+	}
+	if !corpus.Contains("Dragonfly88") {
+		t.Error("expected common password within limit to be rejected")
+	}
+	if corpus.Contains("Zebra5zebra") {
+		t.Error("expected password beyond CommonLimit to be accepted")
+	}
+}
+
+func TestBreachCorpus_LoadsLazily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	corpus := NewBreachCorpus(BreachCorpusConfig{Path: path})
+
+	// 创建时不加载，首次检查时才读取文件
+	if corpus.index.Load() != nil {
+		t.Fatal("expected corpus not to be loaded before first check")
+	}
+	writeFile(t, path, sha1Hex("Tr0ub4dor&3")+":3\n")
+	if !corpus.Contains("Tr0ub4dor&3") {
+		t.Error("expected breached password to be rejected")
+	}
+}
+
+func TestBreachCorpus_MalformedFileFallsBackToBuiltin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	writeFile(t, path, "not-a-hash:1\n")
+
+	corpus := NewBreachCorpus(BreachCorpusConfig{Path: path})
+	if !corpus.Contains("Password1") {
+		t.Error("expected builtin common list to remain in effect")
+	}
+	if corpus.Contains("Xy7Kp2mQ") {
+		t.Error("expected unknown password to be accepted")
+	}
+}
+
+func TestBreachCorpus_HotSwap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	writeFile(t, path, sha1Hex("Tr0ub4dor&3")+":3\n")
+
+	corpus := NewBreachCorpus(BreachCorpusConfig{Path: path})
+	corpus.reloadInterval = time.Nanosecond
+	if corpus.Contains("Xy7Kp2mQ") {
+		t.Fatal("expected password to be accepted before corpus update")
+	}
+
+	writeFile(t, path, sha1Hex("Tr0ub4dor&3")+":3\n"+sha1Hex("Xy7Kp2mQ")+":5\n")
+	// 确保修改时间变化，避免文件系统时间精度导致指纹不变
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	// 后台重新加载完成前继续使用旧索引
+	deadline := time.Now().Add(5 * time.Second)
+	for !corpus.Contains("Xy7Kp2mQ") {
+		if time.Now().After(deadline) {
+			t.Fatal("expected corpus to be reloaded after update")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !corpus.Contains("Tr0ub4dor&3") {
+		t.Error("expected previous entries to survive reload")
+	}
+}
+
+func TestBloomFilter_NoFalseNegatives(t *testing.T) {
+	const n = 10000
+	filter := newBloomFilter(n, 0.01)
+	for i := 0; i < n; i++ {
+		filter.add(sha1.Sum([]byte(fmt.Sprintf("member-%d", i))))
+	}
+
+	for i := 0; i < n; i++ {
+		if !filter.test(sha1.Sum([]byte(fmt.Sprintf("member-%d", i)))) {
+			t.Fatalf("false negative for member-%d", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if filter.test(sha1.Sum([]byte(fmt.Sprintf("other-%d", i)))) {
+			falsePositives++
+		}
+	}
+	// 目标误判率 1%，留出足够余量避免偶发失败
+	if rate := float64(falsePositives) / n; rate > 0.03 {
+		t.Errorf("false positive rate %.4f exceeds bound", rate)
+	}
+}
+
+func TestBreachedPasswordRule(t *testing.T) {
+	rule := NewBreachedPasswordRule(nil)
+
+	violations := rule.Check("Password1")
+	if len(violations) != 1 || violations[0].Code != CodeBreached {
+		t.Fatalf("expected breached violation, got %v", violations)
+	}
+	if violations := rule.Check("Xy7Kp2mQ"); len(violations) > 0 {
+		t.Fatalf("expected ok, got violations: %v", violations)
+	}
+}
@@ -1,17 +1,16 @@
 // Package password 密码检查
 package password
 
-import "regexp"
-
 // -------------------------- 重构密码服务，聚合所有规则 --------------------------
 
 // PasswordChecker 密码检查接口
 type PasswordChecker interface {
-	// Check 执行所有密码校验规则
+	// Check 执行所有密码校验规则，一次收集所有违规
+	// 有违规时返回 [*PolicyError]，通过 errors.As 获取违规列表
 	Check(password string) error
 
-	// CheckStrength 密码强度检测
-	CheckStrength(password string) string
+	// CheckStrength 评估密码强度（见 [EstimateStrength]），userInputs 为用户名、邮箱等个人信息
+	CheckStrength(password string, userInputs ...string) Strength
 }
 
 // passwordChecker 密码服务实现（不再持有大配置，而是持有规则列表）
@@ -25,58 +24,27 @@ func NewPasswordChecker(rules ...Rule) PasswordChecker {
 }
 
 // NewDefaultPasswordChecker 创建使用默认规则的密码服务实例
-// 默认规则：长度8-32位，要求数字、大小写字母，禁止简单密码
+// 默认规则见 [DefaultPolicyConfig]：长度8-32位，要求数字、大小写字母，禁止简单密码与内置列表中的常见密码
 func NewDefaultPasswordChecker() PasswordChecker {
-	return NewPasswordChecker(
-		NewLengthRule(LengthConfig{Min: 8, Max: 32}),
-		NewCharTypeRule(CharTypeConfig{
-			RequireDigit:  true,
-			RequireUpper:  true,
-			RequireLower:  true,
-			RequireSymbol: false,
-		}),
-		NewSimplePasswordRule(SimplePasswordConfig{BanSimple: true}),
-	)
+	return NewPasswordChecker(DefaultPolicyConfig.Rules()...)
 }
 
 // Check Implements [PasswordChecker.Check]
 func (p *passwordChecker) Check(password string) error {
-	// 依次执行每个规则，只要有一个失败就返回错误
+	// 依次执行每个规则，收集所有违规
+	var violations []Violation
 	for _, rule := range p.rules {
-		if err := rule.Check(password); err != nil {
-			return err
-		}
+		violations = append(violations, rule.Check(password)...)
+	}
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
 	}
 	return nil
 }
 
 // CheckStrength Implements [PasswordChecker.CheckStrength]
-func (p *passwordChecker) CheckStrength(password string) string {
-	strength := 0
-	if len(password) >= 8 {
-		strength++
-	}
-	if regexp.MustCompile(`[0-9]`).MatchString(password) {
-		strength++
-	}
-	if regexp.MustCompile(`[A-Z]`).MatchString(password) {
-		strength++
-	}
-	if regexp.MustCompile(`[a-z]`).MatchString(password) {
-		strength++
-	}
-	if regexp.MustCompile(`[~!@#$%^&*()_+-=[]{}|;:,.<>?]`).MatchString(password) {
-		strength++
-	}
-
-	switch strength {
-	case 0, 1, 2:
-		return "弱"
-	case 3, 4:
-		return "中"
-	default:
-		return "强"
-	}
+func (p *passwordChecker) CheckStrength(password string, userInputs ...string) Strength {
+	return EstimateStrength(password, userInputs...)
 }
 
 // -------------------------- 辅助函数 --------------------------
@@ -1,6 +1,11 @@
 package password
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"hello-gozero/pkg/i18n"
+)
 
 // 测试 isConsecutive 辅助函数
 func TestIsConsecutive(t *testing.T) {
@@ -82,39 +87,6 @@ func TestPasswordService_EmptyRules(t *testing.T) {
 	}
 }
 
-// 测试 CheckStrength 的边界情况
-func TestPasswordService_CheckStrength_EdgeCases(t *testing.T) {
-	svc := NewDefaultPasswordChecker()
-
-	cases := []struct {
-		name     string
-		password string
-		want     string
-	}{
-		{"empty", "", "弱"},
-		{"single char", "a", "弱"},
-		{"only digits", "12345678", "弱"},                 // 长度+数字=2
-		{"only lowercase", "abcdefgh", "弱"},              // 长度+小写=2
-		{"only uppercase", "ABCDEFGH", "弱"},              // 长度+大写=2
-		{"digits and lower", "abc12345", "中"},            // 长度+数字+小写=3
-		{"digits and upper", "ABC12345", "中"},            // 长度+数字+大写=3
-		{"upper and lower", "AbcdEfgh", "中"},             // 长度+大小写=3
-		{"all but symbol", "Abcd1234", "中"},              // 长度+数字+大小写=4
-		{"all types", "Abcd123!", "中"},                   // 长度+数字+大小写+符号=4（长度<8不加分）
-		{"short with all", "Ab1!", "中"},                  // 数字+大小写+符号=4
-		{"long pure digit", "12345678901234567890", "弱"}, // 长度+数字=2
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			got := svc.CheckStrength(tc.password)
-			if got != tc.want {
-				t.Errorf("CheckStrength(%q) = %s, want %s", tc.password, got, tc.want)
-			}
-		})
-	}
-}
-
 // 测试单规则场景
 func TestPasswordService_SingleRule(t *testing.T) {
 	// 只有长度规则
@@ -135,29 +107,53 @@ func TestPasswordService_SingleRule(t *testing.T) {
 	}
 }
 
-// 测试规则执行顺序（遇到第一个失败就返回）
-func TestPasswordService_RuleExecutionOrder(t *testing.T) {
+// 测试一次收集所有规则的违规
+func TestPasswordService_CollectsAllViolations(t *testing.T) {
 	svc := NewPasswordChecker(
 		NewLengthRule(LengthConfig{Min: 8, Max: 32}),
-		NewCharTypeRule(CharTypeConfig{RequireDigit: true}),
+		NewCharTypeRule(CharTypeConfig{RequireDigit: true, RequireUpper: true}),
+		NewSimplePasswordRule(SimplePasswordConfig{BanSimple: true}),
 	)
 
-	// 太短会先被长度规则拒绝
 	err := svc.Check("abc")
-	if err == nil {
-		t.Fatal("Expected error for short password")
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("Expected *PolicyError, got: %v", err)
+	}
+
+	want := []string{CodeTooShort, CodeMissingDigit, CodeMissingUpper, CodeDigitsOrLetters, CodeConsecutiveChars}
+	if len(policyErr.Violations) != len(want) {
+		t.Fatalf("Expected %d violations, got: %v", len(want), policyErr.Violations)
+	}
+	for i, code := range want {
+		if policyErr.Violations[i].Code != code {
+			t.Errorf("violation %d: expected code %s, got %s", i, code, policyErr.Violations[i].Code)
+		}
 	}
-	if err.Error() != "密码长度不能小于8位" {
-		t.Errorf("Expected length error first, got: %v", err)
+	if min := policyErr.Violations[0].Params["min"]; min != 8 {
+		t.Errorf("Expected too_short min param 8, got: %v", min)
 	}
 
-	// 长度满足但没有数字会被字符类型规则拒绝
-	err = svc.Check("abcdefgh")
-	if err == nil {
-		t.Fatal("Expected error for password without digit")
+	if err := svc.Check("Xy7Kp2mQ"); err != nil {
+		t.Errorf("Expected no violations, got: %v", err)
 	}
-	if err.Error() != "密码必须包含数字" {
-		t.Errorf("Expected digit requirement error, got: %v", err)
+}
+
+// 测试违规提示文案按语言渲染
+func TestViolation_Message(t *testing.T) {
+	v := Violation{Code: CodeTooShort, Params: map[string]any{"min": 10}}
+
+	if got := v.Message(i18n.LocaleEN); got != "must be at least 10 characters long" {
+		t.Errorf("unexpected en message: %q", got)
+	}
+	if got := v.Message(i18n.LocaleZH); got != "密码长度不能小于10位" {
+		t.Errorf("unexpected zh message: %q", got)
+	}
+
+	err := &PolicyError{Violations: []Violation{v, {Code: CodeMissingDigit}}}
+	want := "password does not meet the policy: must be at least 10 characters long; must contain a digit"
+	if err.Error() != want {
+		t.Errorf("unexpected error message: %q", err.Error())
 	}
 }
 
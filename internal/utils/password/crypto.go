@@ -7,12 +7,18 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-
-	"golang.org/x/crypto/bcrypt"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 const MinPepperLength = 32
 
+// pepperedHashPrefix 带 pepper 的哈希前缀，后接 pepper 版本号与 PHC 格式的哈希，
+// 如 `$pepper$v=2$argon2id$v=19$m=19456,t=2,p=1$...` 或 `$pepper$v=1$2a$10$...`
+// 不带该前缀的哈希视为迁移前直接生成的旧哈希（不含 pepper，如 `$2a$` bcrypt 哈希）
+const pepperedHashPrefix = "$pepper$v="
+
 // 错误定义
 var (
 	ErrEmptyPassword      = errors.New("password cannot be empty")          // 密码为空
@@ -20,13 +26,23 @@ var (
 	ErrPepperTooShort     = errors.New("pepper key length is insufficient") // pepper密钥长度不足
 	ErrHashGenerationFail = errors.New("failed to generate password hash")  // 哈希生成失败
 	ErrEmptyStoredHash    = errors.New("stored hash cannot be empty")       // 存储的哈希为空
+	ErrInvalidPepperVer   = errors.New("invalid pepper version")            // pepper版本号非法或重复
+	ErrUnknownPepperVer   = errors.New("unknown pepper version")            // 当前pepper版本不在配置的pepper列表中
 )
 
+// Pepper 带版本号的 pepper 密钥
+// 哈希中记录生成时使用的 pepper 版本，轮换 pepper 时新增一个版本并设为当前版本，
+// 旧版本保留到所有旧哈希都已重新生成后再移除
+type Pepper struct {
+	Version int    // 版本号，正整数
+	Key     string // pepper 密钥
+}
+
 // PasswordCryptoTool 密码加密验证工具接口（对外暴露）
-// 核心能力：基于pepper密钥+bcrypt哈希，完成密码串的哈希生成与验证
+// 核心能力：基于pepper密钥+哈希算法（Argon2id 或 bcrypt，见 [Hasher]），完成密码串的哈希生成与验证
 // 适用场景：任意密码串的加密存储、密码合法性校验（无场景限制）
 type PasswordCryptoTool interface {
-	// GenerateHash 生成密码串的bcrypt哈希值（含pepper加盐），用于持久化存储
+	// GenerateHash 使用当前算法生成密码串的哈希值（含pepper加盐），用于持久化存储
 	// 参数：password - 待哈希的密码串（任意格式/来源）
 	// 返回：哈希字符串 | 错误（空密码串/哈希失败等）
 	GenerateHash(password string) (string, error)
@@ -36,41 +52,66 @@ type PasswordCryptoTool interface {
 	//   password    - 待验证的密码串
 	//   storedHash  - 已存储的密码哈希值
 	// 返回：匹配返回true，不匹配/参数非法返回false
+	//
+	// 根据哈希前缀中的算法标识选择算法，兼容迁移前不含 pepper 的 bcrypt 旧哈希；
+	// 哈希使用的 pepper 版本已从配置中移除时返回false
 	VerifyHash(password, storedHash string) bool
+
+	// NeedsRehash 判断已存储的哈希是否需要重新生成（在密码验证通过后，用明文密码调用 GenerateHash 替换）
+	// 以下情况返回true：不含 pepper 的旧哈希、pepper 版本不是当前版本、算法不是当前算法、算法参数与配置不一致
+	NeedsRehash(storedHash string) bool
 }
 
 // passwordCryptoTool 接口私有实现体
 type passwordCryptoTool struct {
-	bcryptCost int    // bcrypt哈希成本
-	pepperKey  string // 额外加盐密钥（pepper），仅存储在后端
+	hasher         Hasher         // 生成新哈希使用的算法
+	registry       hasherRegistry // 验证时支持的所有算法
+	currentVersion int            // 生成新哈希使用的pepper版本
+	peppers        map[int]string // 额外加盐密钥（pepper），按版本号索引，仅存储在后端
 }
 
 // NewPasswordCryptoTool 创建密码工具实例（工厂函数，新增pepperKey参数）
 // 参数：
 //
 //	bcryptCost - 哈希成本（4-31，默认10）
-//	pepperKey  - 额外加盐密钥（必填，建议32位以上随机字符串）
+//	pepperKey  - 额外加盐密钥（必填，建议32位以上随机字符串），作为版本 1 使用
 //
 // 返回：接口实例 | 错误
 func NewPasswordCryptoTool(bcryptCost int, pepperKey string) (PasswordCryptoTool, error) {
-	// 校验pepperKey非空（核心安全配置，不能为空）
-	if pepperKey == "" {
-		return nil, ErrEmptyPepper
-	}
+	return NewVersionedPasswordCryptoTool(NewBcryptHasher(bcryptCost), 1, []Pepper{{Version: 1, Key: pepperKey}})
+}
 
-	// 新增：校验 pepper 长度
-	if len(pepperKey) < MinPepperLength {
-		return nil, fmt.Errorf("%w: minimum required %d characters, current length: %d", ErrPepperTooShort, MinPepperLength, len(pepperKey))
+// NewVersionedPasswordCryptoTool 创建支持 pepper 轮换的密码工具实例
+// 参数：
+//
+//	hasher         - 生成新哈希使用的算法（[NewArgon2idHasher] 或 [NewBcryptHasher]），其他算法的旧哈希仍可验证
+//	currentVersion - 生成新哈希使用的 pepper 版本，必须在 peppers 中
+//	peppers        - 所有仍需支持验证的 pepper（包括当前版本与尚未迁移完成的旧版本）
+//
+// 返回：接口实例 | 错误
+func NewVersionedPasswordCryptoTool(hasher Hasher, currentVersion int, peppers []Pepper) (PasswordCryptoTool, error) {
+	keys := make(map[int]string, len(peppers))
+	for _, pepper := range peppers {
+		if pepper.Version <= 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidPepperVer, pepper.Version)
+		}
+		if _, ok := keys[pepper.Version]; ok {
+			return nil, fmt.Errorf("%w: duplicate version %d", ErrInvalidPepperVer, pepper.Version)
+		}
+		if err := CheckPepper(pepper.Key); err != nil {
+			return nil, fmt.Errorf("pepper version %d: %w", pepper.Version, err)
+		}
+		keys[pepper.Version] = pepper.Key
 	}
-
-	// 校验哈希成本范围
-	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
-		bcryptCost = bcrypt.DefaultCost
+	if _, ok := keys[currentVersion]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPepperVer, currentVersion)
 	}
 
 	return &passwordCryptoTool{
-		bcryptCost: bcryptCost,
-		pepperKey:  pepperKey,
+		hasher:         hasher,
+		registry:       newHasherRegistry(hasher),
+		currentVersion: currentVersion,
+		peppers:        keys,
 	}, nil
 }
 
@@ -81,16 +122,17 @@ func (t *passwordCryptoTool) GenerateHash(password string) (string, error) {
 		return "", ErrEmptyPassword
 	}
 
-	// 1. 添加pepper额外加盐
-	pwdWithPepper := t.addPepper(password)
+	// 1. 添加当前版本的pepper额外加盐
+	pwdWithPepper := addPepper(t.peppers[t.currentVersion], password)
 
-	// 2. 生成bcrypt哈希（自动生成随机盐值+pepper固定盐值）
-	hashBytes, err := bcrypt.GenerateFromPassword([]byte(pwdWithPepper), t.bcryptCost)
+	// 2. 使用当前算法生成哈希（自动生成随机盐值+pepper固定盐值）
+	hash, err := t.hasher.Hash([]byte(pwdWithPepper))
 	if err != nil {
 		return "", fmt.Errorf("%w: %v", ErrHashGenerationFail, err)
 	}
 
-	return string(hashBytes), nil
+	// 3. 记录pepper版本，验证时据此选择pepper
+	return pepperedHashPrefix + strconv.Itoa(t.currentVersion) + hash, nil
 }
 
 // VerifyHash Implements [PasswordCryptoTool.VerifyHash]
@@ -100,18 +142,88 @@ func (t *passwordCryptoTool) VerifyHash(password, storedHash string) bool {
 		return false
 	}
 
-	// 1. 给输入密码串添加相同的pepper
-	pwdWithPepper := t.addPepper(password)
+	version, hash, peppered := parseHash(storedHash)
+
+	// 1. 根据哈希前缀中的算法标识选择算法
+	hasher, ok := t.registry.lookup(hash)
+	if !ok {
+		return false
+	}
+	if !peppered {
+		// 迁移前的旧哈希，直接比对明文密码
+		return hasher.Verify([]byte(password), hash)
+	}
+
+	// 2. 给输入密码串添加生成哈希时使用的pepper
+	pepperKey, ok := t.peppers[version]
+	if !ok {
+		return false
+	}
+	pwdWithPepper := addPepper(pepperKey, password)
 
-	// 2. 比对哈希（bcrypt自动提取随机盐值，结合pepper比对）
-	err := bcrypt.CompareHashAndPassword([]byte(storedHash), []byte(pwdWithPepper))
-	return err == nil
+	// 3. 比对哈希（算法自动提取随机盐值与参数，结合pepper比对）
+	return hasher.Verify([]byte(pwdWithPepper), hash)
+}
+
+// NeedsRehash Implements [PasswordCryptoTool.NeedsRehash]
+func (t *passwordCryptoTool) NeedsRehash(storedHash string) bool {
+	version, hash, peppered := parseHash(storedHash)
+	if !peppered || version != t.currentVersion {
+		return true
+	}
+	if !slices.Contains(t.hasher.IDs(), hashID(hash)) {
+		// 其他算法生成的哈希，迁移到当前算法
+		return true
+	}
+	return t.hasher.NeedsRehash(hash)
+}
+
+// parseHash 解析已存储的哈希，返回 pepper 版本与 PHC 格式的哈希
+// 不带 pepper 前缀（或前缀格式错误）时 peppered 为false，原样返回哈希
+func parseHash(storedHash string) (version int, hash string, peppered bool) {
+	rest, ok := strings.CutPrefix(storedHash, pepperedHashPrefix)
+	if !ok {
+		return 0, storedHash, false
+	}
+	idx := strings.IndexByte(rest, '$')
+	if idx <= 0 {
+		return 0, storedHash, false
+	}
+	version, err := strconv.Atoi(rest[:idx])
+	if err != nil {
+		return 0, storedHash, false
+	}
+	return version, rest[idx:], true
 }
 
 // addPepper 使用 HMAC-SHA256 混合密码和 pepper（更安全）
-func (t *passwordCryptoTool) addPepper(inputPwd string) string {
-	h := hmac.New(sha256.New, []byte(t.pepperKey))
-	h.Write([]byte(inputPwd))
+func addPepper(pepperKey, inputPwd string) string {
 	// 返回 base64 编码的 HMAC 结果
-	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+	return base64.StdEncoding.EncodeToString(HMACWithPepper(pepperKey, []byte(inputPwd)))
+}
+
+// CheckPepper 校验 pepper 密钥是否满足安全要求（非空且长度不少于 [MinPepperLength]）
+// 其他基于 pepper 的组件（如两步验证密钥加密、恢复码摘要）在初始化时复用该校验
+func CheckPepper(pepperKey string) error {
+	// 校验pepperKey非空（核心安全配置，不能为空）
+	if pepperKey == "" {
+		return ErrEmptyPepper
+	}
+
+	// 校验 pepper 长度
+	if len(pepperKey) < MinPepperLength {
+		return fmt.Errorf("%w: minimum required %d characters, current length: %d", ErrPepperTooShort, MinPepperLength, len(pepperKey))
+	}
+	return nil
+}
+
+// HMACWithPepper 使用 pepper 作为密钥计算输入的 HMAC-SHA256，返回原始摘要
+//
+// 与 [PasswordCryptoTool] 混合密码的方式一致，可用于：
+//   - 低熵但需要确定性查找的值的摘要（如恢复码），pepper 仅保存在后端，数据库泄露后无法离线枚举
+//   - 从 pepper 派生用途隔离的子密钥（输入中携带用途标签）
+func HMACWithPepper(pepperKey string, input []byte) []byte {
+	h := hmac.New(sha256.New, []byte(pepperKey))
+	h.Write(input)
+	return h.Sum(nil)
 }
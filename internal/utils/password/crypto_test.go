@@ -0,0 +1,151 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	testPepperV1 = "pepper-v1-0123456789abcdef0123456789abcdef"
+	testPepperV2 = "pepper-v2-0123456789abcdef0123456789abcdef"
+)
+
+func newTestCryptoTool(t *testing.T, hasher Hasher, current int, peppers ...Pepper) PasswordCryptoTool {
+	t.Helper()
+	tool, err := NewVersionedPasswordCryptoTool(hasher, current, peppers)
+	if err != nil {
+		t.Fatalf("NewVersionedPasswordCryptoTool() error = %v", err)
+	}
+	return tool
+}
+
+func TestNewVersionedPasswordCryptoTool_Validation(t *testing.T) {
+	cases := []struct {
+		name    string
+		current int
+		peppers []Pepper
+		wantErr error
+	}{
+		{"short pepper", 1, []Pepper{{Version: 1, Key: "short"}}, ErrPepperTooShort},
+		{"empty pepper", 1, []Pepper{{Version: 1}}, ErrEmptyPepper},
+		{"zero version", 0, []Pepper{{Version: 0, Key: testPepperV1}}, ErrInvalidPepperVer},
+		{"duplicate version", 1, []Pepper{{Version: 1, Key: testPepperV1}, {Version: 1, Key: testPepperV2}}, ErrInvalidPepperVer},
+		{"unknown current version", 2, []Pepper{{Version: 1, Key: testPepperV1}}, ErrUnknownPepperVer},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewVersionedPasswordCryptoTool(NewBcryptHasher(bcrypt.MinCost), tc.current, tc.peppers)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordCryptoTool_GenerateAndVerify(t *testing.T) {
+	tool := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 1, Pepper{Version: 1, Key: testPepperV1})
+
+	hash, err := tool.GenerateHash("Secret#123")
+	if err != nil {
+		t.Fatalf("GenerateHash() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, pepperedHashPrefix+"1$2") {
+		t.Fatalf("hash %q does not carry pepper version", hash)
+	}
+	if !tool.VerifyHash("Secret#123", hash) {
+		t.Fatal("VerifyHash() = false for correct password")
+	}
+	if tool.VerifyHash("Secret#124", hash) {
+		t.Fatal("VerifyHash() = true for wrong password")
+	}
+	if tool.NeedsRehash(hash) {
+		t.Fatal("NeedsRehash() = true for a fresh hash")
+	}
+
+	// 不同 pepper 生成的哈希无法通过验证
+	other := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 1, Pepper{Version: 1, Key: testPepperV2})
+	if other.VerifyHash("Secret#123", hash) {
+		t.Fatal("VerifyHash() = true with a different pepper")
+	}
+}
+
+func TestPasswordCryptoTool_LegacyHash(t *testing.T) {
+	tool := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 1, Pepper{Version: 1, Key: testPepperV1})
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Secret#123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tool.VerifyHash("Secret#123", string(legacy)) {
+		t.Fatal("VerifyHash() = false for legacy bcrypt hash")
+	}
+	if tool.VerifyHash("Secret#124", string(legacy)) {
+		t.Fatal("VerifyHash() = true for wrong password against legacy hash")
+	}
+	if !tool.NeedsRehash(string(legacy)) {
+		t.Fatal("NeedsRehash() = false for legacy bcrypt hash")
+	}
+}
+
+func TestPasswordCryptoTool_PepperRotation(t *testing.T) {
+	v1 := Pepper{Version: 1, Key: testPepperV1}
+	v2 := Pepper{Version: 2, Key: testPepperV2}
+
+	oldHash, err := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 1, v1).GenerateHash("Secret#123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 2, v1, v2)
+	if !rotated.VerifyHash("Secret#123", oldHash) {
+		t.Fatal("VerifyHash() = false for hash with previous pepper version")
+	}
+	if !rotated.NeedsRehash(oldHash) {
+		t.Fatal("NeedsRehash() = false for hash with previous pepper version")
+	}
+
+	newHash, err := rotated.GenerateHash("Secret#123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.NeedsRehash(newHash) {
+		t.Fatal("NeedsRehash() = true for hash with current pepper version")
+	}
+
+	// 旧版本移除后，仍使用旧版本的哈希无法通过验证
+	retired := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 2, v2)
+	if retired.VerifyHash("Secret#123", oldHash) {
+		t.Fatal("VerifyHash() = true for hash with retired pepper version")
+	}
+}
+
+func TestPasswordCryptoTool_CostChange(t *testing.T) {
+	pepper := Pepper{Version: 1, Key: testPepperV1}
+
+	hash, err := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 1, pepper).GenerateHash("Secret#123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stronger := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost+1), 1, pepper)
+	if !stronger.VerifyHash("Secret#123", hash) {
+		t.Fatal("VerifyHash() = false after cost change")
+	}
+	if !stronger.NeedsRehash(hash) {
+		t.Fatal("NeedsRehash() = false for hash with outdated cost")
+	}
+}
+
+func TestPasswordCryptoTool_Malformed(t *testing.T) {
+	tool := newTestCryptoTool(t, NewBcryptHasher(bcrypt.MinCost), 1, Pepper{Version: 1, Key: testPepperV1})
+
+	for _, hash := range []string{"", "not-a-hash", pepperedHashPrefix, pepperedHashPrefix + "x$2a$04$abc"} {
+		if tool.VerifyHash("Secret#123", hash) {
+			t.Fatalf("VerifyHash(%q) = true", hash)
+		}
+	}
+}
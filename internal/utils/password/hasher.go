@@ -0,0 +1,60 @@
+package password
+
+import "strings"
+
+// 哈希算法名称，用于配置
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// Hasher 哈希算法（[PasswordCryptoTool] 的算法后端）
+// 生成的哈希采用 PHC 字符串格式（`$<算法标识>$...`），验证时根据哈希前缀中的算法标识选择对应的算法
+type Hasher interface {
+	// IDs 算法在 PHC 格式哈希中的标识，一个算法可以有多个标识（如 bcrypt 的 2a、2b、2y）
+	IDs() []string
+
+	// Hash 生成哈希
+	Hash(password []byte) (string, error)
+
+	// Verify 验证密码与哈希是否匹配，哈希格式错误时返回false
+	Verify(password []byte, encoded string) bool
+
+	// NeedsRehash 判断哈希的参数是否与当前配置不一致
+	NeedsRehash(encoded string) bool
+}
+
+// hasherRegistry 按算法标识索引的哈希算法
+type hasherRegistry map[string]Hasher
+
+// newHasherRegistry 创建哈希算法注册表
+// 所有支持的算法都以默认参数注册（只用于验证旧哈希），current 覆盖同一算法的默认实例
+func newHasherRegistry(current Hasher) hasherRegistry {
+	registry := make(hasherRegistry)
+	defaultArgon2id, _ := NewArgon2idHasher(DefaultArgon2Params)
+	for _, hasher := range []Hasher{NewBcryptHasher(0), defaultArgon2id, current} {
+		for _, id := range hasher.IDs() {
+			registry[id] = hasher
+		}
+	}
+	return registry
+}
+
+// lookup 根据 PHC 格式哈希前缀中的算法标识查找哈希算法
+func (r hasherRegistry) lookup(encoded string) (Hasher, bool) {
+	hasher, ok := r[hashID(encoded)]
+	return hasher, ok
+}
+
+// hashID 提取 PHC 格式哈希中的算法标识，如 `$argon2id$v=19$...` 中的 argon2id，格式错误时返回空字符串
+func hashID(encoded string) string {
+	rest, ok := strings.CutPrefix(encoded, "$")
+	if !ok {
+		return ""
+	}
+	id, _, ok := strings.Cut(rest, "$")
+	if !ok {
+		return ""
+	}
+	return id
+}
@@ -0,0 +1,32 @@
+// Package password 密码历史
+package password
+
+// HashVerifier 校验密码与已存储的哈希是否匹配，[PasswordCryptoTool] 实现了该接口
+type HashVerifier interface {
+	VerifyHash(password, storedHash string) bool
+}
+
+// -------- HistoryRule 密码历史校验规则 --------
+
+// HistoryRule 密码历史校验规则，新密码不能与最近使用过的密码相同
+type HistoryRule struct {
+	verifier       HashVerifier
+	size           int
+	previousHashes []string
+}
+
+// NewHistoryRule 创建密码历史校验规则
+// size 为策略要求不能重复使用的最近密码个数（见 [PolicyConfig.HistorySize]），previousHashes 为最近使用过的密码哈希（包含当前密码）
+func NewHistoryRule(verifier HashVerifier, size int, previousHashes ...string) Rule {
+	return &HistoryRule{verifier: verifier, size: size, previousHashes: previousHashes}
+}
+
+func (r *HistoryRule) Check(password string) []Violation {
+	// 每次比对都需要计算一次慢哈希，调用方应将 previousHashes 控制在 size 以内
+	for _, hash := range r.previousHashes {
+		if r.verifier.VerifyHash(password, hash) {
+			return []Violation{{Code: CodeReused, Params: map[string]any{"count": r.size}}}
+		}
+	}
+	return nil
+}
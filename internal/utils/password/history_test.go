@@ -0,0 +1,81 @@
+package password
+
+import (
+	"testing"
+	"time"
+)
+
+// plainVerifier 以明文比对代替哈希，便于测试
+type plainVerifier struct {
+	calls int
+}
+
+func (v *plainVerifier) VerifyHash(password, storedHash string) bool {
+	v.calls++
+	return password == storedHash
+}
+
+func TestHistoryRule(t *testing.T) {
+	verifier := &plainVerifier{}
+	rule := NewHistoryRule(verifier, 3, "Current1!", "Previous1!", "Older1!")
+
+	violations := rule.Check("Previous1!")
+	if len(violations) != 1 || violations[0].Code != CodeReused {
+		t.Fatalf("expected reused violation, got %v", violations)
+	}
+	if violations[0].Params["count"] != 3 {
+		t.Errorf("unexpected params: %v", violations[0].Params)
+	}
+	// 匹配后不再比对剩余的哈希
+	if verifier.calls != 2 {
+		t.Errorf("expected 2 hash comparisons, got %d", verifier.calls)
+	}
+
+	if violations := rule.Check("Brand-new1!"); len(violations) > 0 {
+		t.Errorf("expected ok, got violations: %v", violations)
+	}
+}
+
+func TestHistoryRule_WithCryptoTool(t *testing.T) {
+	tool, err := NewPasswordCryptoTool(4, "0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := tool.GenerateHash("Previous1!")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := NewHistoryRule(tool, 5, hash)
+	if violations := rule.Check("Previous1!"); len(violations) != 1 {
+		t.Fatalf("expected reused violation, got %v", violations)
+	}
+	if violations := rule.Check("Different1!"); len(violations) > 0 {
+		t.Fatalf("expected ok, got violations: %v", violations)
+	}
+}
+
+func TestPolicyConfig_Expired(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	policy := PolicyConfig{MaxAge: 90}
+
+	cases := []struct {
+		name      string
+		policy    PolicyConfig
+		changedAt time.Time
+		want      bool
+	}{
+		{"fresh", policy, now.AddDate(0, 0, -10), false},
+		{"just before expiry", policy, now.AddDate(0, 0, -90).Add(time.Minute), false},
+		{"expired", policy, now.AddDate(0, 0, -90), true},
+		{"never expires", PolicyConfig{}, now.AddDate(-5, 0, 0), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.Expired(tc.changedAt, now); got != tc.want {
+				t.Fatalf("Expired() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package password 密码策略
+package password
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPolicy 密码策略配置非法
+var ErrInvalidPolicy = errors.New("invalid password policy")
+
+// PolicyConfig 密码策略配置，通过 [PolicyConfig.Rules] 构建规则链
+type PolicyConfig struct {
+	MinLength     int  `json:"MinLength,default=8"`       // 最小长度
+	MaxLength     int  `json:"MaxLength,default=32"`      // 最大长度
+	RequireDigit  bool `json:"RequireDigit,default=true"` // 是否要求数字
+	RequireUpper  bool `json:"RequireUpper,default=true"` // 是否要求大写字母
+	RequireLower  bool `json:"RequireLower,default=true"` // 是否要求小写字母
+	RequireSymbol bool `json:"RequireSymbol,optional"`    // 是否要求特殊符号（见 [Symbols]）
+	BanSimple     bool `json:"BanSimple,default=true"`    // 是否禁止简单密码（纯数字/纯字母、连续字符、重复字符）
+	BanBreached   bool `json:"BanBreached,default=true"`  // 是否禁止泄露密码与常见密码（见 [BreachCorpus]）
+	MinStrength   int  `json:"MinStrength,optional"`      // 最低强度评分 0-4（见 [EstimateStrength]），0 表示不校验强度
+	HistorySize   int  `json:"HistorySize,optional"`      // 不能重复使用最近 N 个密码（包含当前密码），0 表示不限制
+	MaxAge        int  `json:"MaxAge,optional"`           // 密码有效期（天），过期后要求修改密码，0 表示永不过期
+}
+
+// DefaultPolicyConfig 默认密码策略
+var DefaultPolicyConfig = PolicyConfig{
+	MinLength:    8,
+	MaxLength:    32,
+	RequireDigit: true,
+	RequireUpper: true,
+	RequireLower: true,
+	BanSimple:    true,
+	BanBreached:  true,
+}
+
+// Validate 校验策略配置
+func (c PolicyConfig) Validate() error {
+	if c.MinLength < 1 {
+		return fmt.Errorf("%w: MinLength must be at least 1", ErrInvalidPolicy)
+	}
+	if c.MaxLength < c.MinLength {
+		return fmt.Errorf("%w: MaxLength(%d) is less than MinLength(%d)", ErrInvalidPolicy, c.MaxLength, c.MinLength)
+	}
+	if c.MinStrength < 0 || c.MinStrength > MaxScore {
+		return fmt.Errorf("%w: MinStrength must be between 0 and %d", ErrInvalidPolicy, MaxScore)
+	}
+	if c.HistorySize < 0 {
+		return fmt.Errorf("%w: HistorySize must not be negative", ErrInvalidPolicy)
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("%w: MaxAge must not be negative", ErrInvalidPolicy)
+	}
+	return nil
+}
+
+// Rules 根据配置构建规则链，泄露密码校验只使用内置常见密码列表
+func (c PolicyConfig) Rules() []Rule {
+	return c.rules(nil, nil)
+}
+
+// rules 根据配置构建规则链
+// 泄露密码校验使用指定的密码库，为空时只使用内置常见密码列表；userInputs 为强度评估时考虑的个人信息
+func (c PolicyConfig) rules(corpus *BreachCorpus, userInputs []string) []Rule {
+	rules := []Rule{
+		NewLengthRule(LengthConfig{Min: c.MinLength, Max: c.MaxLength}),
+		NewCharTypeRule(CharTypeConfig{
+			RequireDigit:  c.RequireDigit,
+			RequireUpper:  c.RequireUpper,
+			RequireLower:  c.RequireLower,
+			RequireSymbol: c.RequireSymbol,
+		}),
+	}
+	if c.BanSimple {
+		rules = append(rules, NewSimplePasswordRule(SimplePasswordConfig{BanSimple: true}))
+	}
+	if c.BanBreached {
+		rules = append(rules, NewBreachedPasswordRule(corpus))
+	}
+	if c.MinStrength > 0 {
+		rules = append(rules, NewStrengthRule(c.MinStrength, userInputs...))
+	}
+	return rules
+}
+
+// Expired 判断在 changedAt 设置的密码到 now 时是否已过期
+func (c PolicyConfig) Expired(changedAt, now time.Time) bool {
+	if c.MaxAge <= 0 {
+		return false
+	}
+	return now.Sub(changedAt) >= time.Duration(c.MaxAge)*24*time.Hour
+}
+
+// PoliciesConfig 密码策略集合配置
+// 选择策略的优先级：租户 > 角色 > 默认
+type PoliciesConfig struct {
+	Default PolicyConfig            `json:"Default"`          // 默认策略
+	Roles   map[string]PolicyConfig `json:"Roles,optional"`   // 按角色覆盖的策略，key 为角色名
+	Tenants map[string]PolicyConfig `json:"Tenants,optional"` // 按租户覆盖的策略，key 为租户标识
+	Breach  BreachCorpusConfig      `json:"Breach"`           // 所有策略共用的本地泄露密码库
+}
+
+// PolicySubject 选择密码策略的依据
+type PolicySubject struct {
+	Tenant string   // 租户标识，为空表示不区分租户
+	Roles  []string // 角色列表，按顺序匹配第一个配置了策略的角色
+}
+
+// Policies 密码策略集合
+type Policies struct {
+	conf   PoliciesConfig
+	corpus *BreachCorpus
+}
+
+// NewPolicies 创建密码策略集合，校验所有策略配置
+func NewPolicies(conf PoliciesConfig) (*Policies, error) {
+	if err := conf.Default.Validate(); err != nil {
+		return nil, fmt.Errorf("default policy: %w", err)
+	}
+	for role, policy := range conf.Roles {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("policy for role %q: %w", role, err)
+		}
+	}
+	for tenant, policy := range conf.Tenants {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("policy for tenant %q: %w", tenant, err)
+		}
+	}
+	return &Policies{conf: conf, corpus: NewBreachCorpus(conf.Breach)}, nil
+}
+
+// Resolve 选择适用的密码策略：租户 > 角色 > 默认
+func (p *Policies) Resolve(subject PolicySubject) PolicyConfig {
+	if subject.Tenant != "" {
+		if policy, ok := p.conf.Tenants[subject.Tenant]; ok {
+			return policy
+		}
+	}
+	for _, role := range subject.Roles {
+		if policy, ok := p.conf.Roles[role]; ok {
+			return policy
+		}
+	}
+	return p.conf.Default
+}
+
+// Rules 构建适用策略的规则链，userInputs 为用户名、邮箱等个人信息，强度评估时密码中包含这些信息会降低评分
+// 需要追加规则（如 [HistoryRule]）时使用，否则使用 [Policies.Checker]
+func (p *Policies) Rules(subject PolicySubject, userInputs ...string) []Rule {
+	return p.Resolve(subject).rules(p.corpus, userInputs)
+}
+
+// Checker 创建适用策略的密码检查器，userInputs 含义同 [Policies.Rules]
+func (p *Policies) Checker(subject PolicySubject, userInputs ...string) PasswordChecker {
+	return NewPasswordChecker(p.Rules(subject, userInputs...)...)
+}
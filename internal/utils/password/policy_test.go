@@ -0,0 +1,126 @@
+package password
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolicyConfig_Validate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config PolicyConfig
+		ok     bool
+	}{
+		{"default", DefaultPolicyConfig, true},
+		{"zero min length", PolicyConfig{MinLength: 0, MaxLength: 32}, false},
+		{"max less than min", PolicyConfig{MinLength: 12, MaxLength: 8}, false},
+		{"min strength out of range", PolicyConfig{MinLength: 8, MaxLength: 32, MinStrength: 5}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.ok && err != nil {
+				t.Fatalf("expected ok, got err: %v", err)
+			}
+			if !tc.ok && !errors.Is(err, ErrInvalidPolicy) {
+				t.Fatalf("expected ErrInvalidPolicy, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPolicyConfig_Rules(t *testing.T) {
+	strict := PolicyConfig{MinLength: 12, MaxLength: 64, RequireDigit: true, RequireUpper: true, RequireLower: true, RequireSymbol: true, BanSimple: true}
+	relaxed := PolicyConfig{MinLength: 6, MaxLength: 64}
+
+	cases := []struct {
+		name     string
+		config   PolicyConfig
+		password string
+		ok       bool
+	}{
+		{"strict ok", strict, "Abcd1234!xyz", true},
+		{"strict too short", strict, "Abc123!x", false},
+		{"strict missing symbol", strict, "Abcd1234xyzw", false},
+		{"relaxed digits only", relaxed, "135790", true},
+		{"relaxed too short", relaxed, "13579", false},
+		{"default ok", DefaultPolicyConfig, "Abc13579", true},
+		{"default simple", DefaultPolicyConfig, "abcdefgh", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewPasswordChecker(tc.config.Rules()...).Check(tc.password)
+			if tc.ok && err != nil {
+				t.Fatalf("expected ok, got err: %v", err)
+			}
+			if !tc.ok && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestPolicies_Resolve(t *testing.T) {
+	admin := PolicyConfig{MinLength: 16, MaxLength: 64}
+	acme := PolicyConfig{MinLength: 20, MaxLength: 64}
+	policies, err := NewPolicies(PoliciesConfig{
+		Default: DefaultPolicyConfig,
+		Roles:   map[string]PolicyConfig{"admin": admin},
+		Tenants: map[string]PolicyConfig{"acme": acme},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicies() error = %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		subject PolicySubject
+		want    PolicyConfig
+	}{
+		{"default", PolicySubject{}, DefaultPolicyConfig},
+		{"unknown role", PolicySubject{Roles: []string{"member"}}, DefaultPolicyConfig},
+		{"role", PolicySubject{Roles: []string{"member", "admin"}}, admin},
+		{"tenant overrides role", PolicySubject{Tenant: "acme", Roles: []string{"admin"}}, acme},
+		{"unknown tenant falls back to role", PolicySubject{Tenant: "other", Roles: []string{"admin"}}, admin},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := policies.Resolve(tc.subject); got != tc.want {
+				t.Fatalf("Resolve() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewPolicies_Invalid(t *testing.T) {
+	_, err := NewPolicies(PoliciesConfig{
+		Default: DefaultPolicyConfig,
+		Roles:   map[string]PolicyConfig{"admin": {MinLength: 16, MaxLength: 8}},
+	})
+	if !errors.Is(err, ErrInvalidPolicy) {
+		t.Fatalf("expected ErrInvalidPolicy, got %v", err)
+	}
+}
+
+func TestPolicies_CheckerMinStrength(t *testing.T) {
+	policies, err := NewPolicies(PoliciesConfig{
+		Default: PolicyConfig{MinLength: 8, MaxLength: 64, MinStrength: 3},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicies() error = %v", err)
+	}
+
+	if err := policies.Checker(PolicySubject{}).Check("kj3$9fQ!zP2v"); err != nil {
+		t.Errorf("expected strong password to pass, got %v", err)
+	}
+
+	// 包含个人信息的密码评分更低
+	err = policies.Checker(PolicySubject{}, "johndoe").Check("Johndoe2024")
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) || policyErr.Violations[0].Code != CodeTooWeak {
+		t.Fatalf("expected too_weak violation, got %v", err)
+	}
+}
@@ -2,17 +2,35 @@
 package password
 
 import (
-	"errors"
-	"fmt"
 	"regexp"
 	"strings"
 )
 
+// Symbols 特殊符号字符集，[CharTypeRule] 据此判断是否包含特殊符号
+const Symbols = "~!@#$%^&*()_+-=[]{}|;:,.<>?"
+
+// 违规代码，稳定且机器可读，客户端可以据此自行映射提示文案
+const (
+	CodeTooShort         = "too_short"              // 长度不足，参数：min
+	CodeTooLong          = "too_long"               // 长度超出，参数：max
+	CodeMissingDigit     = "missing_digit"          // 缺少数字
+	CodeMissingUpper     = "missing_upper"          // 缺少大写字母
+	CodeMissingLower     = "missing_lower"          // 缺少小写字母
+	CodeMissingSymbol    = "missing_symbol"         // 缺少特殊符号，参数：symbols
+	CodeDigitsOrLetters  = "only_digits_or_letters" // 纯数字或纯字母
+	CodeConsecutiveChars = "consecutive_chars"      // 连续的数字或字母
+	CodeRepeatedChars    = "repeated_chars"         // 重复的字符
+	CodeBreached         = "breached"               // 在数据泄露中出现过或过于常见
+	CodeTooWeak          = "too_weak"               // 强度评分过低，参数：min_score、score
+	CodeReused           = "reused"                 // 与最近使用过的密码相同，参数：count
+)
+
 // -------------------------- 定义校验规则接口 --------------------------
 
 // Rule 单个校验规则的接口，每种规则实现自己的Check方法
+// 返回密码违反的所有约束，没有违反时返回空
 type Rule interface {
-	Check(password string) error
+	Check(password string) []Violation
 }
 
 // -------------------------- 实现各细分规则 --------------------------
@@ -34,13 +52,13 @@ func NewLengthRule(config LengthConfig) Rule {
 	return &LengthRule{config: config}
 }
 
-func (r *LengthRule) Check(password string) error {
+func (r *LengthRule) Check(password string) []Violation {
 	length := len(password)
 	if length < r.config.Min {
-		return fmt.Errorf("密码长度不能小于%d位", r.config.Min)
+		return []Violation{{Code: CodeTooShort, Params: map[string]any{"min": r.config.Min}}}
 	}
 	if length > r.config.Max {
-		return fmt.Errorf("密码长度不能大于%d位", r.config.Max)
+		return []Violation{{Code: CodeTooLong, Params: map[string]any{"max": r.config.Max}}}
 	}
 	return nil
 }
@@ -64,7 +82,7 @@ func NewCharTypeRule(config CharTypeConfig) Rule {
 	return &CharTypeRule{config: config}
 }
 
-func (r *CharTypeRule) Check(password string) error {
+func (r *CharTypeRule) Check(password string) []Violation {
 	var (
 		hasDigit  = false
 		hasUpper  = false
@@ -80,24 +98,25 @@ func (r *CharTypeRule) Check(password string) error {
 			hasUpper = true
 		case char >= 'a' && char <= 'z':
 			hasLower = true
-		case strings.ContainsRune("~!@#$%^&*()_+-=[]{}|;:,.<>?", char):
+		case strings.ContainsRune(Symbols, char):
 			hasSymbol = true
 		}
 	}
 
+	var violations []Violation
 	if r.config.RequireDigit && !hasDigit {
-		return errors.New("密码必须包含数字")
+		violations = append(violations, Violation{Code: CodeMissingDigit})
 	}
 	if r.config.RequireUpper && !hasUpper {
-		return errors.New("密码必须包含大写字母")
+		violations = append(violations, Violation{Code: CodeMissingUpper})
 	}
 	if r.config.RequireLower && !hasLower {
-		return errors.New("密码必须包含小写字母")
+		violations = append(violations, Violation{Code: CodeMissingLower})
 	}
 	if r.config.RequireSymbol && !hasSymbol {
-		return errors.New("密码必须包含特殊符号（~!@#$%^&*()_+-=[]{}|;:,.<>?）")
+		violations = append(violations, Violation{Code: CodeMissingSymbol, Params: map[string]any{"symbols": Symbols}})
 	}
-	return nil
+	return violations
 }
 
 // -------- SimplePasswordRule 简单密码校验规则 --------
@@ -116,26 +135,31 @@ func NewSimplePasswordRule(config SimplePasswordConfig) Rule {
 	return &SimplePasswordRule{config: config}
 }
 
-func (r *SimplePasswordRule) Check(password string) error {
+var (
+	numRegex    = regexp.MustCompile(`^[0-9]+$`)
+	letterRegex = regexp.MustCompile(`^[a-zA-Z]+$`)
+)
+
+func (r *SimplePasswordRule) Check(password string) []Violation {
 	if !r.config.BanSimple {
 		return nil
 	}
 
+	var violations []Violation
+
 	// 纯数字/纯字母校验
-	numRegex := regexp.MustCompile(`^[0-9]+$`)
-	letterRegex := regexp.MustCompile(`^[a-zA-Z]+$`)
 	if numRegex.MatchString(password) || letterRegex.MatchString(password) {
-		return errors.New("密码不能为纯数字或纯字母")
+		violations = append(violations, Violation{Code: CodeDigitsOrLetters})
 	}
 
 	// 连续字符校验
 	if isConsecutive(password) {
-		return errors.New("密码不能包含连续的数字或字母")
+		violations = append(violations, Violation{Code: CodeConsecutiveChars})
 	}
 
 	// 重复字符校验
 	if isRepeated(password) {
-		return errors.New("密码不能包含重复的字符")
+		violations = append(violations, Violation{Code: CodeRepeatedChars})
 	}
-	return nil
+	return violations
 }
@@ -19,12 +19,12 @@ func TestLengthRule(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := rule.Check(tc.password)
-			if tc.ok && err != nil {
-				t.Fatalf("expected ok, got err: %v", err)
+			violations := rule.Check(tc.password)
+			if tc.ok && len(violations) > 0 {
+				t.Fatalf("expected ok, got violations: %v", violations)
 			}
-			if !tc.ok && err == nil {
-				t.Fatalf("expected error, got nil")
+			if !tc.ok && len(violations) == 0 {
+				t.Fatalf("expected violations, got none")
 			}
 		})
 	}
@@ -52,12 +52,12 @@ func TestCharTypeRule(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := rule.Check(tc.password)
-			if tc.ok && err != nil {
-				t.Fatalf("expected ok, got err: %v", err)
+			violations := rule.Check(tc.password)
+			if tc.ok && len(violations) > 0 {
+				t.Fatalf("expected ok, got violations: %v", violations)
 			}
-			if !tc.ok && err == nil {
-				t.Fatalf("expected error, got nil")
+			if !tc.ok && len(violations) == 0 {
+				t.Fatalf("expected violations, got none")
 			}
 		})
 	}
@@ -84,12 +84,12 @@ func TestSimplePasswordRule(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.rule.Check(tc.password)
-			if tc.ok && err != nil {
-				t.Fatalf("expected ok, got err: %v", err)
+			violations := tc.rule.Check(tc.password)
+			if tc.ok && len(violations) > 0 {
+				t.Fatalf("expected ok, got violations: %v", violations)
 			}
-			if !tc.ok && err == nil {
-				t.Fatalf("expected error, got nil")
+			if !tc.ok && len(violations) == 0 {
+				t.Fatalf("expected violations, got none")
 			}
 		})
 	}
@@ -109,7 +109,8 @@ func TestPasswordService_Check(t *testing.T) {
 		{"no upper", "abc12345", false},
 		{"no lower", "ABC12345", false},
 		{"repeated simple banned", "AAAAAAAA", false},
-		{"valid default", "Password1", true},
+		{"common banned", "Password1", false},
+		{"valid default", "Xy7Kp2mQ", true},
 		{"valid with symbol", "Passw0rd!", true},
 	}
 
@@ -155,25 +156,3 @@ func TestPasswordService_CustomRules(t *testing.T) {
 		})
 	}
 }
-
-func TestPasswordService_CheckStrength(t *testing.T) {
-	svc := NewDefaultPasswordChecker()
-
-	cases := []struct {
-		password string
-		want     string
-	}{
-		{"a", "弱"},         // 仅小写 -> 弱
-		{"abcdEFGH", "中"},  // 长度+大小写=3 -> 中
-		{"Password1", "中"}, // 4 -> 中
-		{"Passw0rd!", "中"}, // 当前实现判断为中
-		{"12345678", "弱"},  // 长度+数字=2 -> 弱
-	}
-
-	for i, tc := range cases {
-		got := svc.CheckStrength(tc.password)
-		if got != tc.want {
-			t.Fatalf("case %d: want %s, got %s", i, tc.want, got)
-		}
-	}
-}
@@ -0,0 +1,415 @@
+// Package password 密码强度评估
+package password
+
+import (
+	"math"
+	"time"
+
+	"hello-gozero/pkg/i18n"
+)
+
+// 强度评分的猜测次数阈值：评分 n 表示猜测次数不低于 scoreThresholds[n-1]
+// 阈值参考离线破解速率，评分 3 及以上可以抵御大多数在线与慢哈希的离线攻击
+var scoreThresholds = [4]float64{1e3 + 5, 1e6 + 5, 1e8 + 5, 1e10 + 5}
+
+// MaxScore 最高强度评分
+const MaxScore = 4
+
+const (
+	// maxEstimateLength 参与评估的最大长度，超出部分不再匹配，避免超长输入的计算开销
+	maxEstimateLength = 100
+
+	// bruteforceCardinality 暴力破解时每个字符的猜测次数
+	bruteforceCardinality = 10
+
+	// 匹配片段的最小猜测次数，避免把长密码中的单个字符或短片段估算得过于容易
+	minSubmatchGuessesSingleChar = 10
+	minSubmatchGuessesMultiChar  = 50
+
+	// minGuessesBeforeGrowingSequence 片段数量增加时的额外猜测次数，避免把密码拆成过多的短片段
+	minGuessesBeforeGrowingSequence = 10000
+)
+
+// 强度反馈代码，稳定且机器可读；提示文案通过 [FeedbackMessage] 按语言渲染
+const (
+	FeedbackTop10Password       = "top10_password"        // 警告：十大常见密码之一
+	FeedbackTop100Password      = "top100_password"       // 警告：百大常见密码之一
+	FeedbackCommonPassword      = "common_password"       // 警告：常见密码
+	FeedbackSimilarToCommon     = "similar_to_common"     // 警告：与常见密码相似
+	FeedbackSingleWord          = "single_word"           // 警告：单个单词容易被猜到
+	FeedbackCommonWords         = "common_words"          // 警告：常见单词与人名容易被猜到
+	FeedbackUserInput           = "user_input"            // 警告：包含用户名、邮箱等个人信息
+	FeedbackStraightRow         = "straight_row"          // 警告：键盘上的一行按键
+	FeedbackKeyboardPattern     = "keyboard_pattern"      // 警告：较短的键盘模式
+	FeedbackRepeatedChars       = "repeated_chars"        // 警告：重复的字符
+	FeedbackRepeatedPattern     = "repeated_pattern"      // 警告：重复的片段
+	FeedbackSequence            = "sequence"              // 警告：字母或数字序列
+	FeedbackRecentYears         = "recent_years"          // 警告：近年的年份
+	FeedbackDates               = "dates"                 // 警告：日期
+	FeedbackUseFewWords         = "use_few_words"         // 建议：使用几个不常见的单词
+	FeedbackNoNeedForSymbols    = "no_need_for_symbols"   // 建议：不必强求符号、数字或大写字母
+	FeedbackAddWord             = "add_word"              // 建议：再加一两个不常见的单词
+	FeedbackCapitalization      = "capitalization"        // 建议：首字母大写帮助不大
+	FeedbackAllUppercase        = "all_uppercase"         // 建议：全部大写与全部小写几乎一样容易猜到
+	FeedbackReversedWord        = "reversed_word"         // 建议：倒序书写的单词并不难猜
+	FeedbackL33t                = "l33t"                  // 建议：可预见的替换（如用 @ 代替 a）帮助不大
+	FeedbackLongerKeyboard      = "longer_keyboard"       // 建议：使用更长、转向更多的键盘模式
+	FeedbackAvoidRepeats        = "avoid_repeats"         // 建议：避免重复的单词与字符
+	FeedbackAvoidSequences      = "avoid_sequences"       // 建议：避免序列
+	FeedbackAvoidRecentYears    = "avoid_recent_years"    // 建议：避免近年以及与自己相关的年份
+	FeedbackAvoidDates          = "avoid_dates"           // 建议：避免与自己相关的日期
+	FeedbackAvoidPersonalInputs = "avoid_personal_inputs" // 建议：避免使用个人信息
+)
+
+// Strength 密码强度评估结果
+type Strength struct {
+	// 强度评分 0-4，越高越难猜到
+	Score int
+
+	// 估算的猜测次数
+	Guesses float64
+
+	// 猜测次数的常用对数
+	GuessesLog10 float64
+
+	// 警告代码（见 Feedback* 常量），可能为空
+	Warning string
+
+	// 建议代码（见 Feedback* 常量），评分较高时为空
+	Suggestions []string
+}
+
+// estimation 估算结果：猜测次数与最优的片段拆分
+type estimation struct {
+	guesses  float64
+	sequence []*strengthMatch
+}
+
+// EstimateStrength 评估密码强度
+//
+// 参考 zxcvbn 的思路：先匹配常见密码、英文单词、用户信息（userInputs，如用户名、邮箱）、
+// 键盘模式、重复、序列、年份与日期等模式（字典匹配同时考虑大小写变化、倒序与 l33t 替换），
+// 再选择猜测次数最少的片段拆分，未匹配任何模式的片段按暴力破解估算
+func EstimateStrength(password string, userInputs ...string) Strength {
+	runes := []rune(password)
+	if len(runes) > maxEstimateLength {
+		runes = runes[:maxEstimateLength]
+	}
+
+	result := estimate(runes, userInputDictionary(userInputs))
+	guesses := min(result.guesses, math.MaxFloat64)
+	strength := Strength{
+		Score:        score(guesses),
+		Guesses:      guesses,
+		GuessesLog10: math.Log10(guesses),
+	}
+	strength.Warning, strength.Suggestions = feedback(strength.Score, result.sequence)
+	return strength
+}
+
+// estimate 估算猜测次数最少的片段拆分
+//
+// 动态规划：optimal[k][l] 为前 k+1 个字符拆成 l 个片段时猜测次数最少的拆分；
+// 拆分的猜测次数为 l! × 各片段猜测次数之积 + minGuessesBeforeGrowingSequence^(l-1)，
+// 阶乘项表示片段的排列顺序，后一项避免拆得过碎
+func estimate(password []rune, userDict map[string]int) estimation {
+	n := len(password)
+	if n == 0 {
+		return estimation{guesses: 1}
+	}
+
+	matchesByEnd := make([][]*strengthMatch, n)
+	for _, m := range omnimatch(password, userDict) {
+		matchesByEnd[m.j] = append(matchesByEnd[m.j], m)
+	}
+
+	type candidate struct {
+		match   *strengthMatch
+		product float64 // 各片段猜测次数之积
+		guesses float64 // 拆分的猜测次数
+	}
+	optimal := make([]map[int]candidate, n)
+	for k := range optimal {
+		optimal[k] = make(map[int]candidate)
+	}
+
+	update := func(m *strengthMatch, l int) {
+		k := m.j
+		product := matchGuesses(m, n)
+		if l > 1 {
+			product *= optimal[m.i-1][l-1].product
+		}
+		guesses := factorial(l)*product + math.Pow(minGuessesBeforeGrowingSequence, float64(l-1))
+		// 已有片段更少且猜测次数不更多的拆分时，当前拆分不是最优
+		for other, c := range optimal[k] {
+			if other <= l && c.guesses <= guesses {
+				return
+			}
+		}
+		optimal[k][l] = candidate{match: m, product: product, guesses: guesses}
+	}
+
+	bruteforce := func(i, j int) *strengthMatch {
+		return &strengthMatch{
+			pattern: patternBruteforce,
+			i:       i,
+			j:       j,
+			token:   string(password[i : j+1]),
+			guesses: math.Pow(bruteforceCardinality, float64(j-i+1)),
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for _, m := range matchesByEnd[k] {
+			if m.i == 0 {
+				update(m, 1)
+				continue
+			}
+			for l := range optimal[m.i-1] {
+				update(m, l+1)
+			}
+		}
+
+		update(bruteforce(0, k), 1)
+		for i := 1; i <= k; i++ {
+			m := bruteforce(i, k)
+			for l, c := range optimal[i-1] {
+				// 相邻的暴力破解片段等价于一个更长的片段，不需要重复考虑
+				if c.match.pattern == patternBruteforce {
+					continue
+				}
+				update(m, l+1)
+			}
+		}
+	}
+
+	// 选择猜测次数最少的拆分并回溯
+	bestL, best := 0, math.Inf(1)
+	for l, c := range optimal[n-1] {
+		if c.guesses < best || (c.guesses == best && l < bestL) {
+			bestL, best = l, c.guesses
+		}
+	}
+	sequence := make([]*strengthMatch, bestL)
+	for k, l := n-1, bestL; l > 0; l-- {
+		m := optimal[k][l].match
+		sequence[l-1] = m
+		k = m.i - 1
+	}
+	return estimation{guesses: best, sequence: sequence}
+}
+
+// matchGuesses 片段的猜测次数，短片段不低于最小猜测次数
+func matchGuesses(m *strengthMatch, passwordLength int) float64 {
+	length := m.j - m.i + 1
+	if length == passwordLength {
+		return max(m.guesses, 1)
+	}
+	minGuesses := float64(minSubmatchGuessesMultiChar)
+	if length == 1 {
+		minGuesses = minSubmatchGuessesSingleChar
+	}
+	return max(m.guesses, minGuesses)
+}
+
+// score 根据猜测次数计算强度评分
+func score(guesses float64) int {
+	for i, threshold := range scoreThresholds {
+		if guesses < threshold {
+			return i
+		}
+	}
+	return MaxScore
+}
+
+// feedback 根据最优拆分中最长的片段给出警告与建议
+func feedback(score int, sequence []*strengthMatch) (string, []string) {
+	if len(sequence) == 0 {
+		return "", []string{FeedbackUseFewWords, FeedbackNoNeedForSymbols}
+	}
+	if score > 2 {
+		return "", nil
+	}
+
+	longest := sequence[0]
+	for _, m := range sequence[1:] {
+		if len(m.token) > len(longest.token) {
+			longest = m
+		}
+	}
+
+	warning, suggestions := matchFeedback(longest, len(sequence) == 1)
+	return warning, append([]string{FeedbackAddWord}, suggestions...)
+}
+
+// matchFeedback 单个片段的警告与建议
+func matchFeedback(m *strengthMatch, soleMatch bool) (string, []string) {
+	switch m.pattern {
+	case patternDictionary:
+		return dictionaryFeedback(m, soleMatch)
+	case patternSpatial:
+		if m.turns == 1 {
+			return FeedbackStraightRow, []string{FeedbackLongerKeyboard}
+		}
+		return FeedbackKeyboardPattern, []string{FeedbackLongerKeyboard}
+	case patternRepeat:
+		if len([]rune(m.baseToken)) == 1 {
+			return FeedbackRepeatedChars, []string{FeedbackAvoidRepeats}
+		}
+		return FeedbackRepeatedPattern, []string{FeedbackAvoidRepeats}
+	case patternSequence:
+		return FeedbackSequence, []string{FeedbackAvoidSequences}
+	case patternYear:
+		return FeedbackRecentYears, []string{FeedbackAvoidRecentYears}
+	case patternDate:
+		return FeedbackDates, []string{FeedbackAvoidDates}
+	default:
+		return "", nil
+	}
+}
+
+// dictionaryFeedback 字典片段的警告与建议
+func dictionaryFeedback(m *strengthMatch, soleMatch bool) (string, []string) {
+	var warning string
+	switch m.dictName {
+	case dictPasswords:
+		switch {
+		case soleMatch && !m.l33t && !m.reversed && m.rank <= 10:
+			warning = FeedbackTop10Password
+		case soleMatch && !m.l33t && !m.reversed && m.rank <= 100:
+			warning = FeedbackTop100Password
+		case soleMatch && !m.l33t && !m.reversed:
+			warning = FeedbackCommonPassword
+		case math.Log10(m.guesses) <= 4:
+			warning = FeedbackSimilarToCommon
+		}
+	case dictEnglish:
+		if soleMatch {
+			warning = FeedbackSingleWord
+		} else {
+			warning = FeedbackCommonWords
+		}
+	case dictUserInputs:
+		warning = FeedbackUserInput
+	}
+
+	var suggestions []string
+	runes := []rune(m.token)
+	switch {
+	case uppercaseVariations(m.token) == 1:
+	case isAllUpper(m.token):
+		suggestions = append(suggestions, FeedbackAllUppercase)
+	case runes[0] >= 'A' && runes[0] <= 'Z':
+		suggestions = append(suggestions, FeedbackCapitalization)
+	}
+	if m.reversed && len(runes) >= 4 {
+		suggestions = append(suggestions, FeedbackReversedWord)
+	}
+	if m.l33t {
+		suggestions = append(suggestions, FeedbackL33t)
+	}
+	if m.dictName == dictUserInputs {
+		suggestions = append(suggestions, FeedbackAvoidPersonalInputs)
+	}
+	return warning, suggestions
+}
+
+func isAllUpper(s string) bool {
+	hasUpper := false
+	for _, r := range s {
+		if r >= 'a' && r <= 'z' {
+			return false
+		}
+		if r >= 'A' && r <= 'Z' {
+			hasUpper = true
+		}
+	}
+	return hasUpper
+}
+
+// factorial 阶乘，参与评估的长度有上限，float64 足够表示
+func factorial(n int) float64 {
+	f := 1.0
+	for i := 2; i <= n; i++ {
+		f *= float64(i)
+	}
+	return f
+}
+
+// currentYear 当前年份
+func currentYear() int {
+	return time.Now().Year()
+}
+
+// FeedbackMessage 按语言渲染强度反馈代码的提示文案
+func FeedbackMessage(locale i18n.Locale, code string) string {
+	return i18n.Translate(locale, feedbackKeyPrefix+code, nil)
+}
+
+// -------- StrengthRule 强度校验规则 --------
+
+// StrengthRule 强度校验规则，强度评分低于最低评分时拒绝
+type StrengthRule struct {
+	minScore   int
+	userInputs []string
+}
+
+// NewStrengthRule 创建强度校验规则，userInputs 为用户名、邮箱等个人信息，密码中包含这些信息时评分更低
+func NewStrengthRule(minScore int, userInputs ...string) Rule {
+	return &StrengthRule{minScore: minScore, userInputs: userInputs}
+}
+
+func (r *StrengthRule) Check(password string) []Violation {
+	strength := EstimateStrength(password, r.userInputs...)
+	if strength.Score >= r.minScore {
+		return nil
+	}
+	return []Violation{{
+		Code:   CodeTooWeak,
+		Params: map[string]any{"min_score": r.minScore, "score": strength.Score},
+	}}
+}
+
+// feedbackKeyPrefix 强度反馈提示文案在 i18n 中的消息键前缀
+const feedbackKeyPrefix = "password.strength."
+
+func init() {
+	messages := map[string][2]string{
+		FeedbackTop10Password:       {"This is a top-10 common password.", "这是最常见的十个密码之一"},
+		FeedbackTop100Password:      {"This is a top-100 common password.", "这是最常见的一百个密码之一"},
+		FeedbackCommonPassword:      {"This is a very common password.", "这是非常常见的密码"},
+		FeedbackSimilarToCommon:     {"This is similar to a commonly used password.", "与常见密码相似"},
+		FeedbackSingleWord:          {"A word by itself is easy to guess.", "单个单词很容易被猜到"},
+		FeedbackCommonWords:         {"Common words and names are easy to guess.", "常见单词与人名很容易被猜到"},
+		FeedbackUserInput:           {"Passwords containing your personal details are easy to guess.", "包含个人信息的密码很容易被猜到"},
+		FeedbackStraightRow:         {"Straight rows of keys are easy to guess.", "键盘上的一行按键很容易被猜到"},
+		FeedbackKeyboardPattern:     {"Short keyboard patterns are easy to guess.", "较短的键盘模式很容易被猜到"},
+		FeedbackRepeatedChars:       {"Repeats like \"aaa\" are easy to guess.", "\"aaa\" 这样的重复很容易被猜到"},
+		FeedbackRepeatedPattern:     {"Repeats like \"abcabcabc\" are only slightly harder to guess than \"abc\".", "\"abcabcabc\" 这样的重复只比 \"abc\" 稍难猜到"},
+		FeedbackSequence:            {"Sequences like \"abc\" or \"6543\" are easy to guess.", "\"abc\"、\"6543\" 这样的序列很容易被猜到"},
+		FeedbackRecentYears:         {"Recent years are easy to guess.", "近年的年份很容易被猜到"},
+		FeedbackDates:               {"Dates are often easy to guess.", "日期通常很容易被猜到"},
+		FeedbackUseFewWords:         {"Use a few words, avoid common phrases.", "使用几个单词，避免常见短语"},
+		FeedbackNoNeedForSymbols:    {"No need for symbols, digits, or uppercase letters.", "不必强求符号、数字或大写字母"},
+		FeedbackAddWord:             {"Add another word or two. Uncommon words are better.", "再加一两个单词，不常见的单词更好"},
+		FeedbackCapitalization:      {"Capitalization doesn't help very much.", "首字母大写帮助不大"},
+		FeedbackAllUppercase:        {"All-uppercase is almost as easy to guess as all-lowercase.", "全部大写与全部小写几乎一样容易被猜到"},
+		FeedbackReversedWord:        {"Reversed words aren't much harder to guess.", "倒序书写的单词并不难猜"},
+		FeedbackL33t:                {"Predictable substitutions like '@' instead of 'a' don't help very much.", "用 '@' 代替 'a' 这样可预见的替换帮助不大"},
+		FeedbackLongerKeyboard:      {"Use a longer keyboard pattern with more turns.", "使用更长、转向更多的键盘模式"},
+		FeedbackAvoidRepeats:        {"Avoid repeated words and characters.", "避免重复的单词与字符"},
+		FeedbackAvoidSequences:      {"Avoid sequences.", "避免序列"},
+		FeedbackAvoidRecentYears:    {"Avoid recent years and years that are associated with you.", "避免近年以及与自己相关的年份"},
+		FeedbackAvoidDates:          {"Avoid dates and years that are associated with you.", "避免与自己相关的日期和年份"},
+		FeedbackAvoidPersonalInputs: {"Avoid your username, email address and other personal details.", "避免使用用户名、邮箱等个人信息"},
+	}
+
+	registered := make(i18n.Messages, len(messages))
+	for code, message := range messages {
+		registered[feedbackKeyPrefix+code] = map[i18n.Locale]string{
+			i18n.LocaleEN: message[0],
+			i18n.LocaleZH: message[1],
+		}
+	}
+	i18n.Register(registered)
+}
@@ -0,0 +1,725 @@
+// Package password 密码强度评估的模式匹配
+package password
+
+import (
+	_ "embed"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// englishWords 内置的常用英文单词与人名，按使用频率大致降序排列
+//
+//go:embed english_words.txt
+var englishWords string
+
+// 匹配到的模式
+const (
+	patternDictionary = "dictionary" // 字典词（常见密码、英文单词、用户信息）
+	patternSpatial    = "spatial"    // 键盘相邻按键
+	patternRepeat     = "repeat"     // 重复的字符或片段
+	patternSequence   = "sequence"   // 字母或数字序列
+	patternYear       = "year"       // 年份
+	patternDate       = "date"       // 日期
+	patternBruteforce = "bruteforce" // 没有匹配到任何模式，只能暴力破解
+)
+
+// 字典名称
+const (
+	dictPasswords  = "passwords"
+	dictEnglish    = "english"
+	dictUserInputs = "user_inputs"
+)
+
+// strengthMatch 密码中匹配到模式的一段，i、j 为起止字符下标（包含 j）
+type strengthMatch struct {
+	pattern string
+	i, j    int
+	token   string
+	guesses float64
+
+	// dictionary
+	dictName string
+	rank     int
+	reversed bool
+	l33t     bool
+
+	// repeat
+	baseToken string
+
+	// spatial
+	turns int
+}
+
+// rankedDictionaries 内置字典，key 为小写词，value 为排名（从 1 开始）
+var rankedDictionaries = sync.OnceValue(func() map[string]map[string]int {
+	return map[string]map[string]int{
+		dictPasswords: buildRankedDictionary(splitLines(builtinCommonPasswords)),
+		dictEnglish:   buildRankedDictionary(splitLines(englishWords)),
+	}
+})
+
+func buildRankedDictionary(words []string) map[string]int {
+	dict := make(map[string]int, len(words))
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if _, ok := dict[word]; !ok {
+			dict[word] = i + 1
+		}
+	}
+	return dict
+}
+
+// userInputDictionary 用户信息字典：原值及按非字母数字字符拆分出的片段（如邮箱的用户名与域名）
+func userInputDictionary(userInputs []string) map[string]int {
+	var words []string
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" {
+			continue
+		}
+		words = append(words, input)
+		words = append(words, strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	dict := make(map[string]int, len(words))
+	for i, word := range words {
+		if len([]rune(word)) < minDictionaryWordLength {
+			continue
+		}
+		if _, ok := dict[word]; !ok {
+			dict[word] = i + 1
+		}
+	}
+	return dict
+}
+
+// minDictionaryWordLength 字典匹配的最小长度，更短的片段交给其他模式与暴力破解估算
+const minDictionaryWordLength = 3
+
+// omnimatch 执行所有匹配器
+func omnimatch(password []rune, userDict map[string]int) []*strengthMatch {
+	dicts := map[string]map[string]int{dictUserInputs: userDict}
+	for name, dict := range rankedDictionaries() {
+		dicts[name] = dict
+	}
+
+	var matches []*strengthMatch
+	matches = append(matches, dictionaryMatch(password, dicts)...)
+	matches = append(matches, reverseDictionaryMatch(password, dicts)...)
+	matches = append(matches, l33tMatch(password, dicts)...)
+	matches = append(matches, spatialMatch(password)...)
+	matches = append(matches, repeatMatch(password, userDict)...)
+	matches = append(matches, sequenceMatch(password)...)
+	matches = append(matches, yearMatch(password)...)
+	matches = append(matches, dateMatch(password)...)
+	return matches
+}
+
+// -------- 字典匹配 --------
+
+func dictionaryMatch(password []rune, dicts map[string]map[string]int) []*strengthMatch {
+	lower := []rune(strings.ToLower(string(password)))
+	var matches []*strengthMatch
+	for name, dict := range dicts {
+		for i := 0; i < len(lower); i++ {
+			for j := i + minDictionaryWordLength - 1; j < len(lower); j++ {
+				rank, ok := dict[string(lower[i:j+1])]
+				if !ok {
+					continue
+				}
+				token := string(password[i : j+1])
+				matches = append(matches, &strengthMatch{
+					pattern:  patternDictionary,
+					i:        i,
+					j:        j,
+					token:    token,
+					dictName: name,
+					rank:     rank,
+					guesses:  float64(rank) * uppercaseVariations(token),
+				})
+			}
+		}
+	}
+	return matches
+}
+
+// reverseDictionaryMatch 匹配倒序书写的字典词，如 "drowssap"
+func reverseDictionaryMatch(password []rune, dicts map[string]map[string]int) []*strengthMatch {
+	n := len(password)
+	reversed := make([]rune, n)
+	for i, r := range password {
+		reversed[n-1-i] = r
+	}
+
+	matches := dictionaryMatch(reversed, dicts)
+	for _, m := range matches {
+		m.i, m.j = n-1-m.j, n-1-m.i
+		m.token = string(password[m.i : m.j+1])
+		m.reversed = true
+		m.guesses *= 2
+	}
+	return matches
+}
+
+// l33tTable l33t 替换表：替换字符 -> 可能的原字母
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'},
+	'8': {'b'},
+	'(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'},
+	'6': {'g'}, '9': {'g'},
+	'1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'0': {'o'},
+	'$': {'s'}, '5': {'s'},
+	'+': {'t'}, '7': {'t'},
+	'%': {'x'},
+	'2': {'z'},
+}
+
+// maxL33tVariants 每个密码最多尝试的 l33t 还原方案数量，避免组合爆炸
+const maxL33tVariants = 64
+
+// l33tMatch 匹配使用 l33t 替换的字典词，如 "p@ssw0rd"
+func l33tMatch(password []rune, dicts map[string]map[string]int) []*strengthMatch {
+	lower := []rune(strings.ToLower(string(password)))
+
+	// 生成还原方案：每个方案是 下标 -> 原字母
+	variants := []map[int]rune{{}}
+	for i, r := range lower {
+		letters, ok := l33tTable[r]
+		if !ok {
+			continue
+		}
+		var next []map[int]rune
+		for _, variant := range variants {
+			for _, letter := range letters {
+				if len(next) >= maxL33tVariants {
+					break
+				}
+				sub := make(map[int]rune, len(variant)+1)
+				for k, v := range variant {
+					sub[k] = v
+				}
+				sub[i] = letter
+				next = append(next, sub)
+			}
+		}
+		variants = next
+	}
+	if len(variants) == 1 && len(variants[0]) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var matches []*strengthMatch
+	for _, sub := range variants {
+		unsubbed := make([]rune, len(lower))
+		copy(unsubbed, lower)
+		for i, letter := range sub {
+			unsubbed[i] = letter
+		}
+
+		for _, m := range dictionaryMatch(unsubbed, dicts) {
+			// 片段中没有任何替换时已经由普通字典匹配覆盖
+			subs := make(map[rune]rune)
+			for k := m.i; k <= m.j; k++ {
+				if letter, ok := sub[k]; ok {
+					subs[lower[k]] = letter
+				}
+			}
+			if len(subs) == 0 {
+				continue
+			}
+			key := strconv.Itoa(m.i) + ":" + strconv.Itoa(m.j) + ":" + m.dictName + ":" + strconv.Itoa(m.rank)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			m.token = string(password[m.i : m.j+1])
+			m.l33t = true
+			m.guesses = float64(m.rank) * uppercaseVariations(m.token) * l33tVariations(strings.ToLower(m.token), subs)
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// uppercaseVariations 大小写变化带来的额外猜测次数：全小写不增加，首字母或末字母大写、全大写翻倍，其他按组合数计算
+func uppercaseVariations(token string) float64 {
+	var upper, lower int
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	runes := []rune(token)
+	if lower == 0 ||
+		(upper == 1 && unicode.IsUpper(runes[0])) ||
+		(upper == 1 && unicode.IsUpper(runes[len(runes)-1])) {
+		return 2
+	}
+	var variations float64
+	for i := 1; i <= min(upper, lower); i++ {
+		variations += nCk(upper+lower, i)
+	}
+	return variations
+}
+
+// l33tVariations l33t 替换带来的额外猜测次数，token 为小写的原始片段，subs 为 替换字符 -> 原字母
+func l33tVariations(token string, subs map[rune]rune) float64 {
+	variations := 1.0
+	for subbed, letter := range subs {
+		s := strings.Count(token, string(subbed))
+		u := strings.Count(token, string(letter))
+		if s == 0 || u == 0 {
+			// 全部替换或全部未替换，只需要额外尝试一次
+			variations *= 2
+			continue
+		}
+		var possibilities float64
+		for i := 1; i <= min(u, s); i++ {
+			possibilities += nCk(u+s, i)
+		}
+		variations *= possibilities
+	}
+	return variations
+}
+
+// -------- 键盘模式匹配 --------
+
+// qwertyRows QWERTY 键盘布局，每个按键为 未按 Shift 与按下 Shift 的字符
+var qwertyRows = [][]string{
+	{"`~", "1!", "2@", "3#", "4$", "5%", "6^", "7&", "8*", "9(", "0)", "-_", "=+"},
+	{"qQ", "wW", "eE", "rR", "tT", "yY", "uU", "iI", "oO", "pP", "[{", "]}", "\\|"},
+	{"aA", "sS", "dD", "fF", "gG", "hH", "jJ", "kK", "lL", ";:", "'\""},
+	{"zZ", "xX", "cC", "vV", "bB", "nN", "mM", ",<", ".>", "/?"},
+}
+
+// qwertyRowOffsets 每行第一个按键的横向位置（以半个按键为单位），体现键盘的错位排列
+var qwertyRowOffsets = []int{0, 3, 4, 5}
+
+// keyboardKey 键盘上的一个按键
+type keyboardKey struct {
+	row, x int
+}
+
+// keyboard 键盘布局：字符 -> 按键与是否需要 Shift
+type keyboard struct {
+	keys    map[rune]keyboardKey
+	shifted map[rune]bool
+	// 方向相同的相邻按键视为直线，方向变化计为一次转向
+	neighbors map[keyboardKey][6]*keyboardKey
+	// 平均相邻按键数与按键数，用于估算猜测次数
+	averageDegree float64
+	startingKeys  float64
+}
+
+var qwerty = sync.OnceValue(func() *keyboard {
+	kb := &keyboard{
+		keys:      make(map[rune]keyboardKey),
+		shifted:   make(map[rune]bool),
+		neighbors: make(map[keyboardKey][6]*keyboardKey),
+	}
+	positions := make(map[keyboardKey]struct{})
+	for row, keys := range qwertyRows {
+		for col, chars := range keys {
+			key := keyboardKey{row: row, x: qwertyRowOffsets[row] + 2*col}
+			positions[key] = struct{}{}
+			runes := []rune(chars)
+			kb.keys[runes[0]] = key
+			kb.keys[runes[1]] = key
+			kb.shifted[runes[1]] = true
+		}
+	}
+
+	// 相邻方向：左、左上、右上、右、右下、左下
+	directions := [6][2]int{{0, -2}, {-1, -1}, {-1, 1}, {0, 2}, {1, 1}, {1, -1}}
+	var degrees int
+	for key := range positions {
+		var neighbors [6]*keyboardKey
+		for d, delta := range directions {
+			neighbor := keyboardKey{row: key.row + delta[0], x: key.x + delta[1]}
+			if _, ok := positions[neighbor]; ok {
+				neighbors[d] = &neighbor
+				degrees++
+			}
+		}
+		kb.neighbors[key] = neighbors
+	}
+	kb.startingKeys = float64(len(positions))
+	kb.averageDegree = float64(degrees) / float64(len(positions))
+	return kb
+})
+
+// direction 返回 to 相对 from 的方向，不相邻时返回 -1
+func (kb *keyboard) direction(from, to rune) int {
+	fromKey, ok := kb.keys[from]
+	if !ok {
+		return -1
+	}
+	toKey, ok := kb.keys[to]
+	if !ok {
+		return -1
+	}
+	for d, neighbor := range kb.neighbors[fromKey] {
+		if neighbor != nil && *neighbor == toKey {
+			return d
+		}
+	}
+	return -1
+}
+
+// minSpatialLength 键盘模式的最小长度
+const minSpatialLength = 3
+
+// spatialMatch 匹配键盘上相邻按键组成的片段，如 "qwerty"、"zxcvbn"、"1qaz2wsx"
+func spatialMatch(password []rune) []*strengthMatch {
+	kb := qwerty()
+	var matches []*strengthMatch
+	for i := 0; i < len(password)-1; {
+		j := i
+		lastDirection := -1
+		turns := 0
+		shifted := 0
+		if kb.shifted[password[i]] {
+			shifted++
+		}
+		for j+1 < len(password) {
+			d := kb.direction(password[j], password[j+1])
+			if d < 0 {
+				break
+			}
+			if d != lastDirection {
+				turns++
+				lastDirection = d
+			}
+			if kb.shifted[password[j+1]] {
+				shifted++
+			}
+			j++
+		}
+		if j-i+1 >= minSpatialLength {
+			token := string(password[i : j+1])
+			matches = append(matches, &strengthMatch{
+				pattern: patternSpatial,
+				i:       i,
+				j:       j,
+				token:   token,
+				turns:   turns,
+				guesses: spatialGuesses(kb, j-i+1, turns, shifted),
+			})
+		}
+		if j == i {
+			i++
+		} else {
+			i = j
+		}
+	}
+	return matches
+}
+
+// spatialGuesses 键盘模式的猜测次数：起始按键数 × 各长度与转向次数的走法数，按下 Shift 的按键按大小写的方式计算变化
+func spatialGuesses(kb *keyboard, length, turns, shifted int) float64 {
+	var guesses float64
+	for i := 2; i <= length; i++ {
+		for t := 1; t <= min(turns, i-1); t++ {
+			guesses += nCk(i-1, t-1) * kb.startingKeys * math.Pow(kb.averageDegree, float64(t))
+		}
+	}
+	if shifted > 0 {
+		unshifted := length - shifted
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			var variations float64
+			for i := 1; i <= min(shifted, unshifted); i++ {
+				variations += nCk(shifted+unshifted, i)
+			}
+			guesses *= variations
+		}
+	}
+	return guesses
+}
+
+// -------- 重复匹配 --------
+
+// repeatMatch 匹配重复的字符或片段，如 "aaaa"、"abcabc"；基础片段的猜测次数递归估算
+func repeatMatch(password []rune, userDict map[string]int) []*strengthMatch {
+	var matches []*strengthMatch
+	n := len(password)
+	for i := 0; i < n; {
+		bestLength, bestBase := 0, 0
+		for base := 1; base <= (n-i)/2; base++ {
+			count := 1
+			for i+(count+1)*base <= n && string(password[i+count*base:i+(count+1)*base]) == string(password[i:i+base]) {
+				count++
+			}
+			if count >= 2 && count*base > bestLength {
+				bestLength, bestBase = count*base, base
+			}
+		}
+		if bestLength == 0 {
+			i++
+			continue
+		}
+
+		baseToken := string(password[i : i+bestBase])
+		base := estimate([]rune(baseToken), userDict)
+		matches = append(matches, &strengthMatch{
+			pattern:   patternRepeat,
+			i:         i,
+			j:         i + bestLength - 1,
+			token:     string(password[i : i+bestLength]),
+			baseToken: baseToken,
+			guesses:   base.guesses * float64(bestLength/bestBase),
+		})
+		i += bestLength
+	}
+	return matches
+}
+
+// -------- 序列匹配 --------
+
+// maxSequenceDelta 序列中相邻字符的最大间隔，如 "aceg" 的间隔为 2
+const maxSequenceDelta = 5
+
+// sequenceMatch 匹配间隔固定的字母或数字序列，如 "abcdef"、"13579"、"zyx"
+func sequenceMatch(password []rune) []*strengthMatch {
+	var matches []*strengthMatch
+	n := len(password)
+	i := 0
+	for i < n-2 {
+		delta := int(password[i+1]) - int(password[i])
+		j := i + 1
+		for j+1 < n && int(password[j+1])-int(password[j]) == delta {
+			j++
+		}
+		if delta != 0 && abs(delta) <= maxSequenceDelta && j-i+1 >= 3 && sameCharClass(password[i:j+1]) {
+			token := string(password[i : j+1])
+			matches = append(matches, &strengthMatch{
+				pattern: patternSequence,
+				i:       i,
+				j:       j,
+				token:   token,
+				guesses: sequenceGuesses(password[i], j-i+1, delta > 0),
+			})
+		}
+		i = j
+	}
+	return matches
+}
+
+// sameCharClass 片段的字符是否同属小写字母、大写字母或数字
+func sameCharClass(token []rune) bool {
+	class := func(r rune) int {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 1
+		case r >= 'A' && r <= 'Z':
+			return 2
+		case r >= '0' && r <= '9':
+			return 3
+		default:
+			return 0
+		}
+	}
+	first := class(token[0])
+	for _, r := range token[1:] {
+		if class(r) != first {
+			return false
+		}
+	}
+	return first != 0
+}
+
+// sequenceGuesses 序列的猜测次数：从显而易见的字符开始的序列（如 a、z、0、1、9）最容易被猜到
+func sequenceGuesses(first rune, length int, ascending bool) float64 {
+	var base float64
+	switch {
+	case strings.ContainsRune("aAzZ019", first):
+		base = 4
+	case first >= '0' && first <= '9':
+		base = 10
+	default:
+		base = 26
+	}
+	if !ascending {
+		base *= 2
+	}
+	return base * float64(length)
+}
+
+// -------- 年份与日期匹配 --------
+
+// referenceYear 估算年份与日期猜测次数的参考年份（当前年份）
+var referenceYear = currentYear
+
+// minYearSpace 年份与参考年份的最小间隔，近几年的年份同样需要若干次猜测
+const minYearSpace = 20
+
+var yearRegex = regexp.MustCompile(`19\d\d|20\d\d`)
+
+// yearMatch 匹配年份，如 "1987"、"2024"
+func yearMatch(password []rune) []*strengthMatch {
+	var matches []*strengthMatch
+	s := string(password)
+	for _, loc := range yearRegex.FindAllStringIndex(s, -1) {
+		// 正则返回字节下标，年份片段只包含 ASCII 数字，按前缀的字符数换算
+		i := len([]rune(s[:loc[0]]))
+		year, _ := strconv.Atoi(s[loc[0]:loc[1]])
+		matches = append(matches, &strengthMatch{
+			pattern: patternYear,
+			i:       i,
+			j:       i + 3,
+			token:   s[loc[0]:loc[1]],
+			guesses: yearSpace(year),
+		})
+	}
+	return matches
+}
+
+func yearSpace(year int) float64 {
+	return float64(max(abs(year-referenceYear()), minYearSpace))
+}
+
+// dateSplits 不带分隔符的日期按长度的拆分方式，每种方式为两个拆分点
+var dateSplits = map[int][][2]int{
+	4: {{1, 2}, {2, 3}},         // 1 1 91 | 11 1 1
+	5: {{1, 3}, {2, 3}},         // 1 11 91 | 11 1 91
+	6: {{1, 2}, {2, 4}, {4, 5}}, // 1 1 1991 | 11 11 91 | 1991 1 1
+	7: {{1, 3}, {2, 3}, {4, 5}, {4, 6}},
+	8: {{2, 4}, {4, 6}}, // 11 11 1991 | 1991 11 11
+}
+
+var dateWithSeparatorRegex = regexp.MustCompile(`^(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})$`)
+
+// dateMatch 匹配日期，如 "19870412"、"4/12/87"、"1987-04-12"
+func dateMatch(password []rune) []*strengthMatch {
+	var matches []*strengthMatch
+	n := len(password)
+
+	// 不带分隔符
+	for i := 0; i <= n-4; i++ {
+		for j := i + 3; j <= i+7 && j < n; j++ {
+			token := string(password[i : j+1])
+			if !isDigits(token) {
+				break
+			}
+			bestYear, found := 0, false
+			for _, split := range dateSplits[len(token)] {
+				a, _ := strconv.Atoi(token[:split[0]])
+				b, _ := strconv.Atoi(token[split[0]:split[1]])
+				c, _ := strconv.Atoi(token[split[1]:])
+				if year, ok := mapDate(a, b, c); ok && (!found || abs(year-referenceYear()) < abs(bestYear-referenceYear())) {
+					bestYear, found = year, true
+				}
+			}
+			if found {
+				matches = append(matches, &strengthMatch{
+					pattern: patternDate,
+					i:       i,
+					j:       j,
+					token:   token,
+					guesses: 365 * yearSpace(bestYear),
+				})
+			}
+		}
+	}
+
+	// 带分隔符
+	for i := 0; i <= n-6; i++ {
+		for j := i + 5; j <= i+9 && j < n; j++ {
+			token := string(password[i : j+1])
+			groups := dateWithSeparatorRegex.FindStringSubmatch(token)
+			if groups == nil || groups[2] != groups[4] {
+				continue
+			}
+			a, _ := strconv.Atoi(groups[1])
+			b, _ := strconv.Atoi(groups[3])
+			c, _ := strconv.Atoi(groups[5])
+			if year, ok := mapDate(a, b, c); ok {
+				matches = append(matches, &strengthMatch{
+					pattern: patternDate,
+					i:       i,
+					j:       j,
+					token:   token,
+					// 分隔符有多种选择
+					guesses: 365 * yearSpace(year) * 4,
+				})
+			}
+		}
+	}
+	return matches
+}
+
+// mapDate 将三个数字解释为日期，年份在首位或末位，返回年份
+func mapDate(a, b, c int) (int, bool) {
+	if b < 1 || b > 31 {
+		return 0, false
+	}
+	for _, candidate := range [][3]int{{c, a, b}, {a, b, c}} {
+		year, m, d := candidate[0], candidate[1], candidate[2]
+		if year >= 100 && (year < 1000 || year > 2050) {
+			continue
+		}
+		if year < 100 {
+			// 两位年份：50 以后视为 19xx
+			if year > 50 {
+				year += 1900
+			} else {
+				year += 2000
+			}
+		}
+		if (m >= 1 && m <= 12 && d >= 1 && d <= 31) || (d >= 1 && d <= 12 && m >= 1 && m <= 31) {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// -------- 辅助函数 --------
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// nCk 组合数
+func nCk(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	if k == 0 {
+		return 1
+	}
+	r := 1.0
+	for d := 1; d <= k; d++ {
+		r *= float64(n)
+		r /= float64(d)
+		n--
+	}
+	return r
+}
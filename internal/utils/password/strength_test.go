@@ -0,0 +1,159 @@
+package password
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"hello-gozero/pkg/i18n"
+)
+
+func TestEstimateStrength_Score(t *testing.T) {
+	cases := []struct {
+		name     string
+		password string
+		minScore int
+		maxScore int
+	}{
+		{"empty", "", 0, 0},
+		{"top password", "password", 0, 0},
+		{"capitalized common", "Password1", 0, 0},
+		{"l33t common", "P@ssw0rd", 0, 1},
+		{"reversed common", "drowssap", 0, 1},
+		{"keyboard row", "qwertyuiop", 0, 1},
+		{"keyboard columns", "1qaz2wsx", 0, 1},
+		{"repeated char", "aaaaaaaa", 0, 1},
+		{"repeated pattern", "abcabcabc", 0, 1},
+		{"sequence", "abcdefgh", 0, 1},
+		{"date", "19870412", 0, 1},
+		{"word and year", "Summer2026!", 0, 2},
+		{"random", "kj3$9fQ!zP2v", 3, 4},
+		{"long passphrase", "correcthorsebatterystaple", 4, 4},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := EstimateStrength(tc.password)
+			if s.Score < tc.minScore || s.Score > tc.maxScore {
+				t.Fatalf("EstimateStrength(%q).Score = %d (guesses 10^%.2f), want [%d, %d]",
+					tc.password, s.Score, s.GuessesLog10, tc.minScore, tc.maxScore)
+			}
+		})
+	}
+}
+
+func TestEstimateStrength_Feedback(t *testing.T) {
+	cases := []struct {
+		password   string
+		warning    string
+		suggestion string
+	}{
+		{"password", FeedbackTop10Password, FeedbackAddWord},
+		{"Password1", FeedbackTop10Password, FeedbackCapitalization},
+		{"drowssap", FeedbackSimilarToCommon, FeedbackReversedWord},
+		{"P@ssw0rd", FeedbackTop100Password, FeedbackCapitalization},
+		{"zxcvbn", FeedbackStraightRow, FeedbackLongerKeyboard},
+		{"aaaaaaaa", FeedbackRepeatedChars, FeedbackAvoidRepeats},
+		{"abcabcabc", FeedbackRepeatedPattern, FeedbackAvoidRepeats},
+		{"abcdefgh", FeedbackSequence, FeedbackAvoidSequences},
+		{"1987", FeedbackRecentYears, FeedbackAvoidRecentYears},
+		{"04/12/1987", FeedbackDates, FeedbackAvoidDates},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.password, func(t *testing.T) {
+			s := EstimateStrength(tc.password)
+			if s.Warning != tc.warning {
+				t.Errorf("warning = %q, want %q", s.Warning, tc.warning)
+			}
+			if !slices.Contains(s.Suggestions, tc.suggestion) {
+				t.Errorf("suggestions = %v, want to contain %q", s.Suggestions, tc.suggestion)
+			}
+		})
+	}
+
+	// 评分较高时不给出反馈
+	if s := EstimateStrength("kj3$9fQ!zP2v"); s.Warning != "" || len(s.Suggestions) != 0 {
+		t.Errorf("expected no feedback for strong password, got %q %v", s.Warning, s.Suggestions)
+	}
+	// 空密码给出通用建议
+	if s := EstimateStrength(""); !slices.Contains(s.Suggestions, FeedbackUseFewWords) {
+		t.Errorf("expected generic suggestions for empty password, got %v", s.Suggestions)
+	}
+}
+
+func TestEstimateStrength_L33t(t *testing.T) {
+	plain := EstimateStrength("monkey")
+	subbed := EstimateStrength("m0nk3y")
+	if !slices.Contains(subbed.Suggestions, FeedbackL33t) {
+		t.Errorf("expected l33t suggestion, got %v", subbed.Suggestions)
+	}
+	if subbed.Guesses <= plain.Guesses {
+		t.Errorf("expected substitutions to add some guesses: %v <= %v", subbed.Guesses, plain.Guesses)
+	}
+	if subbed.Score > 1 {
+		t.Errorf("expected l33t common word to stay weak, got score %d", subbed.Score)
+	}
+}
+
+func TestEstimateStrength_UserInputs(t *testing.T) {
+	const pwd = "johndoe2024"
+	without := EstimateStrength(pwd)
+	with := EstimateStrength(pwd, "johndoe", "john.doe@example.com")
+
+	if with.Guesses >= without.Guesses {
+		t.Fatalf("expected user inputs to reduce guesses: %v >= %v", with.Guesses, without.Guesses)
+	}
+	if with.Warning != FeedbackUserInput {
+		t.Errorf("warning = %q, want %q", with.Warning, FeedbackUserInput)
+	}
+	if !slices.Contains(with.Suggestions, FeedbackAvoidPersonalInputs) {
+		t.Errorf("suggestions = %v, want to contain %q", with.Suggestions, FeedbackAvoidPersonalInputs)
+	}
+
+	// 邮箱按非字母数字字符拆分后的片段同样视为个人信息
+	if s := EstimateStrength("Example2024", "someone@example.com"); s.Score > 1 {
+		t.Errorf("expected email domain to be treated as personal input, got score %d", s.Score)
+	}
+}
+
+func TestEstimateStrength_LongInput(t *testing.T) {
+	s := EstimateStrength(strings.Repeat("a", 10000))
+	if s.Score > 1 {
+		t.Errorf("expected repeated long input to be weak, got score %d", s.Score)
+	}
+
+	s = EstimateStrength(strings.Repeat("kj3$9fQ!zP2v", 20))
+	if math.IsInf(s.Guesses, 0) || math.IsNaN(s.Guesses) || math.IsInf(s.GuessesLog10, 0) {
+		t.Errorf("expected finite guesses, got %v (log10 %v)", s.Guesses, s.GuessesLog10)
+	}
+}
+
+func TestStrengthRule(t *testing.T) {
+	rule := NewStrengthRule(3, "johndoe")
+
+	violations := rule.Check("Password1")
+	if len(violations) != 1 || violations[0].Code != CodeTooWeak {
+		t.Fatalf("expected too_weak violation, got %v", violations)
+	}
+	if violations[0].Params["min_score"] != 3 || violations[0].Params["score"] != 0 {
+		t.Errorf("unexpected params: %v", violations[0].Params)
+	}
+
+	if violations := rule.Check("kj3$9fQ!zP2v"); len(violations) > 0 {
+		t.Errorf("expected ok, got violations: %v", violations)
+	}
+	if violations := rule.Check("Johndoe2024"); len(violations) == 0 {
+		t.Error("expected password built from user input to be rejected")
+	}
+}
+
+func TestFeedbackMessage(t *testing.T) {
+	if got := FeedbackMessage(i18n.LocaleEN, FeedbackSequence); !strings.Contains(got, "Sequences") {
+		t.Errorf("unexpected en message: %q", got)
+	}
+	if got := FeedbackMessage(i18n.LocaleZH, FeedbackSequence); !strings.Contains(got, "序列") {
+		t.Errorf("unexpected zh message: %q", got)
+	}
+}
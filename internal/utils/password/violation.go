@@ -0,0 +1,92 @@
+// Package password 密码规则违规
+package password
+
+import (
+	"strings"
+
+	"hello-gozero/pkg/i18n"
+)
+
+// Violation 密码违反的一条约束
+type Violation struct {
+	// 稳定的违规代码，如 [CodeTooShort]
+	Code string
+
+	// 违规参数，如 too_short 的 {"min": 8}，用于渲染提示文案
+	Params map[string]any
+}
+
+// Message 按语言渲染违规提示文案（见 [i18n.Translate]）
+func (v Violation) Message(locale i18n.Locale) string {
+	return i18n.Translate(locale, messageKeyPrefix+v.Code, v.Params)
+}
+
+// PolicyError 密码不符合密码策略，包含违反的所有约束
+type PolicyError struct {
+	Violations []Violation
+}
+
+// Error 以英文列出所有违规，需要按调用方语言展示时使用 [Violation.Message]
+func (e *PolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message(i18n.LocaleEN))
+	}
+	return "password does not meet the policy: " + strings.Join(messages, "; ")
+}
+
+// messageKeyPrefix 违规提示文案在 i18n 中的消息键前缀
+const messageKeyPrefix = "password.violation."
+
+func init() {
+	i18n.Register(i18n.Messages{
+		messageKeyPrefix + CodeTooShort: {
+			i18n.LocaleEN: "must be at least {min} characters long",
+			i18n.LocaleZH: "密码长度不能小于{min}位",
+		},
+		messageKeyPrefix + CodeTooLong: {
+			i18n.LocaleEN: "must be at most {max} characters long",
+			i18n.LocaleZH: "密码长度不能大于{max}位",
+		},
+		messageKeyPrefix + CodeMissingDigit: {
+			i18n.LocaleEN: "must contain a digit",
+			i18n.LocaleZH: "密码必须包含数字",
+		},
+		messageKeyPrefix + CodeMissingUpper: {
+			i18n.LocaleEN: "must contain an uppercase letter",
+			i18n.LocaleZH: "密码必须包含大写字母",
+		},
+		messageKeyPrefix + CodeMissingLower: {
+			i18n.LocaleEN: "must contain a lowercase letter",
+			i18n.LocaleZH: "密码必须包含小写字母",
+		},
+		messageKeyPrefix + CodeMissingSymbol: {
+			i18n.LocaleEN: "must contain a symbol ({symbols})",
+			i18n.LocaleZH: "密码必须包含特殊符号（{symbols}）",
+		},
+		messageKeyPrefix + CodeDigitsOrLetters: {
+			i18n.LocaleEN: "must not consist of digits or letters only",
+			i18n.LocaleZH: "密码不能为纯数字或纯字母",
+		},
+		messageKeyPrefix + CodeConsecutiveChars: {
+			i18n.LocaleEN: "must not be a sequence of consecutive digits or letters",
+			i18n.LocaleZH: "密码不能包含连续的数字或字母",
+		},
+		messageKeyPrefix + CodeRepeatedChars: {
+			i18n.LocaleEN: "must not repeat a single character",
+			i18n.LocaleZH: "密码不能包含重复的字符",
+		},
+		messageKeyPrefix + CodeTooWeak: {
+			i18n.LocaleEN: "is too easy to guess (strength {score}, at least {min_score} required)",
+			i18n.LocaleZH: "密码太容易被猜到（强度评分 {score}，至少需要 {min_score}）",
+		},
+		messageKeyPrefix + CodeReused: {
+			i18n.LocaleEN: "must not match any of your last {count} passwords",
+			i18n.LocaleZH: "密码不能与最近 {count} 次使用过的密码相同",
+		},
+		messageKeyPrefix + CodeBreached: {
+			i18n.LocaleEN: "is too common or has appeared in a data breach",
+			i18n.LocaleZH: "密码过于常见或已在数据泄露中出现",
+		},
+	})
+}
@@ -0,0 +1,165 @@
+// Package token 提供访问令牌（JWT）与刷新令牌的生成和校验工具
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// MinSecretLength 访问令牌签名密钥的最小长度（HS256 建议不少于 256 bit）
+const MinSecretLength = 32
+
+// 错误定义
+var (
+	ErrEmptySecret    = errors.New("token secret cannot be empty")         // 签名密钥为空
+	ErrSecretTooShort = errors.New("token secret length is insufficient")  // 签名密钥长度不足
+	ErrInvalidToken   = errors.New("invalid token")                        // 令牌非法（签名错误、格式错误等）
+	ErrTokenExpired   = errors.New("token is expired")                     // 令牌已过期
+	ErrEmptySubject   = errors.New("token subject must contain a user id") // 签发主体缺少用户 ID
+)
+
+// Subject 令牌签发主体，即令牌所代表的用户身份
+type Subject struct {
+	// 用户 ID（UUID 字符串）
+	UserID string
+
+	// 用户名
+	Username string
+
+	// 会话 ID，同一次登录签发的访问令牌与刷新令牌共享同一个会话 ID
+	SessionID string
+
+	// 用户所属租户 ID，为 0 表示令牌不区分租户（引入多租户之前签发）
+	TenantID uint64
+}
+
+// Claims 访问令牌中携带的声明
+type Claims struct {
+	Username  string `json:"username"`
+	SessionID string `json:"sid"`
+	TenantID  uint64 `json:"tid,omitempty"`
+
+	jwt.RegisteredClaims
+}
+
+// Subject 从声明中还原签发主体
+func (c *Claims) Subject() Subject {
+	return Subject{
+		UserID:    c.RegisteredClaims.Subject,
+		Username:  c.Username,
+		SessionID: c.SessionID,
+		TenantID:  c.TenantID,
+	}
+}
+
+// Manager 访问令牌管理接口
+type Manager interface {
+	// GenerateAccessToken 为指定主体签发访问令牌
+	// 返回：令牌字符串 | 过期时间 | 错误
+	GenerateAccessToken(subject Subject) (string, time.Time, error)
+
+	// ParseAccessToken 校验访问令牌的签名、签发者和有效期，并返回其中的声明
+	// 令牌过期时返回 [ErrTokenExpired]，其他校验失败返回 [ErrInvalidToken]
+	ParseAccessToken(token string) (*Claims, error)
+
+	// AccessTTL 返回访问令牌的有效期
+	AccessTTL() time.Duration
+}
+
+// jwtManager 基于 HS256 的 [Manager] 实现
+type jwtManager struct {
+	secret    []byte
+	issuer    string
+	accessTTL time.Duration
+
+	// now 便于测试时替换当前时间
+	now func() time.Time
+}
+
+// NewJWTManager 创建访问令牌管理器
+// 参数：
+//
+//	secret    - 签名密钥（必填，至少 32 个字符）
+//	issuer    - 签发者标识，解析时会校验
+//	accessTTL - 访问令牌有效期
+func NewJWTManager(secret, issuer string, accessTTL time.Duration) (Manager, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("%w: minimum required %d characters, current length: %d", ErrSecretTooShort, MinSecretLength, len(secret))
+	}
+	if accessTTL <= 0 {
+		accessTTL = 15 * time.Minute
+	}
+
+	return &jwtManager{
+		secret:    []byte(secret),
+		issuer:    issuer,
+		accessTTL: accessTTL,
+		now:       time.Now,
+	}, nil
+}
+
+// GenerateAccessToken Implements [Manager.GenerateAccessToken]
+func (m *jwtManager) GenerateAccessToken(subject Subject) (string, time.Time, error) {
+	if subject.UserID == "" {
+		return "", time.Time{}, ErrEmptySubject
+	}
+
+	now := m.now()
+	expiresAt := now.Add(m.accessTTL)
+	claims := &Claims{
+		Username:  subject.Username,
+		SessionID: subject.SessionID,
+		TenantID:  subject.TenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    m.issuer,
+			Subject:   subject.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseAccessToken Implements [Manager.ParseAccessToken]
+func (m *jwtManager) ParseAccessToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	_, err := parser.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	})
+	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	// 校验签发者，避免接受其他服务（可能共享了密钥）签发的令牌
+	if !claims.VerifyIssuer(m.issuer, m.issuer != "") {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if claims.RegisteredClaims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// AccessTTL Implements [Manager.AccessTTL]
+func (m *jwtManager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
@@ -0,0 +1,114 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestNewJWTManager_SecretValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		secret  string
+		wantErr error
+	}{
+		{"empty secret", "", ErrEmptySecret},
+		{"short secret", "too-short", ErrSecretTooShort},
+		{"valid secret", testSecret, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewJWTManager(tc.secret, "issuer", time.Minute)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("NewJWTManager(%q) err = %v, want %v", tc.secret, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTManager_RoundTrip(t *testing.T) {
+	m, err := NewJWTManager(testSecret, "hello-gozero", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	subject := Subject{UserID: "0192f3a4-0000-7000-8000-000000000001", Username: "alice", SessionID: "sid-1", TenantID: 2}
+	signed, expiresAt, err := m.GenerateAccessToken(subject)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken err: %v", err)
+	}
+	if time.Until(expiresAt) > time.Minute || time.Until(expiresAt) <= 0 {
+		t.Fatalf("unexpected expiresAt: %v", expiresAt)
+	}
+
+	claims, err := m.ParseAccessToken(signed)
+	if err != nil {
+		t.Fatalf("ParseAccessToken err: %v", err)
+	}
+	if got := claims.Subject(); got != subject {
+		t.Fatalf("Subject() = %+v, want %+v", got, subject)
+	}
+}
+
+func TestJWTManager_RejectsInvalidTokens(t *testing.T) {
+	m, _ := NewJWTManager(testSecret, "hello-gozero", time.Minute)
+	other, _ := NewJWTManager(strings.Repeat("x", MinSecretLength), "hello-gozero", time.Minute)
+	otherIssuer, _ := NewJWTManager(testSecret, "someone-else", time.Minute)
+
+	subject := Subject{UserID: "user-id", Username: "alice"}
+	signedByOther, _, _ := other.GenerateAccessToken(subject)
+	signedByOtherIssuer, _, _ := otherIssuer.GenerateAccessToken(subject)
+
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"garbage", "not-a-jwt"},
+		{"wrong secret", signedByOther},
+		{"wrong issuer", signedByOtherIssuer},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := m.ParseAccessToken(tc.token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("ParseAccessToken err = %v, want %v", err, ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func TestJWTManager_Expired(t *testing.T) {
+	mgr, _ := NewJWTManager(testSecret, "hello-gozero", time.Minute)
+	m := mgr.(*jwtManager)
+	m.now = func() time.Time { return time.Now().Add(-2 * time.Minute) }
+
+	signed, _, err := m.GenerateAccessToken(Subject{UserID: "user-id"})
+	if err != nil {
+		t.Fatalf("GenerateAccessToken err: %v", err)
+	}
+
+	if _, err := m.ParseAccessToken(signed); !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("ParseAccessToken err = %v, want %v", err, ErrTokenExpired)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	a, err := NewRefreshToken()
+	if err != nil {
+		t.Fatalf("NewRefreshToken err: %v", err)
+	}
+	b, _ := NewRefreshToken()
+	if a == b {
+		t.Fatal("refresh tokens must be unique")
+	}
+	if HashRefreshToken(a) != HashRefreshToken(a) {
+		t.Fatal("HashRefreshToken must be deterministic")
+	}
+	if HashRefreshToken(a) == HashRefreshToken(b) {
+		t.Fatal("different tokens must hash differently")
+	}
+}
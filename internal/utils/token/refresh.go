@@ -0,0 +1,29 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// refreshTokenBytes 刷新令牌的随机字节数（256 bit）
+const refreshTokenBytes = 32
+
+// NewRefreshToken 生成一个不透明的随机刷新令牌
+// 刷新令牌本身不携带任何信息，服务端通过其哈希值在存储中查找对应的会话
+func NewRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashRefreshToken 计算刷新令牌的 SHA-256 摘要，存储层只保存摘要，避免存储泄露后令牌被直接使用
+// 刷新令牌是高熵随机值，无需使用 bcrypt 等慢哈希
+func HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package token
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestNewNumericCode(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9]{6}$`)
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		code, err := NewNumericCode(6)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !pattern.MatchString(code) {
+			t.Fatalf("code %q is not 6 digits", code)
+		}
+		seen[code] = struct{}{}
+	}
+	if len(seen) < 90 {
+		t.Fatalf("codes are not random enough: %d unique of 100", len(seen))
+	}
+}
+
+func TestNewNumericCode_InvalidLength(t *testing.T) {
+	for _, digits := range []int{0, 3, 11} {
+		if _, err := NewNumericCode(digits); !errors.Is(err, ErrInvalidCodeLength) {
+			t.Fatalf("NewNumericCode(%d) err = %v, want %v", digits, err, ErrInvalidCodeLength)
+		}
+	}
+}
+
+func TestCompareVerifyCode(t *testing.T) {
+	hash := HashVerifyCode("Alice@Example.com", "123456")
+
+	if !CompareVerifyCode("alice@example.com", "123456", hash) {
+		t.Fatal("expected code to match (target is case insensitive)")
+	}
+	if CompareVerifyCode("alice@example.com", "654321", hash) {
+		t.Fatal("expected wrong code to mismatch")
+	}
+	if CompareVerifyCode("bob@example.com", "123456", hash) {
+		t.Fatal("expected code for another target to mismatch")
+	}
+}
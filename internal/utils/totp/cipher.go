@@ -0,0 +1,76 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"hello-gozero/internal/utils/password"
+)
+
+// cipherKeyLabel 派生加密密钥时使用的用途标签，保证加密密钥与同一 pepper 派生的其他密钥互相隔离
+const cipherKeyLabel = "totp-secret-encryption"
+
+// ErrDecryptFailed 密文无法解密（格式错误、被篡改或密钥不匹配）
+var ErrDecryptFailed = errors.New("failed to decrypt totp secret")
+
+// SecretCipher TOTP 密钥的加密工具，用于在数据库中加密保存密钥
+type SecretCipher interface {
+	// Encrypt 加密密钥，返回 base64 编码的密文（每次加密使用随机 nonce，相同明文的密文也不相同）
+	Encrypt(secret string) (string, error)
+
+	// Decrypt 解密 [SecretCipher.Encrypt] 生成的密文
+	Decrypt(ciphertext string) (string, error)
+}
+
+// secretCipher 基于 AES-256-GCM 的 [SecretCipher] 实现
+type secretCipher struct {
+	aead cipher.AEAD
+}
+
+// NewSecretCipher 创建密钥加密工具
+// 加密密钥沿用 [password.PasswordCryptoTool] 的 pepper 方案：由仅保存在后端的 pepper 经 HMAC-SHA256 派生
+func NewSecretCipher(pepperKey string) (SecretCipher, error) {
+	if err := password.CheckPepper(pepperKey); err != nil {
+		return nil, err
+	}
+
+	key := password.HMACWithPepper(pepperKey, []byte(cipherKeyLabel))
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aes cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %w", err)
+	}
+	return &secretCipher{aead: aead}, nil
+}
+
+// Encrypt Implements [SecretCipher.Encrypt]
+// 密文格式：base64(nonce || ciphertext || tag)
+func (c *secretCipher) Encrypt(secret string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt Implements [SecretCipher.Decrypt]
+func (c *secretCipher) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrDecryptFailed
+	}
+	nonce, data := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", ErrDecryptFailed
+	}
+	return string(plain), nil
+}
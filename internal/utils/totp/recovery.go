@@ -0,0 +1,43 @@
+package totp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+)
+
+const (
+	// recoveryCodeAlphabet 恢复码字符集，去掉了容易混淆的 0/o、1/l/i
+	recoveryCodeAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+
+	// recoveryCodeGroupSize 恢复码每组字符数，两组之间以 "-" 分隔，如 "a3kx9-7mq2p"
+	recoveryCodeGroupSize = 5
+)
+
+// GenerateRecoveryCodes 生成 n 个随机恢复码
+// 恢复码在身份验证器丢失时代替动态口令使用，每个只能使用一次
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	buf := make([]byte, recoveryCodeGroupSize*2)
+	for len(codes) < n {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		var sb strings.Builder
+		for i, b := range buf {
+			if i == recoveryCodeGroupSize {
+				sb.WriteByte('-')
+			}
+			// 字符集长度为 31，取模带来的偏差可以忽略
+			sb.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+		codes = append(codes, sb.String())
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode 规范化用户输入的恢复码（忽略大小写、空格与分隔符），用于计算摘要
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,172 @@
+package totp
+
+import (
+	"encoding/base32"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录 B 的测试向量（HMAC-SHA1，8 位）
+func TestGenerateCode_RFC6238(t *testing.T) {
+	key := []byte("12345678901234567890")
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tc := range cases {
+		got := generateCode(key, step(time.Unix(tc.unix, 0)), 8)
+		if got != tc.want {
+			t.Errorf("generateCode(t=%d) = %s, want %s", tc.unix, got, tc.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+
+	code, err := GenerateCode(secret, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// RFC 向量 14050471 的后 6 位
+	if code != "050471" {
+		t.Fatalf("code = %s, want 050471", code)
+	}
+
+	if s, ok := Validate(secret, code, now); !ok || s != step(now) {
+		t.Fatalf("Validate() = %d, %v, want %d, true", s, ok, step(now))
+	}
+	// 时钟偏差一个时间步以内仍然有效
+	if _, ok := Validate(secret, code, now.Add(Period*time.Second)); !ok {
+		t.Fatal("expected code to be valid in the next step")
+	}
+	if _, ok := Validate(secret, code, now.Add(-Period*time.Second)); !ok {
+		t.Fatal("expected code to be valid in the previous step")
+	}
+	// 超出偏差范围
+	if _, ok := Validate(secret, code, now.Add(3*Period*time.Second)); ok {
+		t.Fatal("expected code to be invalid after the skew window")
+	}
+	if _, ok := Validate(secret, "123", now); ok {
+		t.Fatal("expected short code to be invalid")
+	}
+	if _, ok := Validate("not base32!", code, now); ok {
+		t.Fatal("expected invalid secret to fail")
+	}
+	// 小写与空格不影响密钥解析
+	if _, ok := Validate(strings.ToLower(secret[:8])+" "+secret[8:], code, now); !ok {
+		t.Fatal("expected lowercase secret with spaces to be accepted")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		t.Fatalf("generated secret is not valid base32: %v", err)
+	}
+	if len(key) != secretSize {
+		t.Fatalf("key length = %d, want %d", len(key), secretSize)
+	}
+	if _, err := GenerateCode("", time.Now()); !errors.Is(err, ErrInvalidSecret) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidSecret)
+	}
+}
+
+func TestKeyURI(t *testing.T) {
+	uri := KeyURI("Hello GoZero", "alice", "JBSWY3DPEHPK3PXP")
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("invalid uri: %v", err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Fatalf("unexpected uri: %s", uri)
+	}
+	if u.Path != "/Hello GoZero:alice" {
+		t.Fatalf("label = %q", u.Path)
+	}
+	q := u.Query()
+	if q.Get("secret") != "JBSWY3DPEHPK3PXP" || q.Get("issuer") != "Hello GoZero" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Fatalf("unexpected query: %v", q)
+	}
+}
+
+func TestSecretCipher(t *testing.T) {
+	c, err := NewSecretCipher("0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	enc1, err := c.Encrypt("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	enc2, _ := c.Encrypt("JBSWY3DPEHPK3PXP")
+	if enc1 == enc2 {
+		t.Fatal("expected ciphertexts of the same secret to differ")
+	}
+	if strings.Contains(enc1, "JBSWY3DPEHPK3PXP") {
+		t.Fatal("ciphertext contains the plaintext")
+	}
+
+	plain, err := c.Decrypt(enc1)
+	if err != nil || plain != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("Decrypt() = %q, %v", plain, err)
+	}
+
+	// 篡改密文
+	tampered := []byte(enc1)
+	tampered[len(tampered)/2] ^= 0x01
+	if _, err := c.Decrypt(string(tampered)); !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("err = %v, want %v", err, ErrDecryptFailed)
+	}
+
+	// 不同的 pepper 无法解密
+	other, _ := NewSecretCipher("fedcba9876543210fedcba9876543210")
+	if _, err := other.Decrypt(enc1); !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("err = %v, want %v", err, ErrDecryptFailed)
+	}
+
+	if _, err := NewSecretCipher("short"); err == nil {
+		t.Fatal("expected error for short pepper")
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("got %d codes, want 10", len(codes))
+	}
+	seen := make(map[string]struct{})
+	for _, code := range codes {
+		if len(code) != recoveryCodeGroupSize*2+1 || code[recoveryCodeGroupSize] != '-' {
+			t.Fatalf("unexpected code format: %q", code)
+		}
+		seen[code] = struct{}{}
+	}
+	if len(seen) != len(codes) {
+		t.Fatal("recovery codes are not unique")
+	}
+
+	if got := NormalizeRecoveryCode(" A3KX9-7MQ2P "); got != "a3kx97mq2p" {
+		t.Fatalf("NormalizeRecoveryCode() = %q", got)
+	}
+}
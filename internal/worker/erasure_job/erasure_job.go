@@ -0,0 +1,44 @@
+// Package erasurejob 个人数据擦除后台任务
+package erasurejob
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"hello-gozero/internal/service/erasure"
+	"hello-gozero/internal/worker"
+)
+
+// ErasureTask 继续处理未完成的个人数据擦除，需要通过 [worker.NewScheduledWorker] 定时执行
+//
+// 擦除请求在接口中同步处理，中途失败或实例退出时擦除记录保留在数据库中，由该任务从未完成的步骤继续，直到全部完成
+type ErasureTask struct {
+	logger logx.Logger
+	eraser *erasure.Eraser
+}
+
+// NewErasureTask 创建个人数据擦除任务
+func NewErasureTask(eraser *erasure.Eraser, logger logx.Logger) worker.ScheduledTask {
+	return &ErasureTask{
+		logger: logger,
+		eraser: eraser,
+	}
+}
+
+// Execute Implements [worker.ScheduledTask.Execute]
+// 每次执行依次处理所有已到达重试时间的擦除，失败的擦除至少等待一个检查间隔再重试
+func (t *ErasureTask) Execute(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		processed, err := t.eraser.ProcessNext(ctx)
+		if err != nil {
+			return err
+		}
+		if !processed {
+			return nil
+		}
+	}
+}
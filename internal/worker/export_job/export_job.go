@@ -0,0 +1,44 @@
+// Package exportjob 个人数据导出后台任务
+package exportjob
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"hello-gozero/internal/service/dataexport"
+	"hello-gozero/internal/worker"
+)
+
+// DataExportTask 处理个人数据导出任务，需要通过 [worker.NewScheduledWorker] 定时执行
+//
+// 每次执行依次处理所有待处理的任务，然后清理过期的归档；任务状态保存在数据库中，实例重启后由其他实例继续处理
+type DataExportTask struct {
+	logger   logx.Logger
+	exporter *dataexport.Exporter
+}
+
+// NewDataExportTask 创建个人数据导出任务
+func NewDataExportTask(exporter *dataexport.Exporter, logger logx.Logger) worker.ScheduledTask {
+	return &DataExportTask{
+		logger:   logger,
+		exporter: exporter,
+	}
+}
+
+// Execute Implements [worker.ScheduledTask.Execute]
+func (t *DataExportTask) Execute(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		processed, err := t.exporter.ProcessNext(ctx)
+		if err != nil {
+			return err
+		}
+		if !processed {
+			break
+		}
+	}
+	return t.exporter.CleanupExpired(ctx)
+}
@@ -4,21 +4,19 @@ package userevent
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/zeromicro/go-zero/core/logx"
 
+	"hello-gozero/internal/event"
 	userRepo "hello-gozero/internal/repository/user"
+	"hello-gozero/internal/tenant"
 	kafkaconsumer "hello-gozero/internal/worker/kafka_consumer"
 )
 
-// UserEvent 用户事件结构
-type UserEvent struct {
-	EventType string                 `json:"event_type"` // 事件类型：user_registered, user_updated, user_deleted
-	UserID    string                 `json:"user_id"`    // UUID 格式的用户 ID
-	Data      map[string]interface{} `json:"data"`
-	Timestamp int64                  `json:"timestamp"`
-}
+// UserEvent 用户事件结构，见 [event.UserEvent]
+type UserEvent = event.UserEvent
 
 // UserEventHandler 用户事件消息处理器
 // 用于处理用户相关的事件消息，如：用户注册、用户更新等
@@ -47,27 +45,40 @@ func NewUserEventHandler(
 
 // Handle Implements [MessageHandler.Handle]
 func (h *UserEventHandler) Handle(ctx context.Context, message kafka.Message) error {
-	var event UserEvent
-	if err := json.Unmarshal(message.Value, &event); err != nil {
+	var userEvent UserEvent
+	if err := json.Unmarshal(message.Value, &userEvent); err != nil {
 		h.logger.WithContext(ctx).Errorf("Failed to unmarshal user event: %v, raw message: %s", err, string(message.Value))
 		return nil // 返回 nil 以提交 offset，避免重复消费无效消息
 	}
 
-	h.logger.WithContext(ctx).Infof("Processing user event: type=%s, user_id=%s", event.EventType, event.UserID)
+	h.logger.WithContext(ctx).Infof("Processing user event: type=%s, user_id=%s", userEvent.EventType, userEvent.UserID)
+
+	// 按事件所属租户访问用户仓库，引入多租户之前发布的事件没有租户，属于默认租户
+	tenantID := userEvent.TenantID
+	if tenantID == 0 {
+		tenantID = tenant.DefaultID
+	}
+	ctx = tenant.NewContext(ctx, tenant.Tenant{ID: tenantID})
 
 	// 根据事件类型处理不同的业务逻辑
-	switch event.EventType {
-	case "connection_test":
+	switch userEvent.EventType {
+	case event.TypeConnectionTest:
 		h.logger.WithContext(ctx).Info("Kafka connection test message received")
 		return nil
-	case "user_registered":
-		return h.handleUserRegistered(ctx, event)
-	case "user_updated":
-		return h.handleUserUpdated(ctx, event)
-	case "user_deleted":
-		return h.handleUserDeleted(ctx, event)
+	case event.TypeUserRegistered:
+		return h.handleUserRegistered(ctx, userEvent)
+	case event.TypeUserUpdated:
+		return h.handleUserUpdated(ctx, userEvent)
+	case event.TypeUserDeleted:
+		return h.handleUserDeleted(ctx, userEvent)
+	case event.TypeUserErased:
+		return h.handleUserErased(ctx, userEvent)
+	case event.TypeLoginLockout, event.TypeUserLocked, event.TypeUserUnlocked, event.TypeUserStatusChanged, event.TypeUserRestored, event.TypeUserDataExported, event.TypeUserRolesChanged, event.TypeUserInvited, event.TypeUsernameChanged:
+		// 安全事件由安全审计等外部系统订阅处理，这里只记录日志
+		h.logger.WithContext(ctx).Infof("Security event: type=%s, user_id=%s, data=%+v", userEvent.EventType, userEvent.UserID, userEvent.Data)
+		return nil
 	default:
-		h.logger.WithContext(ctx).Infof("Unknown event type: %s", event.EventType)
+		h.logger.WithContext(ctx).Infof("Unknown event type: %s", userEvent.EventType)
 		return nil
 	}
 }
@@ -124,14 +135,25 @@ func (h *UserEventHandler) handleUserRegistered(ctx context.Context, event UserE
 	return nil
 }
 
-// handleUserUpdated 处理用户更新事件
+// handleUserUpdated 处理用户更新事件，数据：username、fields（变更的字段名）
+//
+// 发布方已经使用延迟双删使缓存失效，这里再删除一次，兜底发布方第二次删除失败（如进程退出）的情况；
+// 删除缓存是幂等操作，重复消费没有副作用
 func (h *UserEventHandler) handleUserUpdated(ctx context.Context, event UserEvent) error {
-	h.logger.WithContext(ctx).Infof("User updated: user_id=%d, data=%+v", event.UserID, event.Data)
+	h.logger.WithContext(ctx).Infof("User updated: user_id=%s, data=%+v", event.UserID, event.Data)
 
-	// 示例业务逻辑：
-	// 1. 更新缓存
-	// 2. 同步到其他系统
-	// 3. 触发相关业务流程
+	username, _ := event.Data["username"].(string)
+	if username == "" {
+		h.logger.WithContext(ctx).Errorf("User updated event without username: user_id=%s", event.UserID)
+		return nil // 无效消息，提交 offset
+	}
+	if err := h.CachedUser.DeleteByUsername(ctx, username); err != nil {
+		return fmt.Errorf("failed to delete user cache for user(%s): %w", username, err)
+	}
+
+	// 其他示例业务逻辑：
+	// 1. 同步到其他系统
+	// 2. 触发相关业务流程
 	// etc.
 
 	return nil
@@ -149,3 +171,16 @@ func (h *UserEventHandler) handleUserDeleted(ctx context.Context, event UserEven
 
 	return nil
 }
+
+// handleUserErased 处理个人数据擦除事件
+// 擦除流程已经清理了本服务的数据库与缓存，这里只记录日志（不记录事件数据）
+func (h *UserEventHandler) handleUserErased(ctx context.Context, event UserEvent) error {
+	h.logger.WithContext(ctx).Infof("User erased: user_id=%s", event.UserID)
+
+	// 示例业务逻辑：
+	// 1. 按用户 ID 删除本地保存的用户资料副本
+	// 2. 删除或匿名化包含该用户信息的历史记录（如审计日志中的用户名）
+	// etc.
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// Package userpurge 已删除用户的彻底清理任务
+package userpurge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"hello-gozero/internal/config"
+	userRepo "hello-gozero/internal/repository/user"
+	"hello-gozero/internal/tenant"
+	"hello-gozero/internal/worker"
+)
+
+// PurgeDeletedUsersTask 彻底删除软删除时间超过保留期的用户，需要通过 [worker.NewScheduledWorker] 定时执行
+//
+// 每次执行分批删除直到没有过期用户，每批在一个事务中完成；多个实例同时执行时只会重复扫描，不会误删
+type PurgeDeletedUsersTask struct {
+	logger logx.Logger
+	user   userRepo.UserRepository
+	conf   config.UserPurgeConfig
+}
+
+// NewPurgeDeletedUsersTask 创建已删除用户清理任务
+func NewPurgeDeletedUsersTask(user userRepo.UserRepository, conf config.UserPurgeConfig, logger logx.Logger) worker.ScheduledTask {
+	return &PurgeDeletedUsersTask{
+		logger: logger,
+		user:   user,
+		conf:   conf,
+	}
+}
+
+// Execute Implements [worker.ScheduledTask.Execute]
+func (t *PurgeDeletedUsersTask) Execute(ctx context.Context) error {
+	if t.conf.RetentionDays <= 0 || t.conf.BatchSize <= 0 {
+		return nil
+	}
+	deletedBefore := time.Now().AddDate(0, 0, -t.conf.RetentionDays)
+	// 清理所有租户的已删除用户
+	ctx = tenant.WithAllTenants(ctx)
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		purged, err := t.user.PurgeDeleted(ctx, deletedBefore, t.conf.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to purge deleted users (purged %d before failure): %w", total, err)
+		}
+		total += purged
+		if purged < int64(t.conf.BatchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		t.logger.Infof("purged %d users deleted before %s", total, deletedBefore.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package i18n
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
 )
 
 type Locale string
@@ -32,3 +35,69 @@ func GetLocale(ctx context.Context) Locale {
 		return LocaleEN
 	}
 }
+
+// ParseAcceptLanguage 从 Accept-Language 请求头中选择第一个支持的语言，都不支持时返回 [LocaleEN]
+// 只按语言主标签匹配（如 zh、zh-TW、zh-Hans-CN 都匹配 [LocaleZH]），按请求头中的顺序选择，忽略 q 权重
+func ParseAcceptLanguage(header string) Locale {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(tag, "-")
+		switch strings.ToLower(primary) {
+		case "en":
+			return LocaleEN
+		case "zh":
+			return LocaleZH
+		}
+	}
+	return LocaleEN
+}
+
+// Messages 消息文本，按消息键与语言组织
+// 文本中的 `{name}` 占位符在渲染时替换为同名参数的值；每条消息至少需要提供 [LocaleEN] 版本，作为其他语言缺失时的回退
+type Messages map[string]map[Locale]string
+
+// catalog 已注册的消息
+var catalog = struct {
+	sync.RWMutex
+	messages Messages
+}{messages: make(Messages)}
+
+// Register 注册消息，通常在包初始化时调用；消息缺少 [LocaleEN] 版本时 panic
+func Register(messages Messages) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	for key, texts := range messages {
+		if _, ok := texts[LocaleEN]; !ok {
+			panic(fmt.Sprintf("i18n: message %q has no %s fallback", key, LocaleEN))
+		}
+		catalog.messages[key] = texts
+	}
+}
+
+// Translate 按语言渲染消息，语言缺失时回退到 [LocaleEN]，消息未注册时返回消息键
+func Translate(locale Locale, key string, params map[string]any) string {
+	catalog.RLock()
+	texts, ok := catalog.messages[key]
+	catalog.RUnlock()
+	if !ok {
+		return key
+	}
+
+	text, ok := texts[locale]
+	if !ok {
+		text = texts[LocaleEN]
+	}
+	if len(params) == 0 {
+		return text
+	}
+	replacements := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+// T 按上下文中的语言渲染消息，见 [Translate]
+func T(ctx context.Context, key string, params map[string]any) string {
+	return Translate(GetLocale(ctx), key, params)
+}
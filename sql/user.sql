@@ -1,6 +1,26 @@
 -- 使用/切换到指定数据库
 USE hello_gozero_db;
 
+-- ============================================================
+-- 租户（多租户隔离）
+-- ============================================================
+-- 请求通过请求头或子域名携带租户标识（code），用户名、邮箱、手机号在租户内唯一；
+-- 引入多租户之前的用户都属于默认租户（id = 1）
+DROP TABLE IF EXISTS `t_tenant`;
+
+CREATE TABLE `t_tenant` (
+  `id`      BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+  `code`    VARCHAR(50)   NOT NULL      COMMENT '租户标识（小写，用于请求头与子域名）',
+  `name`    VARCHAR(100)  DEFAULT ''    COMMENT '租户名称',
+  `status`  TINYINT       DEFAULT 1     COMMENT '状态：0-停用，1-正常',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  UNIQUE KEY `uk_code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='租户表';
+
+INSERT INTO `t_tenant` (`id`, `code`, `name`, `status`) VALUES (1, 'default', '默认租户', 1);
+
 -- 删除表（如果存在）
 DROP TABLE IF EXISTS `t_user`;
 
@@ -8,15 +28,19 @@ DROP TABLE IF EXISTS `t_user`;
 -- 仅当表不存在时创建（不会删除已有数据！）
 CREATE TABLE `t_user` (
   `id` BINARY(16) NOT NULL PRIMARY KEY COMMENT '用户ID (UUID，二进制存储)',
+  `tenant_id` BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '租户ID',
 
   `username`            VARCHAR(50)   NOT NULL      COMMENT '用户名',
   `password`            VARCHAR(255)  NOT NULL      COMMENT '加密密码',
   `email`               VARCHAR(100)  DEFAULT ''    COMMENT '邮箱',
+  `email_verified_at`   DATETIME      DEFAULT NULL  COMMENT '邮箱验证时间（为空表示未验证）',
   `phone_country_code`  VARCHAR(6)    NOT NULL      COMMENT '手机号国际区号（例如：+86）',
   `phone_number`        VARCHAR(20)   NOT NULL      COMMENT '手机号',
   `nickname`            VARCHAR(50)   DEFAULT ''    COMMENT '昵称',
-  `status`              TINYINT       DEFAULT 1     COMMENT '状态：0-禁用，1-正常',
+  `status`              TINYINT       DEFAULT 1     COMMENT '状态：0-禁用，1-正常，2-锁定，3-待验证，4-暂停',
+  `suspended_until`     DATETIME      DEFAULT NULL  COMMENT '暂停到期时间（仅暂停状态有值）',
   `last_login_time`     DATETIME      DEFAULT NULL  COMMENT '最后登录时间',
+  `password_changed_at` DATETIME      DEFAULT NULL  COMMENT '最后一次设置密码的时间（为空表示以创建时间为准）',
   
   `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
   `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
@@ -54,15 +78,19 @@ CREATE TABLE `t_user` (
 
 -- ✅ 正确写法：“索引表达式”。
 -- 类比理解：“只监控活着的用户名，死了的随便重名”。符合软删除业务中最常见且合理的唯一性需求。
+-- 唯一性限定在租户内：不同租户可以有相同的用户名、邮箱与手机号
 CREATE UNIQUE INDEX uk_username_active ON t_user (
+  tenant_id,
   (CASE WHEN deleted_at IS NULL THEN username ELSE NULL END)
 );
 
 CREATE UNIQUE INDEX uk_email_active ON t_user (
+  tenant_id,
   (CASE WHEN deleted_at IS NULL THEN email ELSE NULL END)
 );
 
 CREATE UNIQUE INDEX uk_phone_active ON t_user (
+  tenant_id,
   (CASE WHEN deleted_at IS NULL THEN phone_country_code ELSE NULL END),
   (CASE WHEN deleted_at IS NULL THEN phone_number ELSE NULL END)
 );
@@ -72,33 +100,278 @@ CREATE UNIQUE INDEX uk_phone_active ON t_user (
 -- ============================================================
 CREATE INDEX `idx_status` ON `t_user` (`status`);
 CREATE INDEX `idx_created_at` ON `t_user` (`created_at`);
+CREATE INDEX `idx_updated_at` ON `t_user` (`updated_at`);
 CREATE INDEX `idx_deleted_at` ON `t_user` (`deleted_at`);
 
+-- ============================================================
+-- 已有数据库升级
+-- ============================================================
+-- ALTER TABLE `t_user` ADD COLUMN `email_verified_at` DATETIME DEFAULT NULL COMMENT '邮箱验证时间（为空表示未验证）' AFTER `email`;
+-- CREATE INDEX `idx_updated_at` ON `t_user` (`updated_at`);
+-- ALTER TABLE `t_user` ADD COLUMN `password_changed_at` DATETIME DEFAULT NULL COMMENT '最后一次设置密码的时间（为空表示以创建时间为准）' AFTER `last_login_time`;
+-- ALTER TABLE `t_user` MODIFY COLUMN `status` TINYINT DEFAULT 1 COMMENT '状态：0-禁用，1-正常，2-锁定，3-待验证，4-暂停';
+-- ALTER TABLE `t_user` ADD COLUMN `suspended_until` DATETIME DEFAULT NULL COMMENT '暂停到期时间（仅暂停状态有值）' AFTER `status`;
+-- ALTER TABLE `t_user` ADD COLUMN `tenant_id` BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '租户ID' AFTER `id`;
+-- DROP INDEX `uk_username_active` ON `t_user`;
+-- DROP INDEX `uk_email_active` ON `t_user`;
+-- DROP INDEX `uk_phone_active` ON `t_user`;
+-- 然后按上面的定义重新创建三个唯一索引
+-- ALTER TABLE `t_user_erasure` ADD COLUMN `tenant_id` BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '租户ID（用户彻底删除后仍按它限定查询）' AFTER `user_id`;
+-- UPDATE `t_user_erasure` e JOIN `t_user` u ON u.`id` = e.`user_id` SET e.`tenant_id` = u.`tenant_id`;
+
 
 INSERT INTO `t_user` (
   `id`,
+  `tenant_id`,
   `username`,
   `password`,
   `email`,
+  `email_verified_at`,
   `phone_country_code`,
   `phone_number`,
   `nickname`,
   `status`,
+  `suspended_until`,
   `last_login_time`,
+  `password_changed_at`,
   `created_at`,
   `updated_at`,
   `deleted_at`
 ) VALUES (
   UNHEX(REPLACE(UUID(), '-', '')),          -- UUID 转为 BINARY(16)
+  1,                                        -- 默认租户
   'admin',
   '$2a$10$default_hashed_password_for_test',-- 示例密码哈希（实际应为 bcrypt/scrypt 等）
   'admin@example.com',
+  NOW(),
   '+86',
   '13800138000',
   '系统管理员',
   1,
   NULL,
+  NULL,
+  NOW(),
   NOW(),
   NOW(),
   NULL
-);
\ No newline at end of file
+);
+
+-- ============================================================
+-- 两步验证（TOTP）
+-- ============================================================
+DROP TABLE IF EXISTS `t_user_mfa`;
+
+CREATE TABLE `t_user_mfa` (
+  `user_id`         BINARY(16)    NOT NULL PRIMARY KEY COMMENT '用户ID (UUID，二进制存储)',
+  `totp_secret`     VARCHAR(255)  NOT NULL      COMMENT 'TOTP 密钥（AES-GCM 加密后的 base64 密文）',
+  `enabled`         TINYINT(1)    NOT NULL DEFAULT 0 COMMENT '是否已启用：0-待确认，1-已启用',
+  `last_used_step`  BIGINT        NOT NULL DEFAULT 0 COMMENT '最近一次使用的动态口令时间步（防重放）',
+  `confirmed_at`    DATETIME      DEFAULT NULL  COMMENT '确认启用时间',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户两步验证表';
+
+DROP TABLE IF EXISTS `t_user_mfa_recovery_code`;
+
+CREATE TABLE `t_user_mfa_recovery_code` (
+  `id`          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+  `user_id`     BINARY(16)    NOT NULL      COMMENT '用户ID (UUID，二进制存储)',
+  `code_hash`   CHAR(64)      NOT NULL      COMMENT '恢复码摘要（HMAC-SHA256，十六进制）',
+  `used_at`     DATETIME      DEFAULT NULL  COMMENT '使用时间（为空表示未使用）',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  UNIQUE KEY `uk_user_code` (`user_id`, `code_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='两步验证恢复码表';
+
+-- ============================================================
+-- 密码历史（禁止重复使用最近的密码）
+-- ============================================================
+DROP TABLE IF EXISTS `t_user_password_history`;
+
+CREATE TABLE `t_user_password_history` (
+  `id`             BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+  `user_id`        BINARY(16)    NOT NULL      COMMENT '用户ID (UUID，二进制存储)',
+  `password_hash`  VARCHAR(255)  NOT NULL      COMMENT '密码哈希（与 t_user.password 格式相同）',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  KEY `idx_user_id` (`user_id`, `id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户密码历史表';
+
+-- ============================================================
+-- 账户状态变更历史
+-- ============================================================
+DROP TABLE IF EXISTS `t_user_status_history`;
+
+CREATE TABLE `t_user_status_history` (
+  `id`               BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+  `user_id`          BINARY(16)    NOT NULL      COMMENT '用户ID (UUID，二进制存储)',
+  `from_status`      TINYINT       NOT NULL      COMMENT '变更前状态',
+  `to_status`        TINYINT       NOT NULL      COMMENT '变更后状态',
+  `suspended_until`  DATETIME      DEFAULT NULL  COMMENT '暂停到期时间（仅迁移到暂停状态时有值）',
+  `reason`           VARCHAR(255)  DEFAULT ''    COMMENT '变更原因',
+  `actor`            VARCHAR(50)   NOT NULL      COMMENT '操作人用户名（系统自动变更为 system）',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  KEY `idx_user_id` (`user_id`, `id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户状态变更历史表';
+
+-- ============================================================
+-- 用户名变更历史（原用户名在保留期内只能由原用户使用，按原用户名访问时重定向到当前用户名）
+-- ============================================================
+DROP TABLE IF EXISTS `t_username_history`;
+
+CREATE TABLE `t_username_history` (
+  `id`              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+  `tenant_id`       BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '租户ID',
+  `user_id`         BINARY(16)    NOT NULL      COMMENT '用户ID (UUID，二进制存储)',
+  `old_username`    VARCHAR(50)   NOT NULL      COMMENT '原用户名',
+  `new_username`    VARCHAR(50)   NOT NULL      COMMENT '新用户名',
+  `reserved_until`  DATETIME      NOT NULL      COMMENT '原用户名保留到期时间',
+  `actor`           VARCHAR(50)   NOT NULL      COMMENT '操作人用户名',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  KEY `idx_tenant_old_username` (`tenant_id`, `old_username`, `reserved_until`),
+  KEY `idx_user_id` (`user_id`, `id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户名变更历史表';
+
+-- ============================================================
+-- 个人数据导出任务（任务保存在数据库中，实例重启后由其他实例继续处理）
+-- ============================================================
+DROP TABLE IF EXISTS `t_user_data_export`;
+
+CREATE TABLE `t_user_data_export` (
+  `id`             BINARY(16)    NOT NULL PRIMARY KEY COMMENT '导出任务ID (UUID，二进制存储)',
+  `user_id`        BINARY(16)    NOT NULL      COMMENT '用户ID (UUID，二进制存储)',
+  `status`         TINYINT       NOT NULL DEFAULT 0 COMMENT '状态：0-等待处理，1-处理中，2-已完成，3-失败，4-已过期',
+  `attempts`       INT           NOT NULL DEFAULT 0 COMMENT '已尝试处理的次数',
+  `blob_key`       VARCHAR(255)  DEFAULT ''    COMMENT '归档在对象存储中的键',
+  `size`           BIGINT        NOT NULL DEFAULT 0 COMMENT '归档大小，单位字节',
+  `error`          VARCHAR(255)  DEFAULT ''    COMMENT '最近一次失败的原因',
+  `started_at`     DATETIME      DEFAULT NULL  COMMENT '最近一次开始处理的时间',
+  `completed_at`   DATETIME      DEFAULT NULL  COMMENT '完成时间',
+  `expires_at`     DATETIME      DEFAULT NULL  COMMENT '归档过期时间',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  KEY `idx_user_status` (`user_id`, `status`),
+  KEY `idx_status_created_at` (`status`, `created_at`),
+  KEY `idx_status_expires_at` (`status`, `expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='个人数据导出任务表';
+
+-- ============================================================
+-- 用户邀请（被邀请人通过带签名令牌的链接接受邀请并注册，撤销或接受后令牌立即失效）
+-- ============================================================
+DROP TABLE IF EXISTS `t_user_invitation`;
+
+CREATE TABLE `t_user_invitation` (
+  `id`           BINARY(16)      NOT NULL PRIMARY KEY COMMENT '邀请ID (UUID，二进制存储)',
+  `tenant_id`    BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '租户ID',
+  `email`        VARCHAR(100)    NOT NULL      COMMENT '被邀请人邮箱',
+  `role`         VARCHAR(50)     DEFAULT ''    COMMENT '接受后分配的角色（为空表示不分配）',
+  `invited_by`   VARCHAR(50)     NOT NULL      COMMENT '邀请人用户名',
+  `status`       TINYINT         NOT NULL DEFAULT 0 COMMENT '状态：0-等待接受，1-已接受，2-已撤销',
+  `user_id`      BINARY(16)      DEFAULT NULL  COMMENT '接受邀请后注册的用户ID',
+  `expires_at`   DATETIME        NOT NULL      COMMENT '过期时间',
+  `accepted_at`  DATETIME        DEFAULT NULL  COMMENT '接受时间',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  KEY `idx_tenant_status_email` (`tenant_id`, `status`, `email`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户邀请表';
+
+-- ============================================================
+-- 个人数据擦除记录（每个用户一条，记录擦除进度，中断后从未完成的步骤继续；完成后作为擦除凭证保留）
+-- ============================================================
+DROP TABLE IF EXISTS `t_user_erasure`;
+
+CREATE TABLE `t_user_erasure` (
+  `user_id`         BINARY(16)    NOT NULL PRIMARY KEY COMMENT '用户ID (UUID，二进制存储)',
+  `tenant_id`       BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '租户ID（用户彻底删除后仍按它限定查询）',
+  `status`          TINYINT       NOT NULL DEFAULT 0 COMMENT '状态：0-等待处理，1-处理中，2-已完成',
+  `step`            INT           NOT NULL DEFAULT 0 COMMENT '已完成的步骤数',
+  `attempts`        INT           NOT NULL DEFAULT 0 COMMENT '已尝试处理的次数',
+  `error`           VARCHAR(255)  DEFAULT ''    COMMENT '最近一次失败的原因',
+  `subjects`        TEXT                        COMMENT '擦除前的用户名与邮箱（JSON），擦除完成后清空',
+  `actor`           VARCHAR(50)   NOT NULL      COMMENT '操作人用户名',
+  `started_at`      DATETIME      DEFAULT NULL  COMMENT '最近一次开始处理的时间',
+  `next_attempt_at` DATETIME      DEFAULT NULL  COMMENT '失败后最早的重试时间',
+  `completed_at`    DATETIME      DEFAULT NULL  COMMENT '完成时间',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  KEY `idx_status_created_at` (`status`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='个人数据擦除记录表';
+
+-- ============================================================
+-- 角色与权限（RBAC）
+-- 权限编码必须与 internal/constant/rbac 中的常量一致；配置 Auth.Admins 中的用户不依赖此处的分配，始终拥有全部权限
+-- ============================================================
+DROP TABLE IF EXISTS `t_user_role`;
+DROP TABLE IF EXISTS `t_role_permission`;
+DROP TABLE IF EXISTS `t_permission`;
+DROP TABLE IF EXISTS `t_role`;
+
+CREATE TABLE `t_role` (
+  `id`           BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+  `name`         VARCHAR(50)   NOT NULL      COMMENT '角色名称',
+  `description`  VARCHAR(255)  DEFAULT ''    COMMENT '角色说明',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  UNIQUE KEY `uk_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='角色表';
+
+CREATE TABLE `t_permission` (
+  `id`           BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '自增ID',
+  `code`         VARCHAR(100)  NOT NULL      COMMENT '权限编码，格式为 资源:操作:范围（如 user:delete:any）',
+  `description`  VARCHAR(255)  DEFAULT ''    COMMENT '权限说明',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  UNIQUE KEY `uk_code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='权限表';
+
+CREATE TABLE `t_role_permission` (
+  `role_id`        BIGINT UNSIGNED NOT NULL COMMENT '角色ID',
+  `permission_id`  BIGINT UNSIGNED NOT NULL COMMENT '权限ID',
+  PRIMARY KEY (`role_id`, `permission_id`),
+  KEY `idx_permission_id` (`permission_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='角色权限关联表';
+
+CREATE TABLE `t_user_role` (
+  `user_id`      BINARY(16)      NOT NULL     COMMENT '用户ID (UUID，二进制存储)',
+  `role_id`      BIGINT UNSIGNED NOT NULL     COMMENT '角色ID',
+  `granted_by`   VARCHAR(50)     NOT NULL DEFAULT '' COMMENT '分配角色的操作人用户名',
+
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`user_id`, `role_id`),
+  KEY `idx_role_id` (`role_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户角色关联表';
+
+INSERT INTO `t_role` (`name`, `description`) VALUES
+  ('admin',   '管理员，拥有全部权限'),
+  ('auditor', '审计员，只能查看用户与角色');
+
+INSERT INTO `t_permission` (`code`, `description`) VALUES
+  ('user:list:any',    '查看全部用户（包括已删除用户）'),
+  ('user:update:any',  '修改任意用户资料'),
+  ('user:delete:any',  '删除任意用户'),
+  ('user:status:any',  '变更任意用户的账户状态'),
+  ('user:restore:any', '恢复已删除的用户'),
+  ('user:erase:any',   '擦除任意用户的个人数据'),
+  ('user:invite:any',  '邀请用户注册、查看与撤销邀请'),
+  ('role:read:any',    '查看角色与任意用户的角色、权限'),
+  ('role:assign:any',  '为其他用户分配角色');
+
+-- 管理员拥有全部权限
+INSERT INTO `t_role_permission` (`role_id`, `permission_id`)
+SELECT r.`id`, p.`id` FROM `t_role` r CROSS JOIN `t_permission` p WHERE r.`name` = 'admin';
+
+-- 审计员只读
+INSERT INTO `t_role_permission` (`role_id`, `permission_id`)
+SELECT r.`id`, p.`id` FROM `t_role` r JOIN `t_permission` p ON p.`code` IN ('user:list:any', 'role:read:any') WHERE r.`name` = 'auditor';
+
+-- 为初始管理员分配管理员角色
+INSERT INTO `t_user_role` (`user_id`, `role_id`, `granted_by`)
+SELECT u.`id`, r.`id`, 'system' FROM `t_user` u JOIN `t_role` r ON r.`name` = 'admin' WHERE u.`tenant_id` = 1 AND u.`username` = 'admin';